@@ -1,6 +1,7 @@
 package main
 
 import (
+	"os"
 	"path/filepath"
 
 	"github.com/pkg/errors"
@@ -13,6 +14,7 @@ import (
 var (
 	analyzeOpts struct {
 		gatherBundle string
+		output       string
 	}
 )
 
@@ -24,6 +26,14 @@ func newAnalyzeCmd() *cobra.Command {
 
 This command helps users to analyze the reasons for an installation that failed while bootstrapping.`,
 		Args: cobra.ExactArgs(0),
+		PreRunE: func(_ *cobra.Command, _ []string) error {
+			switch analyzeOpts.output {
+			case "text", "json", "junit":
+				return nil
+			default:
+				return errors.Errorf("invalid output %q", analyzeOpts.output)
+			}
+		},
 		Run: func(_ *cobra.Command, _ []string) {
 			gatherBundle := analyzeOpts.gatherBundle
 			if gatherBundle == "" {
@@ -36,12 +46,25 @@ This command helps users to analyze the reasons for an installation that failed
 			if !filepath.IsAbs(gatherBundle) {
 				gatherBundle = filepath.Join(rootOpts.dir, gatherBundle)
 			}
-			if err := service.AnalyzeGatherBundle(gatherBundle); err != nil {
+			report, err := service.Analyze(gatherBundle)
+			if err != nil {
 				logrus.Fatal(err)
 			}
+			switch analyzeOpts.output {
+			case "text":
+				report.Log()
+			case "json":
+				err = report.WriteJSON(os.Stdout)
+			case "junit":
+				err = report.WriteJUnit(os.Stdout)
+			}
+			if err != nil {
+				logrus.Fatal(errors.Wrap(err, "failed to write the analysis"))
+			}
 		},
 	}
 	cmd.PersistentFlags().StringVar(&analyzeOpts.gatherBundle, "file", "", "Filename of the bootstrap gather bundle; either absolute or relative to the assets directory")
+	cmd.PersistentFlags().StringVarP(&analyzeOpts.output, "output", "o", "text", "Format of the analysis (e.g. \"text | json | junit\"); json and junit are written to stdout")
 	return cmd
 }
 
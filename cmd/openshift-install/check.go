@@ -0,0 +1,187 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/installconfig"
+	"github.com/openshift/installer/pkg/asset/machines"
+	assetquota "github.com/openshift/installer/pkg/asset/quota"
+	"github.com/openshift/installer/pkg/quota"
+)
+
+var (
+	checkOpts struct {
+		output string
+	}
+)
+
+// check runs a check against the install config of the asset store and
+// returns its reports. The error is only returned when the check could not
+// be run.
+type check func(assetStore asset.Store) ([]quota.ConstraintReport, error)
+
+func newCheckCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "check",
+		Short: "Checks that the cluster of the install config can be installed in the platform account",
+		Long: `Run the pre-flight checks of "create cluster" against the install config,
+without creating anything, and print a pass/warn/fail report.`,
+		Args: cobra.ExactArgs(0),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+	cmd.PersistentFlags().StringVarP(&checkOpts.output, "output", "o", "text", "Format of the report (e.g. \"text | json\")")
+
+	cmd.AddCommand(newCheckTargetCmd(&cobra.Command{
+		Use:   "quota",
+		Short: "Checks that the quotas of the platform account can hold the cluster",
+	}, checkQuota))
+	cmd.AddCommand(newCheckTargetCmd(&cobra.Command{
+		Use:   "permissions",
+		Short: "Checks that the platform credentials have the permissions to create the cluster",
+	}, checkAssets(&installconfig.PlatformCredsCheck{}, &installconfig.PlatformPermsCheck{})))
+	cmd.AddCommand(newCheckTargetCmd(&cobra.Command{
+		Use:   "config",
+		Short: "Checks that the install config is valid and that its platform resources can be used",
+	}, checkConfig))
+	return cmd
+}
+
+func newCheckTargetCmd(cmd *cobra.Command, run check) *cobra.Command {
+	cmd.Args = cobra.ExactArgs(0)
+	cmd.PreRunE = func(_ *cobra.Command, _ []string) error {
+		switch checkOpts.output {
+		case "text", "json":
+			return nil
+		default:
+			return errors.Errorf("invalid output %q", checkOpts.output)
+		}
+	}
+	cmd.Run = func(_ *cobra.Command, _ []string) {
+		cleanup := setupFileHook(rootOpts.dir)
+		defer cleanup()
+
+		err := runCheckCmd(os.Stdout, rootOpts.dir, run)
+		if err != nil {
+			logrus.Fatal(err)
+		}
+	}
+	return cmd
+}
+
+func runCheckCmd(out io.Writer, directory string, run check) error {
+	assetStore, err := newAssetStore(directory)
+	if err != nil {
+		return errors.Wrap(err, "failed to create asset store")
+	}
+
+	reports, err := run(assetStore)
+	if err != nil {
+		return err
+	}
+
+	if err := quota.WriteReports(out, reports, checkOpts.output); err != nil {
+		return err
+	}
+	return quota.ReportsError(reports)
+}
+
+// fetchPreserved fetches the asset without consuming the install config,
+// or any of the other assets, from the directory.
+func fetchPreserved(assetStore asset.Store, a asset.Asset, preserved ...asset.WritableAsset) error {
+	preserved = append(preserved, &installconfig.InstallConfig{})
+	return errors.Wrapf(assetStore.Fetch(a, preserved...), "failed to fetch %s", a.Name())
+}
+
+// checkAssets returns a check generating each of the assets, which fail when
+// they return an error. The assets are generated even if they are in the
+// state file, so that they are checked against the current state of the
+// platform.
+func checkAssets(assets ...asset.Asset) check {
+	return func(assetStore asset.Store) ([]quota.ConstraintReport, error) {
+		reports := make([]quota.ConstraintReport, 0, len(assets))
+		for _, a := range assets {
+			parents := asset.Parents{}
+			for _, d := range a.Dependencies() {
+				if err := fetchPreserved(assetStore, d); err != nil {
+					return nil, err
+				}
+				parents.Add(d)
+			}
+			reports = append(reports, assetReport(a, a.Generate(parents)))
+		}
+		return reports, nil
+	}
+}
+
+// checkConfig checks the install config, which is validated when it is
+// fetched, and then the platform resources it refers to.
+func checkConfig(assetStore asset.Store) ([]quota.ConstraintReport, error) {
+	installConfig := &installconfig.InstallConfig{}
+	report := assetReport(installConfig, fetchPreserved(assetStore, installConfig))
+	if report.Result == quota.NotAvailable {
+		return []quota.ConstraintReport{report}, nil
+	}
+
+	reports, err := checkAssets(&installconfig.PlatformCredsCheck{}, &installconfig.PlatformProvisionCheck{})(assetStore)
+	if err != nil {
+		return nil, err
+	}
+	return append([]quota.ConstraintReport{report}, reports...), nil
+}
+
+// checkQuota reports the quota constraints of the cluster.
+func checkQuota(assetStore asset.Store) ([]quota.ConstraintReport, error) {
+	installConfig := &installconfig.InstallConfig{}
+	master := &machines.Master{}
+	worker := &machines.Worker{}
+	for _, a := range []asset.WritableAsset{installConfig, master, worker} {
+		if err := fetchPreserved(assetStore, a, master, worker); err != nil {
+			return nil, err
+		}
+	}
+
+	masters, err := master.Machines()
+	if err != nil {
+		return nil, err
+	}
+	workers, err := worker.MachineSets()
+	if err != nil {
+		return nil, err
+	}
+
+	reports, err := assetquota.Reports(installConfig, masters, workers)
+	if err != nil {
+		return nil, err
+	}
+	if reports == nil {
+		return []quota.ConstraintReport{{
+			For:     &quota.Constraint{Name: "quota"},
+			Result:  quota.Unknown,
+			Message: fmt.Sprintf("the quotas of the %s platform are not checked", installConfig.Config.Platform.Name()),
+		}}, nil
+	}
+	return reports, nil
+}
+
+// assetReport returns the report of the generation of an asset.
+func assetReport(a asset.Asset, err error) quota.ConstraintReport {
+	report := quota.ConstraintReport{
+		For:     &quota.Constraint{Name: a.Name()},
+		Result:  quota.Available,
+		Message: "passed",
+	}
+	if err != nil {
+		report.Result = quota.NotAvailable
+		report.Message = err.Error()
+	}
+	return report
+}
@@ -3,7 +3,6 @@ package main
 import (
 	"context"
 	"crypto/x509"
-	"io/ioutil"
 	"os"
 	"path/filepath"
 	"strings"
@@ -27,11 +26,13 @@ import (
 	configclient "github.com/openshift/client-go/config/clientset/versioned"
 	routeclient "github.com/openshift/client-go/route/clientset/versioned"
 	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/cluster"
 	"github.com/openshift/installer/pkg/asset/installconfig"
 	"github.com/openshift/installer/pkg/asset/logging"
 	assetstore "github.com/openshift/installer/pkg/asset/store"
 	targetassets "github.com/openshift/installer/pkg/asset/targets"
 	destroybootstrap "github.com/openshift/installer/pkg/destroy/bootstrap"
+	"github.com/openshift/installer/pkg/events"
 	"github.com/openshift/installer/pkg/gather/service"
 	timer "github.com/openshift/installer/pkg/metrics/timer"
 	"github.com/openshift/installer/pkg/types/baremetal"
@@ -59,6 +60,31 @@ var (
 		assets: targetassets.InstallConfig,
 	}
 
+	iamPolicyTarget = target{
+		name: "IAM Policy",
+		command: &cobra.Command{
+			Use:   "iam-policy",
+			Short: "Generates the policy with the permissions needed to create and destroy the cluster",
+			Long: `Generate the permissions the installer needs to create and destroy the
+cluster of the install config, in iam-policy.json: an IAM policy document on
+AWS, a custom role definition on Azure and the list of predefined roles to
+grant to the service account on GCP.`,
+		},
+		assets: targetassets.IAMPolicy,
+	}
+
+	signerCSRsTarget = target{
+		name: "Signer CSRs",
+		command: &cobra.Command{
+			Use:   "signer-csrs",
+			Short: "Generates the certificate signing requests of the user-provided CA keys",
+			Long: `Generate tls/<signer>.csr for each tls/<signer>.key that is provided
+without tls/<signer>.crt in the asset directory, for the CA of the signer to
+be signed out-of-band before the Ignition configs are created.`,
+		},
+		assets: targetassets.SignerCSRs,
+	}
+
 	manifestsTarget = target{
 		name: "Manifests",
 		command: &cobra.Command{
@@ -99,6 +125,10 @@ var (
 			// FIXME: add longer descriptions for our commands with examples for better UX.
 			// Long:  "",
 			PostRun: func(_ *cobra.Command, _ []string) {
+				if createClusterOpts.plan {
+					return
+				}
+
 				ctx := context.Background()
 
 				cleanup := setupFileHook(rootOpts.dir)
@@ -106,7 +136,7 @@ var (
 
 				// FIXME: pulling the kubeconfig and metadata out of the root
 				// directory is a bit cludgy when we already have them in memory.
-				config, err := clientcmd.BuildConfigFromFlags("", filepath.Join(rootOpts.dir, "auth", "kubeconfig"))
+				config, err := loadAdminKubeconfig(rootOpts.dir)
 				if err != nil {
 					logrus.Fatal(errors.Wrap(err, "loading kubeconfig"))
 				}
@@ -157,7 +187,12 @@ var (
 		assets: targetassets.Cluster,
 	}
 
-	targets = []target{installConfigTarget, manifestsTarget, ignitionConfigsTarget, clusterTarget, singleNodeIgnitionConfigTarget}
+	createClusterOpts struct {
+		plan   bool
+		resume bool
+	}
+
+	targets = []target{installConfigTarget, iamPolicyTarget, signerCSRsTarget, manifestsTarget, ignitionConfigsTarget, clusterTarget, singleNodeIgnitionConfigTarget}
 )
 
 // clusterCreateError defines a custom error type that would help identify where the error occurs
@@ -223,24 +258,41 @@ func newCreateCmd() *cobra.Command {
 		t.command.Run = runTargetCmd(t.assets...)
 		cmd.AddCommand(t.command)
 	}
+	cmd.AddCommand(newCreateMetadataCmd())
+
+	runCluster := clusterTarget.command.Run
+	clusterTarget.command.Run = func(cmd *cobra.Command, args []string) {
+		if createClusterOpts.plan {
+			runClusterPlanCmd(rootOpts.dir)
+			return
+		}
+		runCluster(cmd, args)
+	}
+	clusterTarget.command.Flags().BoolVar(&createClusterOpts.plan, "plan", false, "Show the infrastructure resources that each terraform stage would create, without creating them")
+	clusterTarget.command.Flags().BoolVar(&createClusterOpts.resume, "resume", false, "Continue creating the infrastructure from the first terraform stage that did not complete in a previous run")
 
 	return cmd
 }
 
 func runTargetCmd(targets ...asset.WritableAsset) func(cmd *cobra.Command, args []string) {
 	runner := func(directory string) error {
-		assetStore, err := assetstore.NewStore(directory)
+		assetStore, err := newAssetStore(directory)
 		if err != nil {
 			return errors.Wrap(err, "failed to create asset store")
 		}
 
 		for _, a := range targets {
-			err := assetStore.Fetch(a, targets...)
+			var err error
+			if c, ok := a.(*cluster.Cluster); ok && createClusterOpts.resume {
+				err = resumeCluster(assetStore, c, directory, targets...)
+			} else {
+				err = assetStore.Fetch(a, targets...)
+			}
 			if err != nil {
 				err = errors.Wrapf(err, "failed to fetch %s", a.Name())
 			}
 
-			if err2 := asset.PersistToFile(a, directory); err2 != nil {
+			if err2 := assetstore.PersistToFile(a, directory, storeCipher); err2 != nil {
 				err2 = errors.Wrapf(err2, "failed to write asset (%s) to disk", a.Name())
 				if err != nil {
 					logrus.Error(err2)
@@ -270,7 +322,24 @@ func runTargetCmd(targets ...asset.WritableAsset) func(cmd *cobra.Command, args
 			logrus.Infof(logging.LogCreatedFiles(cmd.Name(), rootOpts.dir, targets))
 		}
 
+		// Push the assets before waiting for the cluster to install, so
+		// that wait-for and gather can be run from other machines.
+		pushStoreBackend()
+	}
+}
+
+// resumeCluster fetches the dependencies of the cluster asset and continues
+// creating the cluster from the terraform stages completed in the directory.
+// None of the assets in preserved will be purged.
+func resumeCluster(assetStore asset.Store, c *cluster.Cluster, directory string, preserved ...asset.WritableAsset) error {
+	parents := asset.Parents{}
+	for _, d := range c.Dependencies() {
+		if err := assetStore.Fetch(d, preserved...); err != nil {
+			return errors.Wrapf(err, "failed to fetch dependency of %q", c.Name())
+		}
+		parents.Add(d)
 	}
+	return c.Resume(directory, parents)
 }
 
 // addRouterCAToClusterCA adds router CA to cluster CA in kubeconfig
@@ -288,7 +357,11 @@ func addRouterCAToClusterCA(ctx context.Context, config *rest.Config, directory
 
 	routerCrtBytes := []byte(caConfigMap.Data["ca-bundle.crt"])
 	kubeconfig := filepath.Join(directory, "auth", "kubeconfig")
-	kconfig, err := clientcmd.LoadFromFile(kubeconfig)
+	data, err := assetstore.ReadFile(kubeconfig, storeCipher)
+	if err != nil {
+		return errors.Wrap(err, "loading kubeconfig")
+	}
+	kconfig, err := clientcmd.Load(data)
 	if err != nil {
 		return errors.Wrap(err, "loading kubeconfig")
 	}
@@ -313,7 +386,11 @@ func addRouterCAToClusterCA(ctx context.Context, config *rest.Config, directory
 		newCA := append(routerCrtBytes, clusterCABytes...)
 		c.CertificateAuthorityData = newCA
 	}
-	if err := clientcmd.WriteToFile(*kconfig, kubeconfig); err != nil {
+	data, err = clientcmd.Write(*kconfig)
+	if err != nil {
+		return errors.Wrap(err, "writing kubeconfig")
+	}
+	if err := assetstore.WriteFile(kubeconfig, data, storeCipher); err != nil {
 		return errors.Wrap(err, "writing kubeconfig")
 	}
 	return nil
@@ -414,6 +491,7 @@ func waitForBootstrapConfigMap(ctx context.Context, client *kubernetes.Clientset
 	if err != nil {
 		return newBootstrapError(err)
 	}
+	events.New(events.BootstrapComplete, nil).Debug("Bootstrap is complete")
 	return nil
 }
 
@@ -424,7 +502,7 @@ func waitForInitializedCluster(ctx context.Context, config *rest.Config) error {
 	timeout := 40 * time.Minute
 
 	// Wait longer for baremetal, due to length of time it takes to boot
-	if assetStore, err := assetstore.NewStore(rootOpts.dir); err == nil {
+	if assetStore, err := newAssetStore(rootOpts.dir); err == nil {
 		if installConfig, err := assetStore.Load(&installconfig.InstallConfig{}); err == nil && installConfig != nil {
 			if installConfig.(*installconfig.InstallConfig).Config.Platform.Name() == baremetal.Name {
 				timeout = 60 * time.Minute
@@ -554,12 +632,23 @@ func logComplete(directory, consoleURL string) error {
 	}
 	kubeconfig := filepath.Join(absDir, "auth", "kubeconfig")
 	pwFile := filepath.Join(absDir, "auth", "kubeadmin-password")
-	pw, err := ioutil.ReadFile(pwFile)
+	pw, err := assetstore.ReadFile(pwFile, storeCipher)
 	if err != nil {
 		return err
 	}
-	logrus.Info("Install complete!")
-	logrus.Infof("To access the cluster as the system:admin user when using 'oc', run 'export KUBECONFIG=%s'", kubeconfig)
+	events.New(events.InstallComplete, map[string]interface{}{
+		"kubeconfig": kubeconfig,
+		"consoleURL": consoleURL,
+	}).Info("Install complete!")
+	encrypted, err := assetstore.IsEncryptedFile(kubeconfig)
+	if err != nil {
+		return err
+	}
+	if encrypted {
+		logrus.Infof("To access the cluster as the system:admin user when using 'oc', run 'openshift-install decrypt %s --output <kubeconfig>' to decrypt the admin kubeconfig, and then 'export KUBECONFIG=<kubeconfig>'", kubeconfig)
+	} else {
+		logrus.Infof("To access the cluster as the system:admin user when using 'oc', run 'export KUBECONFIG=%s'", kubeconfig)
+	}
 	logrus.Infof("Access the OpenShift web-console here: %s", consoleURL)
 	logrus.Infof("Login to the console with user: %q, and password: %q", "kubeadmin", pw)
 	return nil
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	assetstore "github.com/openshift/installer/pkg/asset/store"
+)
+
+var (
+	decryptOpts struct {
+		output string
+	}
+)
+
+func newDecryptCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "decrypt FILE",
+		Short: "Decrypt an asset encrypted at rest",
+		Long: `Decrypt an asset that was encrypted at rest, such as auth/kubeconfig, with the
+passphrase or PGP keyring configured in the environment, and write it to
+standard output or to --output.`,
+		Example: `
+# Use the encrypted admin kubeconfig with oc
+openshift-install decrypt auth/kubeconfig --output ~/.kube/mycluster
+export KUBECONFIG=~/.kube/mycluster`,
+		Args: cobra.ExactArgs(1),
+		Run: func(_ *cobra.Command, args []string) {
+			if err := runDecryptCmd(args[0], decryptOpts.output); err != nil {
+				logrus.Fatal(err)
+			}
+		},
+	}
+	cmd.Flags().StringVarP(&decryptOpts.output, "output", "o", "", "File to write the decrypted asset to, with mode 0600 (defaults to standard output)")
+	return cmd
+}
+
+func runDecryptCmd(path string, output string) error {
+	data, err := assetstore.ReadFile(path, storeCipher)
+	if err != nil {
+		return errors.Wrapf(err, "failed to read %s", path)
+	}
+	if output == "" {
+		_, err = os.Stdout.Write(data)
+		return err
+	}
+	return errors.Wrapf(ioutil.WriteFile(output, data, 0600), "failed to write %s", output)
+}
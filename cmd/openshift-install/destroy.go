@@ -1,14 +1,19 @@
 package main
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 
-	assetstore "github.com/openshift/installer/pkg/asset/store"
 	"github.com/openshift/installer/pkg/destroy"
 	_ "github.com/openshift/installer/pkg/destroy/alibabacloud"
 	_ "github.com/openshift/installer/pkg/destroy/aws"
@@ -20,9 +25,11 @@ import (
 	_ "github.com/openshift/installer/pkg/destroy/libvirt"
 	_ "github.com/openshift/installer/pkg/destroy/openstack"
 	_ "github.com/openshift/installer/pkg/destroy/ovirt"
+	"github.com/openshift/installer/pkg/destroy/providers"
 	quotaasset "github.com/openshift/installer/pkg/destroy/quota"
 	_ "github.com/openshift/installer/pkg/destroy/vsphere"
 	"github.com/openshift/installer/pkg/metrics/timer"
+	"github.com/openshift/installer/pkg/types"
 )
 
 func newDestroyCmd() *cobra.Command {
@@ -36,34 +43,107 @@ func newDestroyCmd() *cobra.Command {
 	}
 	cmd.AddCommand(newDestroyBootstrapCmd())
 	cmd.AddCommand(newDestroyClusterCmd())
+	cmd.AddCommand(newDestroyOrphansCmd())
 	return cmd
 }
 
+var (
+	destroyClusterOpts struct {
+		dryRun  bool
+		output  string
+		timeout time.Duration
+	}
+)
+
 func newDestroyClusterCmd() *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:   "cluster",
 		Short: "Destroy an OpenShift cluster",
-		Args:  cobra.ExactArgs(0),
+		Long: `Destroy the cluster described by metadata.json in the asset directory.
+
+With --dry-run, list the resources that would be deleted instead. Listing the
+resources is only supported on AWS, Azure and GCP.`,
+		Args: cobra.ExactArgs(0),
+		PreRunE: func(_ *cobra.Command, _ []string) error {
+			switch destroyClusterOpts.output {
+			case "text", "json":
+				return nil
+			default:
+				return errors.Errorf("invalid output %q", destroyClusterOpts.output)
+			}
+		},
 		Run: func(_ *cobra.Command, _ []string) {
 			cleanup := setupFileHook(rootOpts.dir)
 			defer cleanup()
 
-			err := runDestroyCmd(rootOpts.dir, os.Getenv("OPENSHIFT_INSTALL_REPORT_QUOTA_FOOTPRINT") == "true")
+			if destroyClusterOpts.dryRun {
+				err := runDestroyInventoryCmd(os.Stdout, rootOpts.dir, destroyClusterOpts.output)
+				if err != nil {
+					logrus.Fatal(err)
+				}
+				return
+			}
+
+			err := runDestroyCmd(os.Stdout, rootOpts.dir, os.Getenv("OPENSHIFT_INSTALL_REPORT_QUOTA_FOOTPRINT") == "true", destroyClusterOpts.timeout)
 			if err != nil {
 				logrus.Fatal(err)
 			}
 		},
 	}
+	cmd.PersistentFlags().BoolVar(&destroyClusterOpts.dryRun, "dry-run", false, "List the resources that would be deleted without deleting them (AWS, Azure and GCP only)")
+	cmd.PersistentFlags().StringVarP(&destroyClusterOpts.output, "output", "o", "text", "Format of the resource list printed by --dry-run (e.g. \"text | json\")")
+	cmd.PersistentFlags().DurationVar(&destroyClusterOpts.timeout, "timeout", 0, "Give up destroying the cluster after this long, printing the resources that were not destroyed as JSON (0 means no timeout)")
+	return cmd
 }
 
-func runDestroyCmd(directory string, reportQuota bool) error {
+// runDestroyInventoryCmd writes the resources that would be deleted by
+// destroying the cluster, grouped by type, to out.
+func runDestroyInventoryCmd(out io.Writer, directory string, output string) error {
+	inventory, err := destroy.Inventory(logrus.StandardLogger(), directory)
+	if err != nil {
+		return errors.Wrap(err, "Failed to list the resources to destroy")
+	}
+
+	if output == "json" {
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(inventory)
+	}
+
+	writeInventory(out, inventory, "")
+	logrus.Infof("%d resources would be destroyed", inventory.Len())
+	return nil
+}
+
+// writeInventory writes the resources of the inventory, grouped by type, to
+// out, with every line indented by indent.
+func writeInventory(out io.Writer, inventory providers.Inventory, indent string) {
+	for _, resourceType := range inventory.Types() {
+		fmt.Fprintf(out, "%s%s (%d):\n", indent, resourceType, len(inventory[resourceType]))
+		for _, id := range inventory[resourceType] {
+			fmt.Fprintf(out, "%s  %s\n", indent, id)
+		}
+	}
+}
+
+// runDestroyCmd destroys the cluster. If the timeout is hit first, the
+// resources that were not destroyed are written to out as JSON.
+func runDestroyCmd(out io.Writer, directory string, reportQuota bool, timeout time.Duration) error {
 	timer.StartTimer(timer.TotalTimeElapsed)
 	destroyer, err := destroy.New(logrus.StandardLogger(), directory)
 	if err != nil {
 		return errors.Wrap(err, "Failed while preparing to destroy cluster")
 	}
-	quota, err := destroyer.Run()
+	quota, err := runDestroyer(destroyer, timeout)
 	if err != nil {
+		var leftovers *providers.LeftoversError
+		if errors.As(err, &leftovers) {
+			encoder := json.NewEncoder(out)
+			encoder.SetIndent("", "  ")
+			if err := encoder.Encode(leftovers.Leftovers); err != nil {
+				logrus.Error(err)
+			}
+		}
 		return errors.Wrap(err, "Failed to destroy cluster")
 	}
 
@@ -73,7 +153,7 @@ func runDestroyCmd(directory string, reportQuota bool) error {
 		}
 	}
 
-	store, err := assetstore.NewStore(directory)
+	store, err := newAssetStore(directory)
 	if err != nil {
 		return errors.Wrap(err, "failed to create asset store")
 	}
@@ -110,6 +190,125 @@ func runDestroyCmd(directory string, reportQuota bool) error {
 	return nil
 }
 
+var (
+	destroyOrphansOpts struct {
+		platform string
+		find     providers.OrphanOptions
+		filter   destroy.OrphanFilter
+		yes      bool
+		output   string
+	}
+)
+
+func newDestroyOrphansCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "orphans",
+		Short: "Destroy clusters whose resources were left behind without a metadata.json",
+		Long: `Find the clusters created by the OpenShift installer that still own
+resources in a region, from the kubernetes.io/cluster/<infraID> tags (or the
+kubernetes-io-cluster-<infraID> labels on GCP, or the resource groups tagged
+kubernetes.io_cluster.<infraID> on Azure) of the resources, and list those
+created earlier than --older-than ago. With --yes, destroy them.
+
+Clusters whose age cannot be told, clusters without the resources that only
+the installer creates, clusters whose API server still accepts connections and
+clusters excluded with --exclude are never destroyed. Clusters whose API server
+address cannot be found are only destroyed with --include-unknown-api-server.`,
+		Args: cobra.ExactArgs(0),
+		PreRunE: func(_ *cobra.Command, _ []string) error {
+			if destroyOrphansOpts.find.Region == "" {
+				return errors.New("--region is required")
+			}
+			switch destroyOrphansOpts.output {
+			case "text", "json":
+				return nil
+			default:
+				return errors.Errorf("invalid output %q", destroyOrphansOpts.output)
+			}
+		},
+		Run: func(_ *cobra.Command, _ []string) {
+			cleanup := setupFileHook(rootOpts.dir)
+			defer cleanup()
+
+			err := runDestroyOrphansCmd(os.Stdout)
+			if err != nil {
+				logrus.Fatal(err)
+			}
+		},
+	}
+	cmd.PersistentFlags().StringVar(&destroyOrphansOpts.platform, "platform", "aws", "Platform to search for orphaned clusters (e.g. \"aws | azure | gcp\")")
+	cmd.PersistentFlags().StringVar(&destroyOrphansOpts.find.Region, "region", "", "Region to search for orphaned clusters")
+	cmd.PersistentFlags().StringVar(&destroyOrphansOpts.find.Project, "project", "", "Project to search for orphaned clusters (GCP only)")
+	cmd.PersistentFlags().StringVar(&destroyOrphansOpts.find.CloudName, "cloud-name", "", "Name of the cloud to search for orphaned clusters, defaults to AzurePublicCloud (Azure only)")
+	cmd.PersistentFlags().StringVar(&destroyOrphansOpts.find.ARMEndpoint, "arm-endpoint", "", "Resource Manager endpoint of the cloud (Azure Stack Hub only)")
+	cmd.PersistentFlags().DurationVar(&destroyOrphansOpts.filter.OlderThan, "older-than", 24*time.Hour, "Only destroy clusters created at least this long ago")
+	cmd.PersistentFlags().StringSliceVar(&destroyOrphansOpts.filter.Exclude, "exclude", nil, "Infra IDs of clusters never to destroy")
+	cmd.PersistentFlags().BoolVar(&destroyOrphansOpts.filter.IncludeUnknownAPIServer, "include-unknown-api-server", false, "Also destroy clusters whose API server address cannot be found, e.g. because their private DNS zone is gone")
+	cmd.PersistentFlags().BoolVar(&destroyOrphansOpts.yes, "yes", false, "Destroy the orphaned clusters, rather than only listing them")
+	cmd.PersistentFlags().StringVarP(&destroyOrphansOpts.output, "output", "o", "text", "Format of the cluster list printed without --yes (e.g. \"text | json\")")
+	return cmd
+}
+
+// runDestroyOrphansCmd writes the orphaned clusters to out, or destroys them
+// with --yes.
+func runDestroyOrphansCmd(out io.Writer) error {
+	logger := logrus.StandardLogger()
+	orphans, err := destroy.FindOrphans(context.Background(), logger, destroyOrphansOpts.platform, destroyOrphansOpts.find, destroyOrphansOpts.filter)
+	if err != nil {
+		return errors.Wrap(err, "Failed to find orphaned clusters")
+	}
+
+	if !destroyOrphansOpts.yes {
+		if destroyOrphansOpts.output == "json" {
+			encoder := json.NewEncoder(out)
+			encoder.SetIndent("", "  ")
+			return encoder.Encode(orphans)
+		}
+		for _, orphan := range orphans {
+			fmt.Fprintf(out, "%s (created %s, %s):\n", orphan.Metadata.InfraID, orphan.Created.Format(time.RFC3339), orphan.Marker)
+			writeInventory(out, orphan.Resources, "  ")
+		}
+		logrus.Infof("%d orphaned clusters would be destroyed; rerun with --yes to destroy them", len(orphans))
+		return nil
+	}
+
+	var failed []string
+	for _, orphan := range orphans {
+		infraID := orphan.Metadata.InfraID
+		logrus.Infof("Destroying orphaned cluster %s", infraID)
+		destroyer, err := destroy.NewForMetadata(logger.WithField("infraID", infraID), orphan.Metadata)
+		if err == nil {
+			_, err = destroyer.Run()
+		}
+		if err != nil {
+			logrus.Errorf("Failed to destroy orphaned cluster %s: %v", infraID, err)
+			failed = append(failed, infraID)
+		}
+	}
+	if len(failed) > 0 {
+		return errors.Errorf("failed to destroy %d of %d orphaned clusters: %s", len(failed), len(orphans), strings.Join(failed, ", "))
+	}
+	logrus.Infof("Destroyed %d orphaned clusters", len(orphans))
+	return nil
+}
+
+// runDestroyer runs the destroyer, giving up after the timeout if it is not
+// zero and the destroyer supports it.
+func runDestroyer(destroyer providers.Destroyer, timeout time.Duration) (*types.ClusterQuota, error) {
+	if timeout == 0 {
+		return destroyer.Run()
+	}
+
+	contextDestroyer, ok := destroyer.(providers.ContextDestroyer)
+	if !ok {
+		logrus.Warn("The destroyer for this platform does not support --timeout; destroying the cluster without a deadline")
+		return destroyer.Run()
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	return contextDestroyer.RunContext(ctx)
+}
+
 func newDestroyBootstrapCmd() *cobra.Command {
 	return &cobra.Command{
 		Use:   "bootstrap",
@@ -1,11 +1,180 @@
 package main
 
 import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 
+	"github.com/openshift/installer/pkg/asset"
+	assetstore "github.com/openshift/installer/pkg/asset/store"
+	targetassets "github.com/openshift/installer/pkg/asset/targets"
+	"github.com/openshift/installer/pkg/asset/tls"
 	"github.com/openshift/installer/pkg/explain"
 )
 
+var (
+	explainCertificatesOpts struct {
+		output string
+	}
+
+	explainIgnitionOpts struct {
+		diff string
+	}
+)
+
 func newExplainCmd() *cobra.Command {
-	return explain.NewCmd()
+	cmd := explain.NewCmd()
+	cmd.AddCommand(newExplainCertificatesCmd())
+	cmd.AddCommand(newExplainIgnitionCmd())
+	return cmd
+}
+
+func newExplainCertificatesCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "certificates",
+		Short: "List the certificates generated by the installer",
+		Long: `List the certificates of the assets in the state file or the asset directory, with the assets that consume them,
+followed by the certificates embedded in the bootstrap Ignition config.`,
+		Args: cobra.ExactArgs(0),
+		PreRunE: func(_ *cobra.Command, _ []string) error {
+			switch explainCertificatesOpts.output {
+			case "text", "json":
+				return nil
+			default:
+				return errors.Errorf("invalid output %q", explainCertificatesOpts.output)
+			}
+		},
+		Run: func(_ *cobra.Command, _ []string) {
+			cleanup := setupFileHook(rootOpts.dir)
+			defer cleanup()
+
+			if err := runExplainCertificatesCmd(os.Stdout, rootOpts.dir); err != nil {
+				logrus.Fatal(err)
+			}
+		},
+	}
+	cmd.Flags().StringVarP(&explainCertificatesOpts.output, "output", "o", "text", "Format of the certificate list (text or json)")
+	return cmd
+}
+
+func runExplainCertificatesCmd(out io.Writer, directory string) error {
+	assetStore, err := newAssetStore(directory)
+	if err != nil {
+		return errors.Wrap(err, "failed to create asset store")
+	}
+
+	var roots []asset.WritableAsset
+	roots = append(roots, targetassets.IgnitionConfigs...)
+	roots = append(roots, targetassets.Cluster...)
+	infos, err := explain.Certificates(assetStore, roots)
+	if err != nil {
+		return err
+	}
+	if len(infos) == 0 {
+		logrus.Warnf("No certificates found in %s; run \"create ignition-configs\" first", directory)
+	}
+	return writeCertificates(out, infos, explainCertificatesOpts.output, time.Now())
+}
+
+func newExplainIgnitionCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "ignition FILE",
+		Short: "Show the files, units and users of an Ignition config",
+		Long: `Show the files, directories, links, systemd units and users of an Ignition config, such as bootstrap.ign,
+with the contents of their data URLs decoded. With --diff, show the entries that were added, removed or
+changed in another Ignition config instead, for example between the configs of two installer versions.`,
+		Example: `
+# Show the contents of the bootstrap Ignition config
+openshift-install explain ignition bootstrap.ign
+
+# Show the changes of the bootstrap Ignition config of a new installer version
+openshift-install explain ignition old/bootstrap.ign --diff new/bootstrap.ign`,
+		Args: cobra.ExactArgs(1),
+		Run: func(_ *cobra.Command, args []string) {
+			if err := runExplainIgnitionCmd(os.Stdout, args[0], explainIgnitionOpts.diff); err != nil {
+				logrus.Fatal(err)
+			}
+		},
+	}
+	cmd.Flags().StringVar(&explainIgnitionOpts.diff, "diff", "", "Ignition config to compare with the Ignition config")
+	return cmd
+}
+
+func runExplainIgnitionCmd(out io.Writer, path string, diffPath string) error {
+	entries, err := loadIgnitionEntries(path)
+	if err != nil {
+		return err
+	}
+	if diffPath == "" {
+		return explain.PrintIgnitionEntries(out, entries)
+	}
+
+	diffEntries, err := loadIgnitionEntries(diffPath)
+	if err != nil {
+		return err
+	}
+	differences, err := explain.DiffIgnitionEntries(out, entries, path, diffEntries, diffPath)
+	if err != nil {
+		return err
+	}
+	logrus.Infof("%d differences between %s and %s", differences, path, diffPath)
+	return nil
+}
+
+// loadIgnitionEntries returns the entries of the Ignition config at path,
+// decrypting it if necessary.
+func loadIgnitionEntries(path string) ([]*explain.IgnitionEntry, error) {
+	data, err := assetstore.ReadFile(path, storeCipher)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read %s", path)
+	}
+	config, err := explain.ParseIgnition(data)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse %s", path)
+	}
+	return explain.IgnitionEntries(config)
+}
+
+func writeCertificates(out io.Writer, infos []*tls.CertificateInfo, output string, now time.Time) error {
+	if output == "json" {
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		if infos == nil {
+			infos = []*tls.CertificateInfo{}
+		}
+		return encoder.Encode(infos)
+	}
+
+	w := tabwriter.NewWriter(out, 0, 8, 1, ' ', 0)
+	for _, info := range infos {
+		fmt.Fprintf(w, "%s\n", info.Source)
+		if info.File != "" {
+			fmt.Fprintf(w, "  File:\t%s\n", info.File)
+		}
+		fmt.Fprintf(w, "  Subject:\t%s\n", info.Subject)
+		fmt.Fprintf(w, "  Issuer:\t%s\n", info.Issuer)
+		if sans := append(append([]string{}, info.DNSNames...), info.IPAddresses...); len(sans) > 0 {
+			fmt.Fprintf(w, "  SANs:\t%s\n", strings.Join(sans, ", "))
+		}
+		fmt.Fprintf(w, "  Key:\t%s\n", info.KeyType)
+		fmt.Fprintf(w, "  CA:\t%t\n", info.IsCA)
+		validity := fmt.Sprintf("%s to %s", info.NotBefore.Format(time.RFC3339), info.NotAfter.Format(time.RFC3339))
+		if info.Expired(now) {
+			validity += " (expired)"
+		}
+		fmt.Fprintf(w, "  Validity:\t%s\n", validity)
+		if len(info.ConsumedBy) > 0 {
+			fmt.Fprintf(w, "  Consumed by:\t%s\n", strings.Join(info.ConsumedBy, ", "))
+		}
+		fmt.Fprintln(w)
+	}
+	return w.Flush()
 }
@@ -20,9 +20,11 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/rest"
 
+	"github.com/openshift/installer/pkg/asset"
 	"github.com/openshift/installer/pkg/asset/installconfig"
-	assetstore "github.com/openshift/installer/pkg/asset/store"
 	"github.com/openshift/installer/pkg/asset/tls"
+	"github.com/openshift/installer/pkg/events"
+	"github.com/openshift/installer/pkg/gather/journal"
 	"github.com/openshift/installer/pkg/gather/service"
 	"github.com/openshift/installer/pkg/gather/ssh"
 	platformstages "github.com/openshift/installer/pkg/terraform/stages/platform"
@@ -51,14 +53,39 @@ var (
 		masters      []string
 		sshKeys      []string
 		skipAnalysis bool
+		method       string
+		knownHosts   string
 	}
 )
 
+const (
+	// gatherMethodSSH runs installer-gather.sh on the bootstrap host over SSH.
+	gatherMethodSSH = "ssh"
+	// gatherMethodJournal reads the journal of the bootstrap host from its systemd-journal-gatewayd.
+	gatherMethodJournal = "journal"
+)
+
 func newGatherBootstrapCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "bootstrap",
 		Short: "Gather debugging data for a failing-to-bootstrap control plane",
-		Args:  cobra.ExactArgs(0),
+		Long: `Gather debugging data for a failing-to-bootstrap control plane.
+
+By default, the data is gathered over SSH by running installer-gather.sh on the
+bootstrap host, which also gathers the data of the control plane hosts. When the
+bootstrap host cannot be reached with SSH, "--method journal" gathers the service
+records and the journals of the bootstrap host from its systemd-journal-gatewayd
+on port 19531 instead, authenticating with the journal-gatewayd certificate of the
+installation directory.`,
+		Args: cobra.ExactArgs(0),
+		PreRunE: func(_ *cobra.Command, _ []string) error {
+			switch gatherBootstrapOpts.method {
+			case gatherMethodSSH, gatherMethodJournal:
+				return nil
+			default:
+				return errors.Errorf("invalid method %q", gatherBootstrapOpts.method)
+			}
+		},
 		Run: func(_ *cobra.Command, _ []string) {
 			cleanup := setupFileHook(rootOpts.dir)
 			defer cleanup()
@@ -77,14 +104,20 @@ func newGatherBootstrapCmd() *cobra.Command {
 	cmd.PersistentFlags().StringArrayVar(&gatherBootstrapOpts.masters, "master", []string{}, "Hostnames or IPs of all control plane hosts")
 	cmd.PersistentFlags().StringArrayVar(&gatherBootstrapOpts.sshKeys, "key", []string{}, "Path to SSH private keys that should be used for authentication. If no key was provided, SSH private keys from user's environment will be used")
 	cmd.PersistentFlags().BoolVar(&gatherBootstrapOpts.skipAnalysis, "skipAnalysis", false, "Skip analysis of the gathered data")
+	cmd.PersistentFlags().StringVar(&gatherBootstrapOpts.knownHosts, "known-hosts", "", "Path to the known_hosts file used to verify the bootstrap host when its host key was not generated in the installation directory (default \"<dir>/.openshift_install_known_hosts\")")
+	cmd.PersistentFlags().StringVar(&gatherBootstrapOpts.method, "method", gatherMethodSSH, "Method used to gather the data (e.g. \"ssh | journal\")")
 	return cmd
 }
 
 func runGatherBootstrapCmd(directory string) (string, error) {
-	assetStore, err := assetstore.NewStore(directory)
+	assetStore, err := newAssetStore(directory)
 	if err != nil {
 		return "", errors.Wrap(err, "failed to create asset store")
 	}
+	if gatherBootstrapOpts.method == gatherMethodJournal {
+		return runGatherBootstrapJournalCmd(assetStore, directory)
+	}
+
 	// add the default bootstrap key pair to the sshKeys list
 	bootstrapSSHKeyPair := &tls.BootstrapSSHKeyPair{}
 	if err := assetStore.Fetch(bootstrapSSHKeyPair); err != nil {
@@ -103,31 +136,20 @@ func runGatherBootstrapCmd(directory string) (string, error) {
 	}
 	gatherBootstrapOpts.sshKeys = append(gatherBootstrapOpts.sshKeys, tmpfile.Name())
 
+	verifier, err := bootstrapHostKeyVerifier(assetStore, directory)
+	if err != nil {
+		return "", err
+	}
+
 	bootstrap := gatherBootstrapOpts.bootstrap
 	port := 22
 	masters := gatherBootstrapOpts.masters
 	if bootstrap == "" && len(masters) == 0 {
-		config := &installconfig.InstallConfig{}
-		if err := assetStore.Fetch(config); err != nil {
-			return "", errors.Wrapf(err, "failed to fetch %s", config.Name())
+		var err error
+		bootstrap, port, masters, err = extractHostAddresses(assetStore, directory)
+		if err != nil {
+			return "", err
 		}
-
-		for _, stage := range platformstages.StagesForPlatform(config.Config.Platform.Name()) {
-			stageBootstrap, stagePort, stageMasters, err := stage.ExtractHostAddresses(directory, config.Config)
-			if err != nil {
-				return "", err
-			}
-			if stageBootstrap != "" {
-				bootstrap = stageBootstrap
-			}
-			if stagePort != 0 {
-				port = stagePort
-			}
-			if len(stageMasters) > 0 {
-				masters = stageMasters
-			}
-		}
-
 		if bootstrap == "" || len(masters) == 0 {
 			return "", errors.New("bootstrap host address and at least one control plane host address must be provided")
 		}
@@ -135,12 +157,101 @@ func runGatherBootstrapCmd(directory string) (string, error) {
 		return "", errors.New("must provide both bootstrap host address and at least one control plane host address when providing one")
 	}
 
-	return logGatherBootstrap(bootstrap, port, masters, directory)
+	return logGatherBootstrap(bootstrap, port, masters, verifier, directory)
+}
+
+// bootstrapHostKeyVerifier returns the verifier of the host key of the bootstrap host. The host key is pinned when it
+// was generated in the directory. Otherwise, it is trusted on first use and added to the known hosts file.
+func bootstrapHostKeyVerifier(assetStore asset.Store, directory string) (*ssh.HostKeyVerifier, error) {
+	hostKey, err := assetStore.Load(&tls.BootstrapSSHHostKey{})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load the bootstrap SSH host key")
+	}
+	if hostKey != nil {
+		return ssh.PinnedHostKey(hostKey.(*tls.BootstrapSSHHostKey).Public())
+	}
+
+	knownHosts := gatherBootstrapOpts.knownHosts
+	if knownHosts == "" {
+		knownHosts = filepath.Join(directory, ".openshift_install_known_hosts")
+	}
+	logrus.Warnf("The host key of the bootstrap machine was not generated by the installer, trusting it on first use with %q", knownHosts)
+	return ssh.KnownHosts(knownHosts)
+}
+
+// extractHostAddresses returns the addresses of the hosts, and the SSH port of the bootstrap host, from the
+// terraform state of the cluster in the directory.
+func extractHostAddresses(assetStore asset.Store, directory string) (string, int, []string, error) {
+	config := &installconfig.InstallConfig{}
+	if err := assetStore.Fetch(config); err != nil {
+		return "", 0, nil, errors.Wrapf(err, "failed to fetch %s", config.Name())
+	}
+
+	bootstrap := ""
+	port := 22
+	var masters []string
+	for _, stage := range platformstages.StagesForPlatform(config.Config.Platform.Name()) {
+		stageBootstrap, stagePort, stageMasters, err := stage.ExtractHostAddresses(directory, config.Config)
+		if err != nil {
+			return "", 0, nil, err
+		}
+		if stageBootstrap != "" {
+			bootstrap = stageBootstrap
+		}
+		if stagePort != 0 {
+			port = stagePort
+		}
+		if len(stageMasters) > 0 {
+			masters = stageMasters
+		}
+	}
+	return bootstrap, port, masters, nil
+}
+
+func runGatherBootstrapJournalCmd(assetStore asset.Store, directory string) (string, error) {
+	rootCA := &tls.RootCA{}
+	journalCertKey := &tls.JournalCertKey{}
+	for _, a := range []asset.WritableAsset{rootCA, journalCertKey} {
+		if err := assetStore.Fetch(a); err != nil {
+			return "", errors.Wrapf(err, "failed to fetch %s", a.Name())
+		}
+	}
+
+	bootstrap := gatherBootstrapOpts.bootstrap
+	if bootstrap == "" {
+		var err error
+		bootstrap, _, _, err = extractHostAddresses(assetStore, directory)
+		if err != nil {
+			return "", err
+		}
+		if bootstrap == "" {
+			return "", errors.New("bootstrap host address must be provided")
+		}
+	}
+
+	logrus.Info("Pulling debug logs from the journal of the bootstrap machine")
+	client, err := journal.NewClient(net.JoinHostPort(bootstrap, strconv.Itoa(journal.Port)), rootCA.Cert(), journalCertKey.Cert(), journalCertKey.Key())
+	if err != nil {
+		return "", errors.Wrap(err, "failed to create journal client")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+	file, err := journal.GatherBootstrap(ctx, client, time.Now().Format("20060102150405"), directory)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to gather the journal of the bootstrap machine")
+	}
+	path, err := filepath.Abs(file)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to stat log file")
+	}
+	logrus.Infof("Bootstrap gather logs captured here %q", path)
+	return path, nil
 }
 
-func logGatherBootstrap(bootstrap string, port int, masters []string, directory string) (string, error) {
+func logGatherBootstrap(bootstrap string, port int, masters []string, verifier *ssh.HostKeyVerifier, directory string) (string, error) {
 	logrus.Info("Pulling debug logs from the bootstrap machine")
-	client, err := ssh.NewClient("core", net.JoinHostPort(bootstrap, strconv.Itoa(port)), gatherBootstrapOpts.sshKeys)
+	client, err := ssh.NewClient("core", net.JoinHostPort(bootstrap, strconv.Itoa(port)), gatherBootstrapOpts.sshKeys, verifier)
 	if err != nil {
 		if errors.Is(err, syscall.ECONNREFUSED) {
 			return "", errors.Wrap(err, "failed to connect to the bootstrap machine")
@@ -184,10 +295,17 @@ func logClusterOperatorConditions(ctx context.Context, config *rest.Config) erro
 			} else if (condition.Type == configv1.OperatorDegraded || condition.Type == configv1.OperatorProgressing) && condition.Status == configv1.ConditionFalse {
 				continue
 			}
+			entry := events.New(events.OperatorCondition, map[string]interface{}{
+				"operator": operator.ObjectMeta.Name,
+				"type":     condition.Type,
+				"status":   condition.Status,
+				"reason":   condition.Reason,
+				"message":  condition.Message,
+			})
 			if condition.Type == configv1.OperatorDegraded {
-				logrus.Errorf("Cluster operator %s %s is %s with %s: %s", operator.ObjectMeta.Name, condition.Type, condition.Status, condition.Reason, condition.Message)
+				entry.Errorf("Cluster operator %s %s is %s with %s: %s", operator.ObjectMeta.Name, condition.Type, condition.Status, condition.Reason, condition.Message)
 			} else {
-				logrus.Infof("Cluster operator %s %s is %s with %s: %s", operator.ObjectMeta.Name, condition.Type, condition.Status, condition.Reason, condition.Message)
+				entry.Infof("Cluster operator %s %s is %s with %s: %s", operator.ObjectMeta.Name, condition.Type, condition.Status, condition.Reason, condition.Message)
 			}
 		}
 	}
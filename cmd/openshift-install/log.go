@@ -9,6 +9,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 
+	"github.com/openshift/installer/pkg/events"
 	"github.com/openshift/installer/pkg/version"
 )
 
@@ -46,6 +47,9 @@ func (h fileHook) Levels() []logrus.Level {
 }
 
 func (h *fileHook) Fire(entry *logrus.Entry) error {
+	// Events are written by their own hook, so leave them out of the text.
+	entry = events.Strip(entry)
+
 	// logrus reuses the same entry for each invocation of hooks.
 	// so we need to make sure we leave them message field as we received.
 	orig := entry.Message
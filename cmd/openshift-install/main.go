@@ -2,6 +2,7 @@ package main
 
 import (
 	"flag"
+	"io"
 	"io/ioutil"
 	"os"
 	"path/filepath"
@@ -14,14 +15,23 @@ import (
 	"k8s.io/klog"
 	klogv2 "k8s.io/klog/v2"
 
+	assetstore "github.com/openshift/installer/pkg/asset/store"
+	"github.com/openshift/installer/pkg/events"
 	"github.com/openshift/installer/pkg/terraform/exec/plugins"
 )
 
 var (
 	rootOpts struct {
-		dir      string
-		logLevel string
+		dir         string
+		logLevel    string
+		logFormat   string
+		eventsFile  string
+		store       string
+		forceUnlock bool
 	}
+
+	// eventsFile is the file opened with --events-file, if any.
+	eventsFile *os.File
 )
 
 func main() {
@@ -65,6 +75,8 @@ func installerMain() {
 		newCompletionCmd(),
 		newMigrateCmd(),
 		newExplainCmd(),
+		newCheckCmd(),
+		newDecryptCmd(),
 	} {
 		rootCmd.AddCommand(subCmd)
 	}
@@ -80,11 +92,19 @@ func newRootCmd() *cobra.Command {
 		Short:            "Creates OpenShift clusters",
 		Long:             "",
 		PersistentPreRun: runRootCmd,
-		SilenceErrors:    true,
-		SilenceUsage:     true,
+		PersistentPostRun: func(*cobra.Command, []string) {
+			releaseInstallDir()
+			closeEventsFile()
+		},
+		SilenceErrors: true,
+		SilenceUsage:  true,
 	}
 	cmd.PersistentFlags().StringVar(&rootOpts.dir, "dir", ".", "assets directory")
 	cmd.PersistentFlags().StringVar(&rootOpts.logLevel, "log-level", "info", "log level (e.g. \"debug | info | warn | error\")")
+	cmd.PersistentFlags().StringVar(&rootOpts.logFormat, "log-format", "text", "log format (e.g. \"text | json-events\"). With json-events, progress events are additionally written as JSON lines")
+	cmd.PersistentFlags().StringVar(&rootOpts.eventsFile, "events-file", "", "file to append JSON events to when using --log-format=json-events (defaults to stdout)")
+	cmd.PersistentFlags().StringVar(&rootOpts.store, "store", "", "location to store the assets directory in, so that it can be shared between machines (e.g. \"s3://bucket/prefix\"); the state file is kept only in the store")
+	cmd.PersistentFlags().BoolVar(&rootOpts.forceUnlock, "force-unlock", false, "remove the lock on the assets directory held by another installer process; only use this if that process is no longer running")
 	return cmd
 }
 
@@ -112,4 +132,63 @@ func runRootCmd(cmd *cobra.Command, args []string) {
 	if err != nil {
 		logrus.Fatal(errors.Wrap(err, "invalid log-level"))
 	}
+
+	switch rootOpts.logFormat {
+	case "text":
+	case "json-events":
+		var out io.Writer = os.Stdout
+		if rootOpts.eventsFile != "" {
+			eventsFile, err = os.OpenFile(rootOpts.eventsFile, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0640)
+			if err != nil {
+				logrus.Fatal(errors.Wrap(err, "failed to open events file"))
+			}
+			out = eventsFile
+		}
+		logrus.AddHook(events.NewHook(out))
+	default:
+		logrus.Fatalf("invalid log-format %q", rootOpts.logFormat)
+	}
+
+	storeCipher, err = assetstore.NewCipherFromEnvironment()
+	if err != nil {
+		logrus.Fatal(errors.Wrap(err, "failed to set up encryption"))
+	}
+
+	if rootOpts.store != "" {
+		storeBackend, err = assetstore.NewBackend(rootOpts.store, rootOpts.dir)
+		if err != nil {
+			logrus.Fatal(errors.Wrap(err, "failed to set up the store"))
+		}
+	}
+
+	if locksInstallDir(cmd) {
+		if err := lockInstallDir(rootOpts.dir, cmd.CommandPath(), rootOpts.forceUnlock); err != nil {
+			logrus.Fatal(errors.Wrap(err, "failed to lock the assets directory"))
+		}
+	}
+
+	if storeBackend != nil {
+		if err := pullStoreBackend(rootOpts.dir); err != nil {
+			logrus.Fatal(err)
+		}
+	}
+
+	// The exit handlers run in the order they are registered, so the events
+	// file is closed after the install directory is released, which may log.
+	logrus.RegisterExitHandler(closeEventsFile)
+}
+
+// closeEventsFile flushes and closes the file opened with --events-file, if
+// any. It is run when the installer exits, whether or not the command succeeds.
+func closeEventsFile() {
+	if eventsFile == nil {
+		return
+	}
+	if err := eventsFile.Sync(); err != nil {
+		logrus.Error(errors.Wrap(err, "failed to sync the events file"))
+	}
+	if err := eventsFile.Close(); err != nil {
+		logrus.Error(errors.Wrap(err, "failed to close the events file"))
+	}
+	eventsFile = nil
 }
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/installer/pkg/asset/cluster"
+	"github.com/openshift/installer/pkg/destroy"
+	"github.com/openshift/installer/pkg/destroy/providers"
+)
+
+var (
+	createMetadataOpts struct {
+		platform string
+		discover providers.DiscoverOptions
+	}
+)
+
+func newCreateMetadataCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "metadata",
+		Short: "Generates the metadata.json of an existing cluster from the tags of its resources",
+		Long: `Recreate the metadata.json of a cluster whose install directory was
+lost, from the tags (or labels) of its resources, so that "destroy cluster"
+can delete it.`,
+		Args: cobra.ExactArgs(0),
+		PreRunE: func(_ *cobra.Command, _ []string) error {
+			if createMetadataOpts.discover.InfraID == "" {
+				return errors.New("--infra-id is required")
+			}
+			return nil
+		},
+		Run: func(_ *cobra.Command, _ []string) {
+			cleanup := setupFileHook(rootOpts.dir)
+			defer cleanup()
+
+			err := runCreateMetadataCmd(rootOpts.dir)
+			if err != nil {
+				logrus.Fatal(err)
+			}
+		},
+	}
+	cmd.Flags().StringVar(&createMetadataOpts.platform, "platform", "aws", "Platform of the cluster (e.g. \"aws | gcp | azure\")")
+	cmd.Flags().StringVar(&createMetadataOpts.discover.InfraID, "infra-id", "", "Infra ID of the cluster")
+	cmd.Flags().StringVar(&createMetadataOpts.discover.Region, "region", "", "Region of the cluster")
+	cmd.Flags().StringVar(&createMetadataOpts.discover.Project, "project", "", "Project of the cluster (GCP only)")
+	cmd.Flags().StringVar(&createMetadataOpts.discover.ResourceGroup, "resource-group", "", "Resource group of the cluster, defaults to <infra-id>-rg (Azure only)")
+	cmd.Flags().StringVar(&createMetadataOpts.discover.BaseDomainResourceGroup, "base-domain-resource-group", "", "Resource group of the base domain, whose cluster records are deleted too (Azure only)")
+	cmd.Flags().StringVar(&createMetadataOpts.discover.CloudName, "cloud-name", "", "Name of the cloud of the cluster, defaults to AzurePublicCloud (Azure only)")
+	cmd.Flags().StringVar(&createMetadataOpts.discover.ARMEndpoint, "arm-endpoint", "", "Resource Manager endpoint of the cloud (Azure Stack Hub only)")
+	return cmd
+}
+
+func runCreateMetadataCmd(directory string) error {
+	metadata, err := destroy.DiscoverMetadata(context.Background(), logrus.StandardLogger(), createMetadataOpts.platform, createMetadataOpts.discover)
+	if err != nil {
+		return errors.Wrap(err, "failed to discover the cluster")
+	}
+	if err := cluster.SaveMetadata(directory, metadata); err != nil {
+		return errors.Wrap(err, "failed to write the cluster metadata")
+	}
+	logrus.Infof("Wrote the metadata of %s; run \"openshift-install destroy cluster\" to destroy it", metadata.InfraID)
+	return nil
+}
@@ -1,9 +1,12 @@
 package main
 
 import (
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 
 	azure "github.com/openshift/installer/cmd/openshift-install/migrate/azure"
+	assetstore "github.com/openshift/installer/pkg/asset/store"
 )
 
 func newMigrateCmd() *cobra.Command {
@@ -18,6 +21,34 @@ func newMigrateCmd() *cobra.Command {
 
 	migrateCmd.AddCommand(azure.NewMigrateAzurePrivateDNSEligibleCmd())
 	migrateCmd.AddCommand(azure.NewMigrateAzurePrivateDNSMigrateCmd())
+	migrateCmd.AddCommand(newMigrateStateCmd())
 
 	return migrateCmd
 }
+
+func newMigrateStateCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "state",
+		Short: "Upgrade the state file of the assets directory to the current schema version",
+		Long: `Upgrade the state file of the assets directory to the current schema version.
+
+The original state file is kept as a backup next to the upgraded state file.
+Other commands also upgrade the state file, with the same backup, the first
+time they save it.`,
+		Args: cobra.ExactArgs(0),
+		Run: func(_ *cobra.Command, _ []string) {
+			cleanup := setupFileHook(rootOpts.dir)
+			defer cleanup()
+
+			migration, err := assetstore.MigrateState(rootOpts.dir, storeOptions()...)
+			if err != nil {
+				logrus.Fatal(errors.Wrap(err, "failed to migrate the state file"))
+			}
+			if migration == nil {
+				logrus.Infof("The state file is at the current schema version %d", assetstore.CurrentStateVersion)
+				return
+			}
+			logrus.Infof("Migrated the state file from schema version %d to %d", migration.From, migration.To)
+		},
+	}
+}
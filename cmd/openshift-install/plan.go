@@ -0,0 +1,81 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"github.com/openshift/installer/pkg/asset/cluster"
+	"github.com/openshift/installer/pkg/asset/installconfig"
+	"github.com/openshift/installer/pkg/terraform"
+)
+
+func runClusterPlanCmd(directory string) {
+	cleanup := setupFileHook(directory)
+	defer cleanup()
+
+	plans, err := planCluster(directory)
+	printClusterPlan(os.Stdout, plans)
+	if err != nil {
+		logrus.Fatal(err)
+	}
+}
+
+func planCluster(directory string) ([]*terraform.StagePlan, error) {
+	assetStore, err := newAssetStore(directory)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create asset store")
+	}
+
+	installConfig := &installconfig.InstallConfig{}
+	terraformVariables := &cluster.TerraformVariables{}
+	if err := assetStore.Fetch(installConfig); err != nil {
+		return nil, errors.Wrapf(err, "failed to fetch %s", installConfig.Name())
+	}
+	if err := assetStore.Fetch(terraformVariables); err != nil {
+		return nil, errors.Wrapf(err, "failed to fetch %s", terraformVariables.Name())
+	}
+
+	return cluster.Plan(installConfig, terraformVariables)
+}
+
+func printClusterPlan(out io.Writer, plans []*terraform.StagePlan) {
+	actions := []terraform.PlanAction{
+		terraform.PlanActionCreate,
+		terraform.PlanActionReplace,
+		terraform.PlanActionUpdate,
+		terraform.PlanActionDelete,
+	}
+
+	w := tabwriter.NewWriter(out, 0, 8, 2, ' ', 0)
+	defer w.Flush()
+	for _, plan := range plans {
+		fmt.Fprintf(w, "Stage: %s\n", plan.Stage)
+		header := []string{"RESOURCE TYPE"}
+		for _, action := range actions {
+			header = append(header, strings.ToUpper(string(action)))
+		}
+		fmt.Fprintf(w, "  %s\n", strings.Join(header, "\t"))
+		for _, resource := range plan.Resources {
+			fmt.Fprintf(w, "  %s", resource.Type)
+			for _, action := range actions {
+				fmt.Fprintf(w, "\t%d", resource.Changes[action])
+			}
+			fmt.Fprintln(w)
+		}
+		fmt.Fprintf(w, "  TOTAL")
+		for _, action := range actions {
+			fmt.Fprintf(w, "\t%d", plan.Totals[action])
+		}
+		fmt.Fprintln(w)
+		for _, name := range plan.UnknownOutputs {
+			fmt.Fprintf(w, "  output %s = %s\n", name, terraform.UnknownOutputPlaceholder)
+		}
+		fmt.Fprintln(w)
+	}
+}
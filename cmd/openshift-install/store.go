@@ -0,0 +1,146 @@
+package main
+
+import (
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/openshift/installer/pkg/asset"
+	assetstore "github.com/openshift/installer/pkg/asset/store"
+)
+
+var (
+	// storeBackend is the backend selected with --store, or nil if the
+	// install directory is not stored remotely.
+	storeBackend assetstore.Backend
+
+	// storeCipher encrypts the state file and sensitive assets, or is nil
+	// if encryption is not configured in the environment.
+	storeCipher assetstore.Cipher
+)
+
+// newAssetStore returns the asset store for the install directory. When a
+// store was selected with --store, the state file is kept in that store.
+func newAssetStore(directory string) (asset.Store, error) {
+	return assetstore.NewStore(directory, storeOptions()...)
+}
+
+// storeOptions returns the asset store options for the store selected with
+// --store and the encryption configured in the environment.
+func storeOptions() []assetstore.Option {
+	var opts []assetstore.Option
+	if storeBackend != nil {
+		opts = append(opts, assetstore.WithBackend(storeBackend))
+	}
+	if storeCipher != nil {
+		opts = append(opts, assetstore.WithCipher(storeCipher))
+	}
+	return opts
+}
+
+// loadAdminKubeconfig returns the client configuration of the admin
+// kubeconfig in the directory, decrypting the kubeconfig if necessary.
+func loadAdminKubeconfig(directory string) (*rest.Config, error) {
+	data, err := assetstore.ReadFile(filepath.Join(directory, "auth", "kubeconfig"), storeCipher)
+	if err != nil {
+		return nil, err
+	}
+	return clientcmd.RESTConfigFromKubeConfig(data)
+}
+
+// pullStoreBackend pulls the install directory from the store selected with
+// --store.
+func pullStoreBackend(directory string) error {
+	logrus.Debugf("Pulling the install directory from %s", rootOpts.store)
+	return errors.Wrapf(assetstore.Pull(storeBackend, directory), "failed to pull the install directory from %s", rootOpts.store)
+}
+
+// pushStoreBackend pushes the install directory to the store selected with
+// --store, if any. The directory is only pushed by commands holding the lock
+// on it, so that other commands cannot overwrite its contents.
+func pushStoreBackend() {
+	if storeBackend == nil || installDirLock == nil {
+		return
+	}
+	logrus.Debugf("Pushing the install directory to %s", rootOpts.store)
+	if err := assetstore.Push(storeBackend, rootOpts.dir); err != nil {
+		logrus.Error(errors.Wrapf(err, "failed to push the install directory to %s", rootOpts.store))
+	}
+}
+
+// lockedCommands are the top-level commands that modify the install
+// directory, and so lock it against concurrent installer runs. Commands
+// which only read the install directory do not lock it, so that e.g.
+// wait-for can run while create cluster is running.
+var lockedCommands = map[string]bool{
+	"create":  true,
+	"destroy": true,
+	"migrate": true,
+}
+
+// installDirLock is the lock held on the install directory, if any.
+var installDirLock *assetstore.Lock
+
+// locksInstallDir returns whether the command locks the install directory.
+func locksInstallDir(cmd *cobra.Command) bool {
+	for c := cmd; c.HasParent(); c = c.Parent() {
+		if !c.Parent().HasParent() {
+			return lockedCommands[c.Name()]
+		}
+	}
+	return false
+}
+
+// lockInstallDir locks the install directory, in the store selected with
+// --store if any, until the installer exits. With force, any existing lock
+// is removed first.
+func lockInstallDir(directory string, command string, force bool) error {
+	backend := storeBackend
+	if backend == nil {
+		var err error
+		if backend, err = assetstore.NewBackend("", directory); err != nil {
+			return err
+		}
+	}
+
+	if force {
+		holder, err := assetstore.ForceUnlock(backend)
+		if err != nil {
+			return err
+		}
+		if holder != nil {
+			logrus.Warnf("Removed the lock on the install directory held by %s", holder)
+		}
+	}
+
+	lock, err := assetstore.AcquireLock(backend, command)
+	if err != nil {
+		return err
+	}
+	installDirLock = lock
+	logrus.RegisterExitHandler(releaseInstallDir)
+	return nil
+}
+
+// releaseInstallDir pushes the install directory to the store selected with
+// --store, if any, and then releases the lock on it. It is run when the
+// installer exits, whether or not the command succeeds.
+func releaseInstallDir() {
+	pushStoreBackend()
+	unlockInstallDir()
+}
+
+// unlockInstallDir releases the lock on the install directory, if held.
+func unlockInstallDir() {
+	if installDirLock == nil {
+		return
+	}
+	if err := installDirLock.Release(); err != nil {
+		logrus.Error(errors.Wrap(err, "failed to unlock the install directory"))
+	}
+	installDirLock = nil
+}
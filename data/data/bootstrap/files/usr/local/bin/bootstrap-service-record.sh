@@ -27,11 +27,11 @@ SERVICE_NAME="${SERVICE_NAME:-${SYSTEMD_UNIT_NAME%.service}}"
 add_service_record_entry() {
   local FILENAME="${SERVICE_RECORDS_DIR}/${SERVICE_NAME}.json"
   mkdir --parents "$(dirname "${FILENAME}")"
-  # Append the new entry to the existing array in the file.
-  # If the file does not already exist, start with an empty array.
   # The new entry contains only the fields that have non-empty values, to omit optional values that were not provided.
-  ([ -f "${FILENAME}" ] && cat "${FILENAME}" || echo '[]') | \
-      jq \
+  local ENTRY
+  ENTRY="$(jq \
+        --null-input \
+        --compact-output \
         --arg timestamp "$(date +"%Y-%m-%dT%H:%M:%SZ")" \
         --arg preCommand "${PRE_COMMAND-}" \
         --arg postCommand "${POST_COMMAND-}" \
@@ -40,12 +40,21 @@ add_service_record_entry() {
         --arg result "${RESULT-}" \
         --arg errorLine "${ERROR_LINE-}" \
         --arg errorMessage "${ERROR_MESSAGE-}" \
-        '. += [
-          {$timestamp,$preCommand,$postCommand,$stage,$phase,$result,$errorLine,$errorMessage} |
-          reduce keys[] as $k (.; if .[$k] == "" then del(.[$k]) else . end)
-        ]' \
+        '{$timestamp,$preCommand,$postCommand,$stage,$phase,$result,$errorLine,$errorMessage} |
+          reduce keys[] as $k (.; if .[$k] == "" then del(.[$k]) else . end)')"
+  # Append the new entry to the existing array in the file.
+  # If the file does not already exist, start with an empty array.
+  ([ -f "${FILENAME}" ] && cat "${FILENAME}" || echo '[]') | \
+      jq --argjson entry "${ENTRY}" '. += [$entry]' \
       > "${FILENAME}.tmp" && \
     mv "${FILENAME}.tmp" "${FILENAME}"
+  # Also record the entry in the journal, so that it can be gathered through systemd-journal-gatewayd when the
+  # bootstrap machine cannot be reached with SSH.
+  logger --journald <<EOF
+SYSLOG_IDENTIFIER=openshift-service-record
+OPENSHIFT_SERVICE=${SERVICE_NAME}
+MESSAGE=${ENTRY}
+EOF
 }
 
 # record_service_start() records the start of a service.
@@ -138,13 +138,16 @@ else
     echo "No masters found!"
 fi
 
+# The host keys of the control plane hosts are trusted on first use, and then verified against the known hosts of
+# the bootstrap host on the following gathers.
+SSH_OPTS=(-o PreferredAuthentications=publickey -o StrictHostKeyChecking=accept-new -o UserKnownHostsFile="${HOME}/.ssh/known_hosts")
 for master in "${MASTERS[@]}"
 do
     echo "Collecting info from ${master}"
-    scp -o PreferredAuthentications=publickey -o StrictHostKeyChecking=false -o UserKnownHostsFile=/dev/null -q /usr/local/bin/installer-masters-gather.sh "core@[${master}]:"
+    scp "${SSH_OPTS[@]}" -q /usr/local/bin/installer-masters-gather.sh "core@[${master}]:"
     mkdir -p "${ARTIFACTS}/control-plane/${master}"
-    ssh -o PreferredAuthentications=publickey -o StrictHostKeyChecking=false -o UserKnownHostsFile=/dev/null "core@${master}" -C "sudo ./installer-masters-gather.sh --id '${MASTER_GATHER_ID}'" </dev/null
-    scp -o PreferredAuthentications=publickey -o StrictHostKeyChecking=false -o UserKnownHostsFile=/dev/null -r -q "core@[${master}]:/tmp/artifacts-${MASTER_GATHER_ID}/*" "${ARTIFACTS}/control-plane/${master}/"
+    ssh "${SSH_OPTS[@]}" "core@${master}" -C "sudo ./installer-masters-gather.sh --id '${MASTER_GATHER_ID}'" </dev/null
+    scp "${SSH_OPTS[@]}" -r -q "core@[${master}]:/tmp/artifacts-${MASTER_GATHER_ID}/*" "${ARTIFACTS}/control-plane/${master}/"
 done
 
 TAR_FILE="${TAR_FILE:-${HOME}/log-bundle-${GATHER_ID}.tar.gz}"
@@ -69,15 +69,21 @@ func DeleteAssetFromDisk(asset WritableAsset, directory string) error {
 			return errors.Wrap(err, "failed to remove file")
 		}
 
-		dir := filepath.Dir(path)
-		ok, err := isDirEmpty(dir)
-		if err != nil && !os.IsNotExist(err) {
-			return errors.Wrap(err, "failed to read directory")
-		}
-		if ok {
+		// Remove the directories of the file that are left empty, up to the directory.
+		for dir := filepath.Dir(path); ; dir = filepath.Dir(dir) {
+			ok, err := isDirEmpty(dir)
+			if err != nil && !os.IsNotExist(err) {
+				return errors.Wrap(err, "failed to read directory")
+			}
+			if !ok {
+				break
+			}
 			if err := os.Remove(dir); err != nil {
 				return errors.Wrap(err, "failed to remove directory")
 			}
+			if dir == filepath.Clean(directory) || filepath.Dir(dir) == filepath.Clean(directory) {
+				break
+			}
 		}
 	}
 	return nil
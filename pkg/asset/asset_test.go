@@ -86,6 +86,28 @@ func TestPersistToFile(t *testing.T) {
 	}
 }
 
+func TestDeleteAssetFromDisk(t *testing.T) {
+	dir, err := ioutil.TempDir("", "TestDeleteAssetFromDisk")
+	if err != nil {
+		t.Skipf("could not create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	asset := &writablePersistAsset{
+		FileList: []*File{
+			{Filename: "dir1/dir2/dir3/file1", Data: []byte("data1")},
+			{Filename: "dir1/dir4/file2", Data: []byte("data2")},
+		},
+	}
+	assert.NoError(t, PersistToFile(asset, dir))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "file3"), []byte("data3"), 0640))
+
+	assert.NoError(t, DeleteAssetFromDisk(asset, dir))
+	verifyFilesCreated(t, dir, map[string][]byte{filepath.Join(dir, "file3"): []byte("data3")})
+	_, err = os.Stat(filepath.Join(dir, "dir1"))
+	assert.True(t, os.IsNotExist(err), "expected the empty directories to be removed")
+}
+
 func verifyFilesCreated(t *testing.T, dir string, expectedFiles map[string][]byte) {
 	dirContents, err := ioutil.ReadDir(dir)
 	assert.NoError(t, err, "could not read contents of directory %q", dir)
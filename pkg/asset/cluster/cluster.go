@@ -16,6 +16,7 @@ import (
 	"github.com/openshift/installer/pkg/asset/installconfig"
 	"github.com/openshift/installer/pkg/asset/password"
 	"github.com/openshift/installer/pkg/asset/quota"
+	"github.com/openshift/installer/pkg/events"
 	"github.com/openshift/installer/pkg/metrics/timer"
 	"github.com/openshift/installer/pkg/terraform"
 	"github.com/openshift/installer/pkg/terraform/exec"
@@ -58,45 +59,69 @@ func (c *Cluster) Dependencies() []asset.Asset {
 
 // Generate launches the cluster and generates the terraform state file on disk.
 func (c *Cluster) Generate(parents asset.Parents) (err error) {
+	return c.provision(parents, "")
+}
+
+// Resume launches the cluster, continuing from the first terraform stage that
+// did not complete in a previous run in the given directory. The state and
+// outputs files of the completed stages are added to the FileList, and their
+// outputs are used as var files for the remaining stages.
+func (c *Cluster) Resume(directory string, parents asset.Parents) error {
+	return c.provision(parents, directory)
+}
+
+// provision runs the terraform stages for the platform. When previousDir is
+// not empty, the stages which already have an outputs file in previousDir
+// are skipped, and any existing state files are re-used.
+func (c *Cluster) provision(parents asset.Parents, previousDir string) error {
 	clusterID := &installconfig.ClusterID{}
 	installConfig := &installconfig.InstallConfig{}
 	terraformVariables := &TerraformVariables{}
 	parents.Get(clusterID, installConfig, terraformVariables)
 
-	if installConfig.Config.Platform.None != nil {
-		return errors.New("cluster cannot be created with platform set to 'none'")
-	}
-
-	if installConfig.Config.BootstrapInPlace != nil {
-		return errors.New("cluster cannot be created with bootstrapInPlace set")
+	platform, err := terraformPlatform(installConfig)
+	if err != nil {
+		return err
 	}
 
-	platform := installConfig.Config.Platform.Name()
+	stages := platformstages.StagesForPlatform(platform)
 
-	if azure := installConfig.Config.Platform.Azure; azure != nil && azure.CloudName == typesazure.StackCloud {
-		platform = typesazure.StackTerraformName
+	tfvarsFiles := make([]*asset.File, 0, len(terraformVariables.Files())+len(stages))
+	for _, file := range terraformVariables.Files() {
+		tfvarsFiles = append(tfvarsFiles, file)
 	}
 
-	stages := platformstages.StagesForPlatform(platform)
-
-	logrus.Infof("Creating infrastructure resources...")
-	switch platform {
-	case typesaws.Name:
-		if err := aws.PreTerraform(context.TODO(), clusterID.InfraID, installConfig); err != nil {
-			return err
-		}
-	case typesazure.Name, typesazure.StackTerraformName:
-		if err := azure.PreTerraform(context.TODO(), clusterID.InfraID, installConfig); err != nil {
-			return err
+	completed := 0
+	if previousDir != "" {
+		for _, stage := range stages {
+			outputs, err := c.loadCompletedStage(previousDir, stage)
+			if err != nil {
+				return err
+			}
+			if outputs == nil {
+				break
+			}
+			logrus.Infof("Skipping stage %s, which was completed by a previous run", stage.Name())
+			tfvarsFiles = append(tfvarsFiles, outputs)
+			completed++
 		}
 	}
 
-	tfvarsFiles := make([]*asset.File, 0, len(terraformVariables.Files())+len(stages))
-	for _, file := range terraformVariables.Files() {
-		tfvarsFiles = append(tfvarsFiles, file)
+	logrus.Infof("Creating infrastructure resources...")
+	if completed == 0 {
+		switch platform {
+		case typesaws.Name:
+			if err := aws.PreTerraform(context.TODO(), clusterID.InfraID, installConfig); err != nil {
+				return err
+			}
+		case typesazure.Name, typesazure.StackTerraformName:
+			if err := azure.PreTerraform(context.TODO(), clusterID.InfraID, installConfig); err != nil {
+				return err
+			}
+		}
 	}
 
-	for _, stage := range stages {
+	for _, stage := range stages[completed:] {
 
 		// Copy the terraform.tfvars to a temp directory where the terraform
 		// will be invoked within.
@@ -114,6 +139,20 @@ func (c *Cluster) Generate(parents asset.Parents) (err error) {
 			extraArgs = append(extraArgs, fmt.Sprintf("-var-file=%s", filepath.Join(tmpDir, file.Filename)))
 		}
 
+		// Re-use the state left behind by a failed run of the stage, so that
+		// terraform picks up the resources it already created.
+		if previousDir != "" {
+			data, err := ioutil.ReadFile(filepath.Join(previousDir, stage.StateFilename()))
+			switch {
+			case err == nil:
+				if err := ioutil.WriteFile(filepath.Join(tmpDir, stage.StateFilename()), data, 0600); err != nil {
+					return err
+				}
+			case !os.IsNotExist(err):
+				return errors.Wrapf(err, "failed to read state file for stage %q", stage.Name())
+			}
+		}
+
 		outputs, err := c.applyTerraform(tmpDir, platform, stage, extraArgs)
 		if err != nil {
 			return err
@@ -125,28 +164,87 @@ func (c *Cluster) Generate(parents asset.Parents) (err error) {
 	return nil
 }
 
+// loadCompletedStage adds the state and outputs files of the stage in the
+// given directory to the FileList. It returns the outputs file, or nil if the
+// stage has not been completed.
+func (c *Cluster) loadCompletedStage(directory string, stage terraform.Stage) (*asset.File, error) {
+	outputs, err := ioutil.ReadFile(filepath.Join(directory, stage.OutputsFilename()))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "failed to read outputs file for stage %q", stage.Name())
+	}
+	state, err := ioutil.ReadFile(filepath.Join(directory, stage.StateFilename()))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read state file for completed stage %q", stage.Name())
+	}
+
+	outputsFile := &asset.File{
+		Filename: stage.OutputsFilename(),
+		Data:     outputs,
+	}
+	c.FileList = append(c.FileList, &asset.File{
+		Filename: stage.StateFilename(),
+		Data:     state,
+	}, outputsFile)
+	return outputsFile, nil
+}
+
+// terraformPlatform returns the name of the platform used to look up the
+// terraform stages for the install config.
+func terraformPlatform(installConfig *installconfig.InstallConfig) (string, error) {
+	if installConfig.Config.Platform.None != nil {
+		return "", errors.New("cluster cannot be created with platform set to 'none'")
+	}
+
+	if installConfig.Config.BootstrapInPlace != nil {
+		return "", errors.New("cluster cannot be created with bootstrapInPlace set")
+	}
+
+	platform := installConfig.Config.Platform.Name()
+
+	if azure := installConfig.Config.Platform.Azure; azure != nil && azure.CloudName == typesazure.StackCloud {
+		platform = typesazure.StackTerraformName
+	}
+	return platform, nil
+}
+
 // Files returns the FileList generated by the asset.
 func (c *Cluster) Files() []*asset.File {
 	return c.FileList
 }
 
 // Load returns error if the tfstate file is already on-disk, because we want to
-// prevent user from accidentally re-launching the cluster.
+// prevent user from accidentally re-launching the cluster. A partially
+// created cluster can be continued with Resume instead.
 func (c *Cluster) Load(f asset.FileFetcher) (found bool, err error) {
-	matches, err := filepath.Glob("terraform(.*)?.tfstate")
+	matches, err := f.FetchByPattern("terraform*.tfstate")
 	if err != nil {
 		return true, err
 	}
 	if len(matches) != 0 {
-		return true, errors.Errorf("terraform state files alread exist.  There may already be a running cluster")
+		return true, errors.Errorf("terraform state files already exist.  There may already be a running cluster. Use --resume to continue creating a partially created cluster")
 	}
 
 	return false, nil
 }
 
-func (c *Cluster) applyTerraform(tmpDir string, platform string, stage terraform.Stage, extraArgs []string) (*asset.File, error) {
+func (c *Cluster) applyTerraform(tmpDir string, platform string, stage terraform.Stage, extraArgs []string) (_ *asset.File, err error) {
 	timer.StartTimer(stage.Name())
-	defer timer.StopTimer(stage.Name())
+	events.New(events.StageStarted, map[string]interface{}{"stage": stage.Name()}).Debugf("Applying terraform stage %s", stage.Name())
+	defer func() {
+		duration := timer.StopTimer(stage.Name())
+		fields := map[string]interface{}{
+			"stage":           stage.Name(),
+			"durationSeconds": duration.Seconds(),
+			"success":         err == nil,
+		}
+		if err != nil {
+			fields["error"] = err.Error()
+		}
+		events.New(events.StageFinished, fields).Debugf("Finished terraform stage %s after %s", stage.Name(), duration)
+	}()
 
 	stateFile, err := terraform.Apply(tmpDir, platform, stage, extraArgs...)
 	if err != nil {
@@ -0,0 +1,114 @@
+package cluster
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/mock"
+	"github.com/openshift/installer/pkg/terraform/stages"
+	"github.com/openshift/installer/pkg/types"
+	awstypes "github.com/openshift/installer/pkg/types/aws"
+)
+
+func TestClusterLoad(t *testing.T) {
+	cases := []struct {
+		name          string
+		files         []*asset.File
+		expectedFound bool
+		expectedError bool
+	}{
+		{
+			name: "no state files",
+		},
+		{
+			name:          "existing state files",
+			files:         []*asset.File{{Filename: "terraform.cluster.tfstate"}},
+			expectedFound: true,
+			expectedError: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			fileFetcher := mock.NewMockFileFetcher(mockCtrl)
+			fileFetcher.EXPECT().FetchByPattern("terraform*.tfstate").Return(tc.files, nil)
+
+			c := &Cluster{}
+			found, err := c.Load(fileFetcher)
+			assert.Equal(t, tc.expectedFound, found, "unexpected found value returned from Load")
+			if tc.expectedError {
+				assert.Error(t, err, "expected error from Load")
+			} else {
+				assert.NoError(t, err, "unexpected error from Load")
+			}
+		})
+	}
+}
+
+func TestLoadCompletedStage(t *testing.T) {
+	dir, err := ioutil.TempDir("", "openshift-install-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	completed := stages.NewStage("aws", "cluster")
+	failed := stages.NewStage("aws", "bootstrap")
+	for name, data := range map[string]string{
+		completed.StateFilename():   "cluster-state",
+		completed.OutputsFilename(): `{"vpc_id":"vpc-1"}`,
+		failed.StateFilename():      "bootstrap-state",
+	} {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(data), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	c := &Cluster{}
+	outputs, err := c.loadCompletedStage(dir, completed)
+	assert.NoError(t, err)
+	assert.Equal(t, &asset.File{Filename: completed.OutputsFilename(), Data: []byte(`{"vpc_id":"vpc-1"}`)}, outputs)
+
+	outputs, err = c.loadCompletedStage(dir, failed)
+	assert.NoError(t, err)
+	assert.Nil(t, outputs)
+
+	assert.Equal(t, []*asset.File{
+		{Filename: completed.StateFilename(), Data: []byte("cluster-state")},
+		{Filename: completed.OutputsFilename(), Data: []byte(`{"vpc_id":"vpc-1"}`)},
+	}, c.FileList)
+}
+
+func TestSaveMetadata(t *testing.T) {
+	dir, err := ioutil.TempDir("", "openshift-install-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	metadata := &types.ClusterMetadata{
+		InfraID: "test-abcde",
+		ClusterPlatformMetadata: types.ClusterPlatformMetadata{
+			AWS: &awstypes.Metadata{
+				Region:     "us-east-1",
+				Identifier: []map[string]string{{"kubernetes.io/cluster/test-abcde": "owned"}},
+			},
+		},
+	}
+	assert.NoError(t, SaveMetadata(dir, metadata))
+
+	loaded, err := LoadMetadata(dir)
+	assert.NoError(t, err)
+	assert.Equal(t, metadata, loaded)
+
+	assert.EqualError(t, SaveMetadata(dir, metadata), fmt.Sprintf("%q already exists", filepath.Join(dir, "metadata.json")))
+}
@@ -3,6 +3,7 @@ package cluster
 import (
 	"encoding/json"
 	"io/ioutil"
+	"os"
 	"path/filepath"
 
 	"github.com/pkg/errors"
@@ -140,3 +141,24 @@ func LoadMetadata(dir string) (*types.ClusterMetadata, error) {
 
 	return metadata, err
 }
+
+// SaveMetadata writes the cluster metadata to an asset directory, refusing to
+// overwrite existing metadata.
+func SaveMetadata(dir string, metadata *types.ClusterMetadata) error {
+	data, err := json.Marshal(metadata)
+	if err != nil {
+		return errors.Wrap(err, "failed to Marshal ClusterMetadata")
+	}
+
+	path := filepath.Join(dir, metadataFileName)
+	if _, err := os.Stat(path); err == nil {
+		return errors.Errorf("%q already exists", path)
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, data, 0640)
+}
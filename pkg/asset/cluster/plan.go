@@ -0,0 +1,83 @@
+package cluster
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/installconfig"
+	"github.com/openshift/installer/pkg/terraform"
+	platformstages "github.com/openshift/installer/pkg/terraform/stages/platform"
+)
+
+// Plan runs terraform plan for every stage of the platform without creating
+// any infrastructure. Stages are planned in order, and the outputs of each
+// planned stage are passed to the later stages. Outputs that are not known
+// until apply are replaced with placeholders of the type of the variables of
+// the later stages.
+func Plan(installConfig *installconfig.InstallConfig, terraformVariables *TerraformVariables) ([]*terraform.StagePlan, error) {
+	platform, err := terraformPlatform(installConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	stages := platformstages.StagesForPlatform(platform)
+
+	plans := make([]*terraform.StagePlan, 0, len(stages))
+	for i, stage := range stages {
+		plan, err := planStage(platform, stage, terraformVariables.Files(), stages[:i], plans)
+		if err != nil {
+			return plans, errors.Wrapf(err, "failed to plan stage %q", stage.Name())
+		}
+		plans = append(plans, plan)
+	}
+
+	return plans, nil
+}
+
+// planStage plans the stage with the outputs of the planned previous stages.
+func planStage(platform string, stage terraform.Stage, varFiles []*asset.File, previousStages []terraform.Stage, previousPlans []*terraform.StagePlan) (*terraform.StagePlan, error) {
+	tmpDir, err := ioutil.TempDir("", fmt.Sprintf("openshift-install-%s-", stage.Name()))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create temp dir for terraform execution")
+	}
+	defer os.RemoveAll(tmpDir)
+
+	variableTypes, err := terraform.VariableTypes(tmpDir, platform, stage)
+	if err != nil {
+		return nil, err
+	}
+
+	tfvarsFiles := make([]*asset.File, 0, len(varFiles)+len(previousPlans))
+	tfvarsFiles = append(tfvarsFiles, varFiles...)
+	for i, plan := range previousPlans {
+		outputs, err := plan.OutputsFile(variableTypes)
+		if err != nil {
+			return nil, err
+		}
+		tfvarsFiles = append(tfvarsFiles, &asset.File{
+			Filename: previousStages[i].OutputsFilename(),
+			Data:     outputs,
+		})
+	}
+
+	var extraArgs []string
+	for _, file := range tfvarsFiles {
+		if err := ioutil.WriteFile(filepath.Join(tmpDir, file.Filename), file.Data, 0600); err != nil {
+			return nil, err
+		}
+		extraArgs = append(extraArgs, fmt.Sprintf("-var-file=%s", filepath.Join(tmpDir, file.Filename)))
+	}
+
+	logrus.Infof("Planning infrastructure resources for stage %s...", stage.Name())
+	data, err := terraform.Plan(tmpDir, platform, stage, extraArgs...)
+	if err != nil {
+		return nil, err
+	}
+	return terraform.ParsePlan(stage.Name(), data)
+}
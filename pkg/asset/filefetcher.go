@@ -8,4 +8,7 @@ type FileFetcher interface {
 	FetchByName(string) (*File, error)
 	// FetchByPattern returns the files whose name match the given glob.
 	FetchByPattern(pattern string) ([]*File, error)
+	// FetchByDirectory returns the files in the given directory and its subdirectories. No files
+	// are returned when the directory does not exist.
+	FetchByDirectory(directory string) ([]*File, error)
 }
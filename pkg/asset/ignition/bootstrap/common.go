@@ -4,7 +4,6 @@ import (
 	"bytes"
 	"crypto/x509"
 	"encoding/json"
-	"encoding/pem"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -103,6 +102,7 @@ func (a *Common) Dependencies() []asset.Asset {
 		&kubeconfig.LoopbackClient{},
 		&mcign.MasterIgnitionCustomizations{},
 		&mcign.WorkerIgnitionCustomizations{},
+		&Overlay{},
 		&machines.Master{},
 		&machines.Worker{},
 		&manifests.Manifests{},
@@ -114,6 +114,7 @@ func (a *Common) Dependencies() []asset.Asset {
 		&tls.AggregatorClientCertKey{},
 		&tls.AggregatorSignerCertKey{},
 		&tls.APIServerProxyCertKey{},
+		&tls.BootstrapSSHHostKey{},
 		&tls.BootstrapSSHKeyPair{},
 		&tls.BoundSASigningKey{},
 		&tls.CloudProviderCABundle{},
@@ -192,6 +193,16 @@ func (a *Common) generateConfig(dependencies asset.Parents, templateData *bootst
 
 	a.addParentFiles(dependencies)
 
+	overlay := &Overlay{}
+	dependencies.Get(overlay)
+	applyOverlay(a.Config, overlay)
+
+	// Pin the host key of the SSH server, so that the installer can verify the bootstrap-host when gathering logs.
+	bootstrapSSHHostKey := &tls.BootstrapSSHHostKey{}
+	dependencies.Get(bootstrapSSHHostKey)
+	a.Config.Storage.Files = replaceOrAppend(a.Config.Storage.Files, ignition.FileFromBytes(tls.BootstrapSSHHostKeyPath, "root", 0600, bootstrapSSHHostKey.Private()))
+	a.Config.Storage.Files = replaceOrAppend(a.Config.Storage.Files, ignition.FileFromBytes(tls.BootstrapSSHHostKeyPath+".pub", "root", 0644, bootstrapSSHHostKey.Public()))
+
 	a.Config.Passwd.Users = append(
 		a.Config.Passwd.Users,
 		igntypes.PasswdUser{Name: "core", SSHAuthorizedKeys: []igntypes.SSHAuthorizedKey{
@@ -337,8 +348,19 @@ func (a *Common) addStorageFiles(base string, uri string, templateData *bootstra
 		return err
 	}
 
-	filename := path.Base(uri)
-	parentDir := path.Base(path.Dir(uri))
+	ign := storageFile(strings.TrimSuffix(base, ".template"), data)
+
+	// Replace files that already exist in the slice with ones added later, otherwise append them
+	a.Config.Storage.Files = replaceOrAppend(a.Config.Storage.Files, ign)
+
+	return nil
+}
+
+// storageFile returns the Ignition file at the path with the data, with the mode used for the files of its
+// directory. The motd is appended to, rather than replaced.
+func storageFile(filePath string, data []byte) igntypes.File {
+	filename := path.Base(filePath)
+	parentDir := path.Base(path.Dir(filePath))
 
 	var mode int
 	appendToFile := false
@@ -350,15 +372,11 @@ func (a *Common) addStorageFiles(base string, uri string, templateData *bootstra
 	} else {
 		mode = 0600
 	}
-	ign := ignition.FileFromBytes(strings.TrimSuffix(base, ".template"), "root", mode, data)
+	ign := ignition.FileFromBytes(filePath, "root", mode, data)
 	if appendToFile {
 		ignition.ConvertToAppendix(&ign)
 	}
-
-	// Replace files that already exist in the slice with ones added later, otherwise append them
-	a.Config.Storage.Files = replaceOrAppend(a.Config.Storage.Files, ign)
-
-	return nil
+	return ign
 }
 
 func (a *Common) addSystemdUnits(uri string, templateData *bootstrapTemplateData, enabledServices []string) (err error) {
@@ -591,33 +609,12 @@ func (a *Common) load(f asset.FileFetcher, filename string) (found bool, err err
 // warnIfCertificatesExpired checks for expired certificates and warns if so
 func warnIfCertificatesExpired(config *igntypes.Config) {
 	expiredCerts := 0
-	for _, file := range config.Storage.Files {
-		if filepath.Ext(file.Path) == ".crt" && file.Contents.Source != nil {
-			fileName := path.Base(file.Path)
-			decoded, err := dataurl.DecodeString(*file.Contents.Source)
-			if err != nil {
-				logrus.Debugf("Unable to decode certificate %s: %s", fileName, err.Error())
-				continue
-			}
-			data := decoded.Data
-			for {
-				block, rest := pem.Decode(data)
-				if block == nil {
-					break
-				}
-
-				cert, err := x509.ParseCertificate(block.Bytes)
-				if err == nil {
-					if time.Now().UTC().After(cert.NotAfter) {
-						logrus.Warnf("Bootstrap Ignition-Config Certificate %s expired at %s.", path.Base(file.Path), cert.NotAfter.Format(time.RFC3339))
-						expiredCerts++
-					}
-				} else {
-					logrus.Debugf("Unable to parse certificate %s: %s", fileName, err.Error())
-					break
-				}
-
-				data = rest
+	now := time.Now().UTC()
+	for _, file := range CertificateFiles(config) {
+		for _, cert := range file.Certificates {
+			if now.After(cert.NotAfter) {
+				logrus.Warnf("Bootstrap Ignition-Config Certificate %s expired at %s.", path.Base(file.Path), cert.NotAfter.Format(time.RFC3339))
+				expiredCerts++
 			}
 		}
 	}
@@ -627,6 +624,37 @@ func warnIfCertificatesExpired(config *igntypes.Config) {
 	}
 }
 
+// CertificateFile is a file of certificates of an Ignition config.
+type CertificateFile struct {
+	Path         string
+	Certificates []*x509.Certificate
+}
+
+// CertificateFiles returns the certificates of the .crt files of the Ignition config. The
+// certificates that cannot be decoded are skipped.
+func CertificateFiles(config *igntypes.Config) []CertificateFile {
+	var files []CertificateFile
+	for _, file := range config.Storage.Files {
+		if filepath.Ext(file.Path) != ".crt" || file.Contents.Source == nil {
+			continue
+		}
+		fileName := path.Base(file.Path)
+		decoded, err := dataurl.DecodeString(*file.Contents.Source)
+		if err != nil {
+			logrus.Debugf("Unable to decode certificate %s: %s", fileName, err.Error())
+			continue
+		}
+		certs, err := tls.PemToCertificates(decoded.Data)
+		if err != nil {
+			logrus.Debugf("Unable to parse certificate %s: %s", fileName, err.Error())
+		}
+		if len(certs) > 0 {
+			files = append(files, CertificateFile{Path: file.Path, Certificates: certs})
+		}
+	}
+	return files
+}
+
 // APIVIP returns a string representation of the platform's API VIP
 // It returns an empty string if the platform does not configure a VIP
 func apiVIP(p *types.Platform) string {
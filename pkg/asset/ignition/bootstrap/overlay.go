@@ -0,0 +1,171 @@
+package bootstrap
+
+import (
+	"path/filepath"
+	"strings"
+
+	ignutil "github.com/coreos/ignition/v2/config/util"
+	igntypes "github.com/coreos/ignition/v2/config/v3_2/types"
+	"github.com/coreos/ignition/v2/config/validate"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"github.com/openshift/installer/pkg/asset"
+)
+
+const (
+	// overlayDir is the directory of the bootstrap overlay in the asset directory. It has the layout of
+	// data/data/bootstrap: the files of its files directory are written to the same paths on the bootstrap
+	// machine, and the files of its systemd/units directory are systemd units, or drop-ins when they are in
+	// a <unit>.d directory.
+	overlayDir           = "openshift/bootstrap-overlay"
+	overlayFilesDir      = "files"
+	overlayUnitsDir      = "systemd/units"
+	overlayDropinsSuffix = ".d"
+)
+
+// Overlay is an asset of the files and systemd units provided by the user in the openshift/bootstrap-overlay
+// directory, which are added to the bootstrap Ignition config. They replace the files and units of the same
+// paths and names generated by the installer.
+type Overlay struct {
+	Config   *igntypes.Config
+	FileList []*asset.File
+}
+
+var _ asset.WritableAsset = (*Overlay)(nil)
+
+// Dependencies returns no dependencies.
+func (o *Overlay) Dependencies() []asset.Asset {
+	return []asset.Asset{}
+}
+
+// Generate generates an empty overlay, as the overlay is only provided by the user.
+func (o *Overlay) Generate(asset.Parents) error {
+	o.Config = &igntypes.Config{}
+	return nil
+}
+
+// Name returns the human-friendly name of the asset.
+func (o *Overlay) Name() string {
+	return "Bootstrap Ignition Overlay"
+}
+
+// Files returns the files of the overlay.
+func (o *Overlay) Files() []*asset.File {
+	return o.FileList
+}
+
+// Load loads and validates the overlay from the openshift/bootstrap-overlay directory.
+func (o *Overlay) Load(f asset.FileFetcher) (bool, error) {
+	files, err := f.FetchByDirectory(overlayDir)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to load the bootstrap overlay")
+	}
+	if len(files) == 0 {
+		return false, nil
+	}
+
+	config, err := overlayConfig(files)
+	if err != nil {
+		return false, errors.Wrapf(err, "invalid bootstrap overlay in %s", overlayDir)
+	}
+	logrus.Infof("Adding %d files and %d systemd units of %s to the bootstrap Ignition config", len(config.Storage.Files), len(config.Systemd.Units), overlayDir)
+
+	o.Config, o.FileList = config, files
+	return true, nil
+}
+
+// overlayConfig returns the Ignition config of the files of the overlay directory.
+func overlayConfig(files []*asset.File) (*igntypes.Config, error) {
+	config := &igntypes.Config{
+		Ignition: igntypes.Ignition{
+			Version: igntypes.MaxVersion.String(),
+		},
+	}
+	for _, f := range files {
+		name, err := filepath.Rel(overlayDir, f.Filename)
+		if err != nil {
+			return nil, err
+		}
+		name = filepath.ToSlash(name)
+
+		switch {
+		case strings.HasPrefix(name, overlayFilesDir+"/"):
+			config.Storage.Files = append(config.Storage.Files, storageFile(strings.TrimPrefix(name, overlayFilesDir), f.Data))
+		case strings.HasPrefix(name, overlayUnitsDir+"/"):
+			unitPath := strings.Split(strings.TrimPrefix(name, overlayUnitsDir+"/"), "/")
+			switch {
+			case len(unitPath) == 1:
+				config.Systemd.Units = replaceOrAppendUnit(config.Systemd.Units, igntypes.Unit{
+					Name:     unitPath[0],
+					Contents: ignutil.StrToPtr(string(f.Data)),
+					Enabled:  ignutil.BoolToPtr(true),
+				})
+			case len(unitPath) == 2 && strings.HasSuffix(unitPath[0], overlayDropinsSuffix):
+				config.Systemd.Units = replaceOrAppendUnit(config.Systemd.Units, igntypes.Unit{
+					Name: strings.TrimSuffix(unitPath[0], overlayDropinsSuffix),
+					Dropins: []igntypes.Dropin{{
+						Name:     unitPath[1],
+						Contents: ignutil.StrToPtr(string(f.Data)),
+					}},
+				})
+			default:
+				return nil, errors.Errorf("%s is neither a systemd unit nor a drop-in in a <unit>.d directory", f.Filename)
+			}
+		default:
+			return nil, errors.Errorf("%s is not in the %s or %s directories", f.Filename, overlayFilesDir, overlayUnitsDir)
+		}
+	}
+
+	if report := validate.ValidateWithContext(config, nil); report.IsFatal() {
+		return nil, errors.New(report.String())
+	}
+	return config, nil
+}
+
+// applyOverlay adds the files and units of the overlay to the config, replacing the files and the units
+// with the same paths and names.
+func applyOverlay(config *igntypes.Config, overlay *Overlay) {
+	if overlay.Config == nil {
+		return
+	}
+	for _, file := range overlay.Config.Storage.Files {
+		for _, f := range config.Storage.Files {
+			if f.Path == file.Path {
+				logrus.Warnf("The bootstrap overlay replaces the file %s generated by the installer", file.Path)
+			}
+		}
+		config.Storage.Files = replaceOrAppend(config.Storage.Files, file)
+	}
+	for _, unit := range overlay.Config.Systemd.Units {
+		config.Systemd.Units = replaceOrAppendUnit(config.Systemd.Units, unit)
+	}
+}
+
+// replaceOrAppendUnit adds the unit to the units. When a unit has the same name, its contents are replaced
+// by the contents of the unit, if any, and its drop-ins are replaced or appended by the drop-ins of the unit.
+func replaceOrAppendUnit(units []igntypes.Unit, unit igntypes.Unit) []igntypes.Unit {
+	for i, u := range units {
+		if u.Name != unit.Name {
+			continue
+		}
+		if unit.Contents != nil {
+			units[i].Contents = unit.Contents
+			units[i].Enabled = unit.Enabled
+		}
+		for _, dropin := range unit.Dropins {
+			replaced := false
+			for j, d := range units[i].Dropins {
+				if d.Name == dropin.Name {
+					units[i].Dropins[j] = dropin
+					replaced = true
+				}
+			}
+			if !replaced {
+				units[i].Dropins = append(units[i].Dropins, dropin)
+			}
+		}
+		return units
+	}
+	return append(units, unit)
+}
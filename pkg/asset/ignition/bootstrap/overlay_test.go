@@ -0,0 +1,136 @@
+package bootstrap
+
+import (
+	"testing"
+
+	ignutil "github.com/coreos/ignition/v2/config/util"
+	igntypes "github.com/coreos/ignition/v2/config/v3_2/types"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/ignition"
+	"github.com/openshift/installer/pkg/asset/mock"
+)
+
+func TestOverlayLoad(t *testing.T) {
+	cases := []struct {
+		name          string
+		files         []*asset.File
+		expectedFound bool
+		expectedFiles []igntypes.File
+		expectedUnits []igntypes.Unit
+		expectedError string
+	}{
+		{
+			name: "no overlay",
+		},
+		{
+			name: "files and units",
+			files: []*asset.File{
+				{Filename: "openshift/bootstrap-overlay/files/usr/local/bin/agent.sh", Data: []byte("#!/bin/sh\n")},
+				{Filename: "openshift/bootstrap-overlay/files/etc/pki/ca-trust/source/anchors/corp.pem", Data: []byte("CA")},
+				{Filename: "openshift/bootstrap-overlay/systemd/units/agent.service", Data: []byte("[Service]\n")},
+				{Filename: "openshift/bootstrap-overlay/systemd/units/kubelet.service.d/10-proxy.conf", Data: []byte("[Service]\nEnvironment=A=1\n")},
+			},
+			expectedFound: true,
+			expectedFiles: []igntypes.File{
+				ignition.FileFromString("/usr/local/bin/agent.sh", "root", 0555, "#!/bin/sh\n"),
+				ignition.FileFromString("/etc/pki/ca-trust/source/anchors/corp.pem", "root", 0600, "CA"),
+			},
+			expectedUnits: []igntypes.Unit{
+				{Name: "agent.service", Contents: ignutil.StrToPtr("[Service]\n"), Enabled: ignutil.BoolToPtr(true)},
+				{Name: "kubelet.service", Dropins: []igntypes.Dropin{{Name: "10-proxy.conf", Contents: ignutil.StrToPtr("[Service]\nEnvironment=A=1\n")}}},
+			},
+		},
+		{
+			name: "file outside of the overlay directories",
+			files: []*asset.File{
+				{Filename: "openshift/bootstrap-overlay/agent.sh", Data: []byte("#!/bin/sh\n")},
+			},
+			expectedError: "invalid bootstrap overlay in openshift/bootstrap-overlay: openshift/bootstrap-overlay/agent.sh is not in the files or systemd/units directories",
+		},
+		{
+			name: "nested drop-in",
+			files: []*asset.File{
+				{Filename: "openshift/bootstrap-overlay/systemd/units/kubelet.service.d/conf/10-proxy.conf", Data: []byte("[Service]\n")},
+			},
+			expectedError: "invalid bootstrap overlay in openshift/bootstrap-overlay: openshift/bootstrap-overlay/systemd/units/kubelet.service.d/conf/10-proxy.conf is neither a systemd unit nor a drop-in in a <unit>.d directory",
+		},
+		{
+			name: "invalid unit",
+			files: []*asset.File{
+				{Filename: "openshift/bootstrap-overlay/systemd/units/agent", Data: []byte("[Service]\n")},
+			},
+			expectedError: `invalid bootstrap overlay in openshift/bootstrap-overlay: error at $.systemd.units.0.name: invalid systemd unit extension`,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			fileFetcher := mock.NewMockFileFetcher(mockCtrl)
+			fileFetcher.EXPECT().FetchByDirectory("openshift/bootstrap-overlay").Return(tc.files, nil)
+
+			overlay := &Overlay{}
+			found, err := overlay.Load(fileFetcher)
+			if tc.expectedError != "" {
+				if assert.Error(t, err) {
+					assert.Contains(t, err.Error(), tc.expectedError)
+				}
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedFound, found)
+			if found {
+				assert.Equal(t, tc.expectedFiles, overlay.Config.Storage.Files)
+				assert.Equal(t, tc.expectedUnits, overlay.Config.Systemd.Units)
+				assert.Equal(t, tc.files, overlay.Files())
+			}
+		})
+	}
+}
+
+func TestApplyOverlay(t *testing.T) {
+	config := &igntypes.Config{}
+	config.Storage.Files = []igntypes.File{
+		ignition.FileFromString("/usr/local/bin/bootkube.sh", "root", 0555, "bootkube"),
+		ignition.FileFromString("/etc/motd", "root", 0644, "motd"),
+	}
+	config.Systemd.Units = []igntypes.Unit{
+		{Name: "kubelet.service", Contents: ignutil.StrToPtr("kubelet"), Enabled: ignutil.BoolToPtr(true), Dropins: []igntypes.Dropin{
+			{Name: "10-a.conf", Contents: ignutil.StrToPtr("a")},
+		}},
+		{Name: "bootkube.service", Contents: ignutil.StrToPtr("bootkube")},
+	}
+
+	overlay := &Overlay{Config: &igntypes.Config{}}
+	overlay.Config.Storage.Files = []igntypes.File{
+		ignition.FileFromString("/usr/local/bin/bootkube.sh", "root", 0555, "custom bootkube"),
+		ignition.FileFromString("/usr/local/bin/agent.sh", "root", 0555, "agent"),
+	}
+	overlay.Config.Systemd.Units = []igntypes.Unit{
+		{Name: "kubelet.service", Dropins: []igntypes.Dropin{
+			{Name: "10-a.conf", Contents: ignutil.StrToPtr("custom a")},
+			{Name: "20-b.conf", Contents: ignutil.StrToPtr("b")},
+		}},
+		{Name: "agent.service", Contents: ignutil.StrToPtr("agent"), Enabled: ignutil.BoolToPtr(true)},
+	}
+
+	applyOverlay(config, overlay)
+	assert.Equal(t, []igntypes.File{
+		ignition.FileFromString("/usr/local/bin/bootkube.sh", "root", 0555, "custom bootkube"),
+		ignition.FileFromString("/etc/motd", "root", 0644, "motd"),
+		ignition.FileFromString("/usr/local/bin/agent.sh", "root", 0555, "agent"),
+	}, config.Storage.Files)
+	assert.Equal(t, []igntypes.Unit{
+		{Name: "kubelet.service", Contents: ignutil.StrToPtr("kubelet"), Enabled: ignutil.BoolToPtr(true), Dropins: []igntypes.Dropin{
+			{Name: "10-a.conf", Contents: ignutil.StrToPtr("custom a")},
+			{Name: "20-b.conf", Contents: ignutil.StrToPtr("b")},
+		}},
+		{Name: "bootkube.service", Contents: ignutil.StrToPtr("bootkube")},
+		{Name: "agent.service", Contents: ignutil.StrToPtr("agent"), Enabled: ignutil.BoolToPtr(true)},
+	}, config.Systemd.Units)
+}
@@ -38,12 +38,13 @@ func TestMasterGenerate(t *testing.T) {
 		},
 	}
 
+	parents := asset.Parents{}
+	parents.Add(installConfig)
+
 	rootCA := &tls.RootCA{}
-	err := rootCA.Generate(nil)
+	err := rootCA.Generate(parents)
 	assert.NoError(t, err, "unexpected error generating root CA")
-
-	parents := asset.Parents{}
-	parents.Add(installConfig, rootCA)
+	parents.Add(rootCA)
 
 	master := &Master{}
 	err = master.Generate(parents)
@@ -28,12 +28,13 @@ func TestWorkerGenerate(t *testing.T) {
 		},
 	}
 
+	parents := asset.Parents{}
+	parents.Add(installConfig)
+
 	rootCA := &tls.RootCA{}
-	err := rootCA.Generate(nil)
+	err := rootCA.Generate(parents)
 	assert.NoError(t, err, "unexpected error generating root CA")
-
-	parents := asset.Parents{}
-	parents.Add(installConfig, rootCA)
+	parents.Add(rootCA)
 
 	worker := &Worker{}
 	err = worker.Generate(parents)
@@ -6,8 +6,11 @@ import (
 	"github.com/aws/aws-sdk-go/service/iam"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/sets"
 
 	ccaws "github.com/openshift/cloud-credential-operator/pkg/aws"
+	"github.com/openshift/installer/pkg/types"
+	awstypes "github.com/openshift/installer/pkg/types/aws"
 )
 
 // PermissionGroup is the group of permissions needed by cluster creation, operation, or teardown.
@@ -32,6 +35,10 @@ const (
 	// PermissionDeleteSharedInstanceRole is a set of permissions required when the installer destroys resources from a
 	// cluster with user-supplied IAM roles for instances.
 	PermissionDeleteSharedInstanceRole PermissionGroup = "delete-shared-instance-role"
+
+	// PermissionKMSEncryptionKeys is an additional set of permissions required when the installer encrypts the
+	// volumes of the machines with user-supplied KMS keys.
+	PermissionKMSEncryptionKeys PermissionGroup = "kms-encryption-keys"
 )
 
 var permissions = map[PermissionGroup][]string{
@@ -231,6 +238,105 @@ var permissions = map[PermissionGroup][]string{
 	PermissionDeleteSharedInstanceRole: {
 		"iam:UntagRole",
 	},
+	// Permissions required for encrypting volumes with user-supplied KMS keys
+	PermissionKMSEncryptionKeys: {
+		"kms:CreateGrant",
+		"kms:Decrypt",
+		"kms:DescribeKey",
+		"kms:Encrypt",
+		"kms:GenerateDataKey",
+		"kms:GenerateDataKeyWithoutPlainText",
+		"kms:ListGrants",
+		"kms:ReEncrypt*",
+		"kms:RevokeGrant",
+	},
+}
+
+// RequiredPermissionGroups returns the permission groups needed to create, and
+// unless the region does not allow it, to destroy the cluster of the install config.
+// It is used to generate the IAM policy; the credentials pre-flight does not check
+// the groups of the KMS keys and instance roles, which may be granted on specific
+// resources only.
+func RequiredPermissionGroups(ic *types.InstallConfig) []PermissionGroup {
+	permissionGroups := []PermissionGroup{PermissionCreateBase}
+	usingExistingVPC := len(ic.AWS.Subnets) != 0
+
+	if !usingExistingVPC {
+		permissionGroups = append(permissionGroups, PermissionCreateNetworking)
+	}
+
+	var usingKMSKeys, usingExistingInstanceRoles bool
+	for _, mpool := range machinePools(ic) {
+		usingKMSKeys = usingKMSKeys || mpool.EC2RootVolume.KMSKeyARN != ""
+		usingExistingInstanceRoles = usingExistingInstanceRoles || mpool.IAMRole != ""
+	}
+	if usingKMSKeys {
+		permissionGroups = append(permissionGroups, PermissionKMSEncryptionKeys)
+	}
+
+	// Add delete permissions for non-C2S installs.
+	if !awstypes.C2SRegions.Has(ic.AWS.Region) {
+		permissionGroups = append(permissionGroups, PermissionDeleteBase)
+		if usingExistingVPC {
+			permissionGroups = append(permissionGroups, PermissionDeleteSharedNetworking)
+		} else {
+			permissionGroups = append(permissionGroups, PermissionDeleteNetworking)
+		}
+		if usingExistingInstanceRoles {
+			permissionGroups = append(permissionGroups, PermissionDeleteSharedInstanceRole)
+		}
+	}
+	return permissionGroups
+}
+
+// machinePools returns the AWS machine pools of the install config.
+func machinePools(ic *types.InstallConfig) []*awstypes.MachinePool {
+	var mpools []*awstypes.MachinePool
+	if ic.AWS.DefaultMachinePlatform != nil {
+		mpools = append(mpools, ic.AWS.DefaultMachinePlatform)
+	}
+	if ic.ControlPlane != nil && ic.ControlPlane.Platform.AWS != nil {
+		mpools = append(mpools, ic.ControlPlane.Platform.AWS)
+	}
+	for _, compute := range ic.Compute {
+		if compute.Platform.AWS != nil {
+			mpools = append(mpools, compute.Platform.AWS)
+		}
+	}
+	return mpools
+}
+
+// PolicyDocument is an IAM policy document.
+type PolicyDocument struct {
+	Version   string            `json:"Version"`
+	Statement []PolicyStatement `json:"Statement"`
+}
+
+// PolicyStatement is a statement of an IAM policy document.
+type PolicyStatement struct {
+	Effect   string   `json:"Effect"`
+	Action   []string `json:"Action"`
+	Resource string   `json:"Resource"`
+}
+
+// Policy returns the IAM policy document allowing the actions of the permission groups.
+func Policy(groups []PermissionGroup) (*PolicyDocument, error) {
+	actions := sets.NewString()
+	for _, group := range groups {
+		groupPerms, ok := permissions[group]
+		if !ok {
+			return nil, errors.Errorf("unable to access permissions group %s", group)
+		}
+		actions.Insert(groupPerms...)
+	}
+	return &PolicyDocument{
+		Version: "2012-10-17",
+		Statement: []PolicyStatement{{
+			Effect:   "Allow",
+			Action:   actions.List(),
+			Resource: "*",
+		}},
+	}, nil
 }
 
 // ValidateCreds will try to create an AWS session, and also verify that the current credentials
@@ -0,0 +1,77 @@
+package aws
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/installer/pkg/types"
+	awstypes "github.com/openshift/installer/pkg/types/aws"
+)
+
+func TestRequiredPermissionGroups(t *testing.T) {
+	cases := []struct {
+		name     string
+		edit     func(ic *types.InstallConfig)
+		expected []PermissionGroup
+	}{
+		{
+			name:     "default",
+			expected: []PermissionGroup{PermissionCreateBase, PermissionCreateNetworking, PermissionDeleteBase, PermissionDeleteNetworking},
+		},
+		{
+			name: "existing VPC",
+			edit: func(ic *types.InstallConfig) {
+				ic.AWS.Subnets = []string{"subnet-1"}
+			},
+			expected: []PermissionGroup{PermissionCreateBase, PermissionDeleteBase, PermissionDeleteSharedNetworking},
+		},
+		{
+			name: "KMS key and existing instance role",
+			edit: func(ic *types.InstallConfig) {
+				ic.ControlPlane.Platform.AWS = &awstypes.MachinePool{
+					EC2RootVolume: awstypes.EC2RootVolume{KMSKeyARN: "arn:aws:kms:us-east-1:123456789012:key/1"},
+				}
+				ic.Compute = []types.MachinePool{{Platform: types.MachinePoolPlatform{AWS: &awstypes.MachinePool{IAMRole: "worker-role"}}}}
+			},
+			expected: []PermissionGroup{PermissionCreateBase, PermissionCreateNetworking, PermissionKMSEncryptionKeys, PermissionDeleteBase, PermissionDeleteNetworking, PermissionDeleteSharedInstanceRole},
+		},
+		{
+			name: "C2S region",
+			edit: func(ic *types.InstallConfig) {
+				ic.AWS.Region = "us-iso-east-1"
+			},
+			expected: []PermissionGroup{PermissionCreateBase, PermissionCreateNetworking},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ic := &types.InstallConfig{
+				ControlPlane: &types.MachinePool{},
+				Platform: types.Platform{
+					AWS: &awstypes.Platform{Region: "us-east-1"},
+				},
+			}
+			if tc.edit != nil {
+				tc.edit(ic)
+			}
+			assert.Equal(t, tc.expected, RequiredPermissionGroups(ic))
+		})
+	}
+}
+
+func TestPolicy(t *testing.T) {
+	policy, err := Policy([]PermissionGroup{PermissionDeleteSharedNetworking, PermissionDeleteSharedInstanceRole, PermissionDeleteSharedNetworking})
+	assert.NoError(t, err)
+	assert.Equal(t, &PolicyDocument{
+		Version: "2012-10-17",
+		Statement: []PolicyStatement{{
+			Effect:   "Allow",
+			Action:   []string{"iam:UntagRole", "tag:UnTagResources"},
+			Resource: "*",
+		}},
+	}, policy)
+
+	_, err = Policy([]PermissionGroup{"unknown"})
+	assert.EqualError(t, err, "unable to access permissions group unknown")
+}
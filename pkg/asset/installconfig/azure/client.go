@@ -27,6 +27,8 @@ type API interface {
 	GetGroup(ctx context.Context, groupName string) (*azres.Group, error)
 	ListResourceIDsByGroup(ctx context.Context, groupName string) ([]string, error)
 	GetStorageEndpointSuffix(ctx context.Context) (string, error)
+	GetComputeUsages(ctx context.Context, region string) ([]azsku.Usage, error)
+	GetNetworkUsages(ctx context.Context, region string) ([]aznetwork.Usage, error)
 }
 
 // Client makes calls to the Azure API.
@@ -250,3 +252,39 @@ func (c *Client) GetVirtualMachineSku(ctx context.Context, name, region string)
 	}
 	return nil, nil
 }
+
+// GetComputeUsages returns the usage and limit of the compute quotas, e.g.
+// the vCPUs of each virtual machine family, of the subscription in a region.
+func (c *Client) GetComputeUsages(ctx context.Context, region string) ([]azsku.Usage, error) {
+	client := azsku.NewUsageClientWithBaseURI(c.ssn.Environment.ResourceManagerEndpoint, c.ssn.Credentials.SubscriptionID)
+	client.Authorizer = c.ssn.Authorizer
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	var usages []azsku.Usage
+	for page, err := client.List(ctx, region); page.NotDone(); err = page.NextWithContext(ctx) {
+		if err != nil {
+			return nil, errors.Wrap(err, "error fetching compute usage pages")
+		}
+		usages = append(usages, page.Values()...)
+	}
+	return usages, nil
+}
+
+// GetNetworkUsages returns the usage and limit of the network quotas, e.g.
+// the public IP addresses, of the subscription in a region.
+func (c *Client) GetNetworkUsages(ctx context.Context, region string) ([]aznetwork.Usage, error) {
+	client := aznetwork.NewUsagesClientWithBaseURI(c.ssn.Environment.ResourceManagerEndpoint, c.ssn.Credentials.SubscriptionID)
+	client.Authorizer = c.ssn.Authorizer
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	var usages []aznetwork.Usage
+	for page, err := client.List(ctx, region); page.NotDone(); err = page.NextWithContext(ctx) {
+		if err != nil {
+			return nil, errors.Wrap(err, "error fetching network usage pages")
+		}
+		usages = append(usages, page.Values()...)
+	}
+	return usages, nil
+}
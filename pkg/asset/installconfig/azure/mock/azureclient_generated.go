@@ -53,6 +53,21 @@ func (mr *MockAPIMockRecorder) GetComputeSubnet(ctx, resourceGroupName, virtualN
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetComputeSubnet", reflect.TypeOf((*MockAPI)(nil).GetComputeSubnet), ctx, resourceGroupName, virtualNetwork, subnet)
 }
 
+// GetComputeUsages mocks base method.
+func (m *MockAPI) GetComputeUsages(ctx context.Context, region string) ([]compute.Usage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetComputeUsages", ctx, region)
+	ret0, _ := ret[0].([]compute.Usage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetComputeUsages indicates an expected call of GetComputeUsages.
+func (mr *MockAPIMockRecorder) GetComputeUsages(ctx, region interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetComputeUsages", reflect.TypeOf((*MockAPI)(nil).GetComputeUsages), ctx, region)
+}
+
 // GetControlPlaneSubnet mocks base method.
 func (m *MockAPI) GetControlPlaneSubnet(ctx context.Context, resourceGroupName, virtualNetwork, subnet string) (*network.Subnet, error) {
 	m.ctrl.T.Helper()
@@ -98,6 +113,21 @@ func (mr *MockAPIMockRecorder) GetGroup(ctx, groupName interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGroup", reflect.TypeOf((*MockAPI)(nil).GetGroup), ctx, groupName)
 }
 
+// GetNetworkUsages mocks base method.
+func (m *MockAPI) GetNetworkUsages(ctx context.Context, region string) ([]network.Usage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNetworkUsages", ctx, region)
+	ret0, _ := ret[0].([]network.Usage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNetworkUsages indicates an expected call of GetNetworkUsages.
+func (mr *MockAPIMockRecorder) GetNetworkUsages(ctx, region interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNetworkUsages", reflect.TypeOf((*MockAPI)(nil).GetNetworkUsages), ctx, region)
+}
+
 // GetResourcesProvider mocks base method.
 func (m *MockAPI) GetResourcesProvider(ctx context.Context, resourceProviderNamespace string) (*resources.Provider, error) {
 	m.ctrl.T.Helper()
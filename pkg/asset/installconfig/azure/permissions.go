@@ -0,0 +1,74 @@
+package azure
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/openshift/installer/pkg/types"
+	azuretypes "github.com/openshift/installer/pkg/types/azure"
+)
+
+// RoleDefinition is a custom role definition, in the format of
+// `az role definition create`.
+type RoleDefinition struct {
+	Name             string   `json:"Name"`
+	IsCustom         bool     `json:"IsCustom"`
+	Description      string   `json:"Description"`
+	Actions          []string `json:"Actions"`
+	NotActions       []string `json:"NotActions"`
+	AssignableScopes []string `json:"AssignableScopes"`
+}
+
+// RequiredRoleDefinition returns a custom role allowing the installer to
+// create and destroy the cluster of the install config in the subscription.
+func RequiredRoleDefinition(ic *types.InstallConfig, subscriptionID string) *RoleDefinition {
+	actions := []string{
+		"Microsoft.Authorization/roleAssignments/*",
+		"Microsoft.Compute/disks/*",
+		"Microsoft.Compute/images/*",
+		"Microsoft.Compute/locations/usages/read",
+		"Microsoft.Compute/skus/read",
+		"Microsoft.Compute/virtualMachines/*",
+		"Microsoft.ManagedIdentity/userAssignedIdentities/*",
+		"Microsoft.Network/loadBalancers/*",
+		"Microsoft.Network/locations/usages/read",
+		"Microsoft.Network/networkInterfaces/*",
+		"Microsoft.Network/networkSecurityGroups/*",
+		"Microsoft.Network/privateDnsZones/*",
+		"Microsoft.Resources/subscriptions/resourceGroups/*",
+		"Microsoft.Resources/tags/*",
+		"Microsoft.Storage/storageAccounts/*",
+	}
+
+	if ic.Azure.VirtualNetwork == "" {
+		actions = append(actions, "Microsoft.Network/virtualNetworks/*")
+	} else {
+		actions = append(actions,
+			"Microsoft.Network/virtualNetworks/read",
+			"Microsoft.Network/virtualNetworks/subnets/join/action",
+			"Microsoft.Network/virtualNetworks/subnets/read",
+		)
+	}
+
+	external := ic.Publish != types.InternalPublishingStrategy
+	if external || ic.Azure.OutboundType != azuretypes.UserDefinedRoutingOutboundType {
+		actions = append(actions, "Microsoft.Network/publicIPAddresses/*")
+	}
+	if external {
+		// the records of the cluster in the public zone of the base domain
+		actions = append(actions,
+			"Microsoft.Network/dnsZones/CNAME/*",
+			"Microsoft.Network/dnsZones/read",
+		)
+	}
+
+	sort.Strings(actions)
+	return &RoleDefinition{
+		Name:             fmt.Sprintf("OpenShift Installer (%s)", ic.ObjectMeta.Name),
+		IsCustom:         true,
+		Description:      fmt.Sprintf("Creates and destroys the OpenShift cluster %s.", ic.ObjectMeta.Name),
+		Actions:          actions,
+		NotActions:       []string{},
+		AssignableScopes: []string{fmt.Sprintf("/subscriptions/%s", subscriptionID)},
+	}
+}
@@ -0,0 +1,26 @@
+package gcp
+
+import (
+	"github.com/openshift/installer/pkg/types"
+)
+
+// RequiredRoles returns the predefined roles that the service account of the
+// installer needs to create and destroy the cluster of the install config.
+// see docs/user/gcp/iam.md
+func RequiredRoles(ic *types.InstallConfig) []string {
+	roles := []string{
+		"roles/compute.admin",
+		"roles/dns.admin",
+		"roles/iam.securityAdmin",
+		"roles/iam.serviceAccountAdmin",
+		"roles/iam.serviceAccountUser",
+		"roles/storage.admin",
+	}
+	// the cluster creates keys for the service accounts of its operators
+	// unless their credentials are passed through or provided
+	switch ic.CredentialsMode {
+	case "", types.MintCredentialsMode:
+		roles = append(roles, "roles/iam.serviceAccountKeyAdmin")
+	}
+	return roles
+}
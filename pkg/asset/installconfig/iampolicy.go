@@ -0,0 +1,95 @@
+package installconfig
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/openshift/installer/pkg/asset"
+	awsconfig "github.com/openshift/installer/pkg/asset/installconfig/aws"
+	icazure "github.com/openshift/installer/pkg/asset/installconfig/azure"
+	icgcp "github.com/openshift/installer/pkg/asset/installconfig/gcp"
+	"github.com/openshift/installer/pkg/types/aws"
+	"github.com/openshift/installer/pkg/types/azure"
+	"github.com/openshift/installer/pkg/types/gcp"
+)
+
+const (
+	iamPolicyFilename = "iam-policy.json"
+)
+
+// IAMPolicy generates the policy granting the installer the permissions to
+// create and destroy the cluster of the install config: an IAM policy
+// document on AWS, a custom role definition on Azure and the list of
+// predefined roles on GCP.
+type IAMPolicy struct {
+	File *asset.File
+}
+
+var _ asset.WritableAsset = (*IAMPolicy)(nil)
+
+// Dependencies returns the dependencies for IAMPolicy.
+func (a *IAMPolicy) Dependencies() []asset.Asset {
+	return []asset.Asset{
+		&InstallConfig{},
+	}
+}
+
+// Generate generates the policy for the platform of the install config.
+func (a *IAMPolicy) Generate(dependencies asset.Parents) error {
+	ic := &InstallConfig{}
+	dependencies.Get(ic)
+
+	var policy interface{}
+	platform := ic.Config.Platform.Name()
+	switch platform {
+	case aws.Name:
+		var err error
+		policy, err = awsconfig.Policy(awsconfig.RequiredPermissionGroups(ic.Config))
+		if err != nil {
+			return err
+		}
+	case azure.Name:
+		session, err := ic.Azure.Session()
+		if err != nil {
+			return errors.Wrap(err, "failed to get Azure session")
+		}
+		policy = icazure.RequiredRoleDefinition(ic.Config, session.Credentials.SubscriptionID)
+	case gcp.Name:
+		policy = struct {
+			Roles []string `json:"roles"`
+		}{Roles: icgcp.RequiredRoles(ic.Config)}
+	default:
+		return fmt.Errorf("IAM policies are not generated for platform %q", platform)
+	}
+
+	data, err := json.MarshalIndent(policy, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal IAM policy")
+	}
+	a.File = &asset.File{
+		Filename: iamPolicyFilename,
+		Data:     append(data, '\n'),
+	}
+	return nil
+}
+
+// Name returns the human-friendly name of the asset.
+func (a *IAMPolicy) Name() string {
+	return "IAM Policy"
+}
+
+// Files returns the files generated by the asset.
+func (a *IAMPolicy) Files() []*asset.File {
+	if a.File != nil {
+		return []*asset.File{a.File}
+	}
+	return []*asset.File{}
+}
+
+// Load does not load the policy from disk, it is always generated from the
+// install config.
+func (a *IAMPolicy) Load(f asset.FileFetcher) (found bool, err error) {
+	return false, nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"net/url"
 	"os"
 	"time"
 
@@ -29,11 +30,15 @@ type API interface {
 	GetDNSZoneIDByName(ctx context.Context, name string) (string, error)
 	GetDNSZones(ctx context.Context) ([]DNSZoneResponse, error)
 	GetEncryptionKey(ctx context.Context, keyCRN string) (*EncryptionKeyResponse, error)
+	GetFloatingIPs(ctx context.Context, region string) ([]vpcv1.FloatingIP, error)
+	GetInstances(ctx context.Context, region string) ([]vpcv1.Instance, error)
+	GetLoadBalancers(ctx context.Context, region string) ([]vpcv1.LoadBalancer, error)
 	GetResourceGroups(ctx context.Context) ([]resourcemanagerv2.ResourceGroup, error)
 	GetResourceGroup(ctx context.Context, nameOrID string) (*resourcemanagerv2.ResourceGroup, error)
 	GetSubnet(ctx context.Context, subnetID string) (*vpcv1.Subnet, error)
 	GetVSIProfiles(ctx context.Context) ([]vpcv1.InstanceProfile, error)
 	GetVPC(ctx context.Context, vpcID string) (*vpcv1.VPC, error)
+	GetVPCs(ctx context.Context, region string) ([]vpcv1.VPC, error)
 	GetVPCZonesForRegion(ctx context.Context, region string) ([]string, error)
 }
 
@@ -280,6 +285,90 @@ func (c *Client) GetEncryptionKey(ctx context.Context, keyCRN string) (*Encrypti
 	return &EncryptionKeyResponse{}, nil
 }
 
+// GetFloatingIPs gets the floating IPs of a region.
+func (c *Client) GetFloatingIPs(ctx context.Context, region string) ([]vpcv1.FloatingIP, error) {
+	ctx, cancel := context.WithTimeout(ctx, 1*time.Minute)
+	defer cancel()
+
+	if err := c.setVPCServiceURLForRegion(ctx, region); err != nil {
+		return nil, errors.Wrap(err, "failed to set vpc api service url")
+	}
+
+	var floatingIPs []vpcv1.FloatingIP
+	options := c.vpcAPI.NewListFloatingIpsOptions()
+	for {
+		page, _, err := c.vpcAPI.ListFloatingIpsWithContext(ctx, options)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to list floating ips")
+		}
+		floatingIPs = append(floatingIPs, page.FloatingIps...)
+		if page.Next == nil {
+			return floatingIPs, nil
+		}
+		start, err := nextStart(page.Next.Href)
+		if err != nil {
+			return nil, err
+		}
+		options.SetStart(start)
+	}
+}
+
+// GetInstances gets the virtual server instances of a region.
+func (c *Client) GetInstances(ctx context.Context, region string) ([]vpcv1.Instance, error) {
+	ctx, cancel := context.WithTimeout(ctx, 1*time.Minute)
+	defer cancel()
+
+	if err := c.setVPCServiceURLForRegion(ctx, region); err != nil {
+		return nil, errors.Wrap(err, "failed to set vpc api service url")
+	}
+
+	var instances []vpcv1.Instance
+	options := c.vpcAPI.NewListInstancesOptions()
+	for {
+		page, _, err := c.vpcAPI.ListInstancesWithContext(ctx, options)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to list instances")
+		}
+		instances = append(instances, page.Instances...)
+		if page.Next == nil {
+			return instances, nil
+		}
+		start, err := nextStart(page.Next.Href)
+		if err != nil {
+			return nil, err
+		}
+		options.SetStart(start)
+	}
+}
+
+// GetLoadBalancers gets the load balancers of a region.
+func (c *Client) GetLoadBalancers(ctx context.Context, region string) ([]vpcv1.LoadBalancer, error) {
+	ctx, cancel := context.WithTimeout(ctx, 1*time.Minute)
+	defer cancel()
+
+	if err := c.setVPCServiceURLForRegion(ctx, region); err != nil {
+		return nil, errors.Wrap(err, "failed to set vpc api service url")
+	}
+
+	var loadBalancers []vpcv1.LoadBalancer
+	options := c.vpcAPI.NewListLoadBalancersOptions()
+	for {
+		page, _, err := c.vpcAPI.ListLoadBalancersWithContext(ctx, options)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to list load balancers")
+		}
+		loadBalancers = append(loadBalancers, page.LoadBalancers...)
+		if page.Next == nil {
+			return loadBalancers, nil
+		}
+		start, err := nextStart(page.Next.Href)
+		if err != nil {
+			return nil, err
+		}
+		options.SetStart(start)
+	}
+}
+
 // GetResourceGroup gets a resource group by its name or ID.
 func (c *Client) GetResourceGroup(ctx context.Context, nameOrID string) (*resourcemanagerv2.ResourceGroup, error) {
 	_, cancel := context.WithTimeout(ctx, 1*time.Minute)
@@ -367,6 +456,34 @@ func (c *Client) GetVPC(ctx context.Context, vpcID string) (*vpcv1.VPC, error) {
 	return nil, &VPCResourceNotFoundError{}
 }
 
+// GetVPCs gets the VPCs of a region.
+func (c *Client) GetVPCs(ctx context.Context, region string) ([]vpcv1.VPC, error) {
+	ctx, cancel := context.WithTimeout(ctx, 1*time.Minute)
+	defer cancel()
+
+	if err := c.setVPCServiceURLForRegion(ctx, region); err != nil {
+		return nil, errors.Wrap(err, "failed to set vpc api service url")
+	}
+
+	var vpcs []vpcv1.VPC
+	options := c.vpcAPI.NewListVpcsOptions()
+	for {
+		page, _, err := c.vpcAPI.ListVpcsWithContext(ctx, options)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to list vpcs")
+		}
+		vpcs = append(vpcs, page.Vpcs...)
+		if page.Next == nil {
+			return vpcs, nil
+		}
+		start, err := nextStart(page.Next.Href)
+		if err != nil {
+			return nil, err
+		}
+		options.SetStart(start)
+	}
+}
+
 // GetVPCZonesForRegion gets the supported zones for a VPC region.
 func (c *Client) GetVPCZonesForRegion(ctx context.Context, region string) ([]string, error) {
 	_, cancel := context.WithTimeout(ctx, 1*time.Minute)
@@ -385,6 +502,19 @@ func (c *Client) GetVPCZonesForRegion(ctx context.Context, region string) ([]str
 	return response, err
 }
 
+// nextStart returns the start token of the next page of a VPC API collection
+// from the URL of the page.
+func nextStart(href *string) (string, error) {
+	if href == nil {
+		return "", errors.New("missing the URL of the next page")
+	}
+	next, err := url.Parse(*href)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to parse the URL of the next page")
+	}
+	return next.Query().Get("start"), nil
+}
+
 func (c *Client) getVPCRegions(ctx context.Context) ([]vpcv1.Region, error) {
 	listRegionsOptions := c.vpcAPI.NewListRegionsOptions()
 	listRegionsResponse, _, err := c.vpcAPI.ListRegionsWithContext(ctx, listRegionsOptions)
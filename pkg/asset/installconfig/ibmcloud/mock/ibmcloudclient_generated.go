@@ -160,6 +160,51 @@ func (mr *MockAPIMockRecorder) GetEncryptionKey(ctx, keyCRN interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEncryptionKey", reflect.TypeOf((*MockAPI)(nil).GetEncryptionKey), ctx, keyCRN)
 }
 
+// GetFloatingIPs mocks base method.
+func (m *MockAPI) GetFloatingIPs(ctx context.Context, region string) ([]vpcv1.FloatingIP, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFloatingIPs", ctx, region)
+	ret0, _ := ret[0].([]vpcv1.FloatingIP)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFloatingIPs indicates an expected call of GetFloatingIPs.
+func (mr *MockAPIMockRecorder) GetFloatingIPs(ctx, region interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFloatingIPs", reflect.TypeOf((*MockAPI)(nil).GetFloatingIPs), ctx, region)
+}
+
+// GetInstances mocks base method.
+func (m *MockAPI) GetInstances(ctx context.Context, region string) ([]vpcv1.Instance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInstances", ctx, region)
+	ret0, _ := ret[0].([]vpcv1.Instance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInstances indicates an expected call of GetInstances.
+func (mr *MockAPIMockRecorder) GetInstances(ctx, region interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInstances", reflect.TypeOf((*MockAPI)(nil).GetInstances), ctx, region)
+}
+
+// GetLoadBalancers mocks base method.
+func (m *MockAPI) GetLoadBalancers(ctx context.Context, region string) ([]vpcv1.LoadBalancer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLoadBalancers", ctx, region)
+	ret0, _ := ret[0].([]vpcv1.LoadBalancer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLoadBalancers indicates an expected call of GetLoadBalancers.
+func (mr *MockAPIMockRecorder) GetLoadBalancers(ctx, region interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoadBalancers", reflect.TypeOf((*MockAPI)(nil).GetLoadBalancers), ctx, region)
+}
+
 // GetResourceGroup mocks base method.
 func (m *MockAPI) GetResourceGroup(ctx context.Context, nameOrID string) (*resourcemanagerv2.ResourceGroup, error) {
 	m.ctrl.T.Helper()
@@ -235,6 +280,21 @@ func (mr *MockAPIMockRecorder) GetVPCZonesForRegion(ctx, region interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVPCZonesForRegion", reflect.TypeOf((*MockAPI)(nil).GetVPCZonesForRegion), ctx, region)
 }
 
+// GetVPCs mocks base method.
+func (m *MockAPI) GetVPCs(ctx context.Context, region string) ([]vpcv1.VPC, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVPCs", ctx, region)
+	ret0, _ := ret[0].([]vpcv1.VPC)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVPCs indicates an expected call of GetVPCs.
+func (mr *MockAPIMockRecorder) GetVPCs(ctx, region interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVPCs", reflect.TypeOf((*MockAPI)(nil).GetVPCs), ctx, region)
+}
+
 // GetVSIProfiles mocks base method.
 func (m *MockAPI) GetVSIProfiles(ctx context.Context) ([]vpcv1.InstanceProfile, error) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,59 @@
+package vsphere
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/vmware/govmomi/find"
+	"github.com/vmware/govmomi/vim25"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+//go:generate mockgen -source=./capacity.go -destination=./mock/capacity_generated.go -package=mock
+
+// CapacityGetter returns the capacity and usage of the datastores and
+// clusters in the vCenter.
+type CapacityGetter interface {
+	DatastoreSummary(ctx context.Context, path string) (*types.DatastoreSummary, error)
+	ClusterSummary(ctx context.Context, path string) (*types.ClusterComputeResourceSummary, error)
+}
+
+type capacityGetter struct {
+	finder *find.Finder
+}
+
+// NewCapacityGetter returns a CapacityGetter using the vCenter client.
+func NewCapacityGetter(client *vim25.Client) CapacityGetter {
+	return &capacityGetter{finder: find.NewFinder(client)}
+}
+
+// DatastoreSummary returns the summary of the datastore with the path.
+func (c *capacityGetter) DatastoreSummary(ctx context.Context, path string) (*types.DatastoreSummary, error) {
+	datastore, err := c.finder.Datastore(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	var datastoreMo mo.Datastore
+	if err := datastore.Properties(ctx, datastore.Reference(), []string{"summary"}, &datastoreMo); err != nil {
+		return nil, errors.Wrapf(err, "failed to get the summary of %s", path)
+	}
+	return &datastoreMo.Summary, nil
+}
+
+// ClusterSummary returns the summary of the cluster with the path.
+func (c *capacityGetter) ClusterSummary(ctx context.Context, path string) (*types.ClusterComputeResourceSummary, error) {
+	cluster, err := c.finder.ClusterComputeResource(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	var clusterMo mo.ClusterComputeResource
+	if err := cluster.Properties(ctx, cluster.Reference(), []string{"summary"}, &clusterMo); err != nil {
+		return nil, errors.Wrapf(err, "failed to get the summary of %s", path)
+	}
+	summary, ok := clusterMo.Summary.(*types.ClusterComputeResourceSummary)
+	if !ok {
+		return nil, errors.Errorf("unexpected summary of %s: %T", path, clusterMo.Summary)
+	}
+	return summary, nil
+}
@@ -0,0 +1,66 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./capacity.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	types "github.com/vmware/govmomi/vim25/types"
+)
+
+// MockCapacityGetter is a mock of CapacityGetter interface.
+type MockCapacityGetter struct {
+	ctrl     *gomock.Controller
+	recorder *MockCapacityGetterMockRecorder
+}
+
+// MockCapacityGetterMockRecorder is the mock recorder for MockCapacityGetter.
+type MockCapacityGetterMockRecorder struct {
+	mock *MockCapacityGetter
+}
+
+// NewMockCapacityGetter creates a new mock instance.
+func NewMockCapacityGetter(ctrl *gomock.Controller) *MockCapacityGetter {
+	mock := &MockCapacityGetter{ctrl: ctrl}
+	mock.recorder = &MockCapacityGetterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCapacityGetter) EXPECT() *MockCapacityGetterMockRecorder {
+	return m.recorder
+}
+
+// ClusterSummary mocks base method.
+func (m *MockCapacityGetter) ClusterSummary(ctx context.Context, path string) (*types.ClusterComputeResourceSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClusterSummary", ctx, path)
+	ret0, _ := ret[0].(*types.ClusterComputeResourceSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClusterSummary indicates an expected call of ClusterSummary.
+func (mr *MockCapacityGetterMockRecorder) ClusterSummary(ctx, path interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClusterSummary", reflect.TypeOf((*MockCapacityGetter)(nil).ClusterSummary), ctx, path)
+}
+
+// DatastoreSummary mocks base method.
+func (m *MockCapacityGetter) DatastoreSummary(ctx context.Context, path string) (*types.DatastoreSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DatastoreSummary", ctx, path)
+	ret0, _ := ret[0].(*types.DatastoreSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DatastoreSummary indicates an expected call of DatastoreSummary.
+func (mr *MockCapacityGetterMockRecorder) DatastoreSummary(ctx, path interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DatastoreSummary", reflect.TypeOf((*MockCapacityGetter)(nil).DatastoreSummary), ctx, path)
+}
@@ -33,6 +33,21 @@ func (m *MockFileFetcher) EXPECT() *MockFileFetcherMockRecorder {
 	return m.recorder
 }
 
+// FetchByDirectory mocks base method.
+func (m *MockFileFetcher) FetchByDirectory(directory string) ([]*asset.File, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchByDirectory", directory)
+	ret0, _ := ret[0].([]*asset.File)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchByDirectory indicates an expected call of FetchByDirectory.
+func (mr *MockFileFetcherMockRecorder) FetchByDirectory(directory interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchByDirectory", reflect.TypeOf((*MockFileFetcher)(nil).FetchByDirectory), directory)
+}
+
 // FetchByName mocks base method.
 func (m *MockFileFetcher) FetchByName(arg0 string) (*asset.File, error) {
 	m.ctrl.T.Helper()
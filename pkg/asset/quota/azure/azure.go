@@ -0,0 +1,118 @@
+package azure
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/profiles/2018-03-01/compute/mgmt/compute"
+	"github.com/Azure/go-autorest/autorest/to"
+	machineapi "github.com/openshift/api/machine/v1beta1"
+	"github.com/sirupsen/logrus"
+
+	"github.com/openshift/installer/pkg/quota"
+	"github.com/openshift/installer/pkg/types"
+	azuretypes "github.com/openshift/installer/pkg/types/azure"
+)
+
+// SkuGetter returns the resource SKU of a virtual machine size in a region.
+type SkuGetter interface {
+	GetVirtualMachineSku(ctx context.Context, name, region string) (*compute.ResourceSku, error)
+}
+
+// Constraints returns a list of quota constraints based on the InstallConfig.
+// These constraints can be used to check if there is enough quota for creating a cluster
+// for the install config.
+func Constraints(ctx context.Context, client SkuGetter, config *types.InstallConfig, controlPlanes []machineapi.Machine, computes []machineapi.MachineSet) []quota.Constraint {
+	region := config.Platform.Azure.Region
+	skus := map[string]*compute.ResourceSku{}
+	vmConstraints := func(vmSize string, count int64) []quota.Constraint {
+		sku, ok := skus[vmSize]
+		if !ok {
+			var err error
+			sku, err = client.GetVirtualMachineSku(ctx, vmSize, region)
+			if err != nil {
+				logrus.Warnf("Skipping the vCPU quota validation of %s: %v", vmSize, err)
+			}
+			skus[vmSize] = sku
+		}
+		ret := []quota.Constraint{{Name: "compute/virtualMachines", Region: region, Count: count}}
+		if sku == nil {
+			return ret
+		}
+		vCPUs := skuVCPUs(sku)
+		ret = append(ret, quota.Constraint{Name: "compute/cores", Region: region, Count: vCPUs * count})
+		if family := to.String(sku.Family); family != "" {
+			ret = append(ret, quota.Constraint{Name: "compute/" + family, Region: region, Count: vCPUs * count})
+		}
+		return ret
+	}
+
+	var ret []quota.Constraint
+	for i, m := range controlPlanes {
+		spec := m.Spec.ProviderSpec.Value.Object.(*machineapi.AzureMachineProviderSpec)
+		if i == 0 {
+			// the bootstrap machine has the size of the control plane machines
+			ret = append(ret, vmConstraints(spec.VMSize, 1)...)
+		}
+		ret = append(ret, vmConstraints(spec.VMSize, 1)...)
+	}
+	for _, w := range computes {
+		spec := w.Spec.Template.Spec.ProviderSpec.Value.Object.(*machineapi.AzureMachineProviderSpec)
+		ret = append(ret, vmConstraints(spec.VMSize, int64(*w.Spec.Replicas))...)
+	}
+	if count := publicIPs(config); count > 0 {
+		ret = append(ret, quota.Constraint{Name: "network/PublicIPAddresses", Region: region, Count: count})
+	}
+	return aggregate(region, ret)
+}
+
+// publicIPs returns the number of public IP addresses of the cluster: the
+// frontend of the public load balancer, which is also used for egress unless
+// the egress is user-defined, and the address of the bootstrap machine of
+// external clusters.
+func publicIPs(config *types.InstallConfig) int64 {
+	var count int64
+	external := config.Publish != types.InternalPublishingStrategy
+	if external || config.Platform.Azure.OutboundType != azuretypes.UserDefinedRoutingOutboundType {
+		count++
+	}
+	if external {
+		count++
+	}
+	return count
+}
+
+// skuVCPUs returns the number of vCPUs of a virtual machine SKU.
+func skuVCPUs(sku *compute.ResourceSku) int64 {
+	if sku.Capabilities == nil {
+		return 0
+	}
+	for _, capability := range *sku.Capabilities {
+		if strings.EqualFold(to.String(capability.Name), "vCPUs") {
+			vCPUs, err := strconv.ParseInt(to.String(capability.Value), 10, 64)
+			if err == nil {
+				return vCPUs
+			}
+		}
+	}
+	return 0
+}
+
+// aggregate sums the counts of the constraints with the same name, all of
+// which are in the region.
+func aggregate(region string, quotas []quota.Constraint) []quota.Constraint {
+	counts := map[string]int64{}
+	var names []string
+	for _, q := range quotas {
+		if _, ok := counts[q.Name]; !ok {
+			names = append(names, q.Name)
+		}
+		counts[q.Name] += q.Count
+	}
+	aggregated := make([]quota.Constraint, 0, len(names))
+	for _, name := range names {
+		aggregated = append(aggregated, quota.Constraint{Name: name, Region: region, Count: counts[name]})
+	}
+	return aggregated
+}
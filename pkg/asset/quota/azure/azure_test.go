@@ -0,0 +1,107 @@
+package azure
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/profiles/2018-03-01/compute/mgmt/compute"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/golang/mock/gomock"
+	machineapi "github.com/openshift/api/machine/v1beta1"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/pointer"
+
+	"github.com/openshift/installer/pkg/asset/installconfig/azure/mock"
+	"github.com/openshift/installer/pkg/quota"
+	"github.com/openshift/installer/pkg/types"
+	azuretypes "github.com/openshift/installer/pkg/types/azure"
+)
+
+func sku(family string, vCPUs string) *compute.ResourceSku {
+	return &compute.ResourceSku{
+		Family:       to.StringPtr(family),
+		Capabilities: &[]compute.ResourceSkuCapabilities{{Name: to.StringPtr("vCPUs"), Value: to.StringPtr(vCPUs)}},
+	}
+}
+
+func machine(vmSize string) machineapi.Machine {
+	m := machineapi.Machine{}
+	m.Spec.ProviderSpec.Value = &runtime.RawExtension{Object: &machineapi.AzureMachineProviderSpec{VMSize: vmSize}}
+	return m
+}
+
+func machineSet(vmSize string, replicas int32) machineapi.MachineSet {
+	ms := machineapi.MachineSet{}
+	ms.Spec.Replicas = pointer.Int32Ptr(replicas)
+	ms.Spec.Template.Spec.ProviderSpec.Value = &runtime.RawExtension{Object: &machineapi.AzureMachineProviderSpec{VMSize: vmSize}}
+	return ms
+}
+
+func TestConstraints(t *testing.T) {
+	cases := []struct {
+		name         string
+		publish      types.PublishingStrategy
+		outboundType azuretypes.OutboundType
+		workerSKU    *compute.ResourceSku
+		workerErr    error
+		expected     []quota.Constraint
+	}{
+		{
+			name:      "external",
+			publish:   types.ExternalPublishingStrategy,
+			workerSKU: sku("standardDSv3Family", "2"),
+			expected: []quota.Constraint{
+				{Name: "compute/virtualMachines", Region: "eastus", Count: 7},
+				{Name: "compute/cores", Region: "eastus", Count: 22},
+				{Name: "compute/standardDSv3Family", Region: "eastus", Count: 22},
+				{Name: "network/PublicIPAddresses", Region: "eastus", Count: 2},
+			},
+		},
+		{
+			name:      "internal with a different worker family",
+			publish:   types.InternalPublishingStrategy,
+			workerSKU: sku("standardFSv2Family", "4"),
+			expected: []quota.Constraint{
+				{Name: "compute/virtualMachines", Region: "eastus", Count: 7},
+				{Name: "compute/cores", Region: "eastus", Count: 28},
+				{Name: "compute/standardDSv3Family", Region: "eastus", Count: 16},
+				{Name: "compute/standardFSv2Family", Region: "eastus", Count: 12},
+				{Name: "network/PublicIPAddresses", Region: "eastus", Count: 1},
+			},
+		},
+		{
+			name:         "internal with user-defined routing and an unknown worker size",
+			publish:      types.InternalPublishingStrategy,
+			outboundType: azuretypes.UserDefinedRoutingOutboundType,
+			workerErr:    errors.New("not found"),
+			expected: []quota.Constraint{
+				{Name: "compute/virtualMachines", Region: "eastus", Count: 7},
+				{Name: "compute/cores", Region: "eastus", Count: 16},
+				{Name: "compute/standardDSv3Family", Region: "eastus", Count: 16},
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			client := mock.NewMockAPI(mockCtrl)
+			client.EXPECT().GetVirtualMachineSku(gomock.Any(), "Standard_D4s_v3", "eastus").Return(sku("standardDSv3Family", "4"), nil)
+			client.EXPECT().GetVirtualMachineSku(gomock.Any(), "worker", "eastus").Return(tc.workerSKU, tc.workerErr)
+
+			config := &types.InstallConfig{
+				Publish: tc.publish,
+				Platform: types.Platform{
+					Azure: &azuretypes.Platform{Region: "eastus", OutboundType: tc.outboundType},
+				},
+			}
+			controlPlanes := []machineapi.Machine{machine("Standard_D4s_v3"), machine("Standard_D4s_v3"), machine("Standard_D4s_v3")}
+			computes := []machineapi.MachineSet{machineSet("worker", 3)}
+
+			assert.Equal(t, tc.expected, Constraints(context.TODO(), client, config, controlPlanes, computes))
+		})
+	}
+}
@@ -0,0 +1,136 @@
+package ibmcloud
+
+import (
+	"context"
+
+	"github.com/IBM/vpc-go-sdk/vpcv1"
+	machineapi "github.com/openshift/api/machine/v1beta1"
+	ibmcloudprovider "github.com/openshift/cluster-api-provider-ibmcloud/pkg/apis/ibmcloudprovider/v1beta1"
+	"github.com/sirupsen/logrus"
+
+	"github.com/openshift/installer/pkg/quota"
+	"github.com/openshift/installer/pkg/types"
+)
+
+// ProfileGetter returns the virtual server instance profiles.
+type ProfileGetter interface {
+	GetVSIProfiles(ctx context.Context) ([]vpcv1.InstanceProfile, error)
+}
+
+// Constraints returns a list of quota constraints based on the InstallConfig.
+// These constraints can be used to check if there is enough quota for creating a cluster
+// for the install config.
+func Constraints(ctx context.Context, client ProfileGetter, config *types.InstallConfig, controlPlanes []machineapi.Machine, computes []machineapi.MachineSet) []quota.Constraint {
+	region := config.Platform.IBMCloud.Region
+	external := config.Publish != types.InternalPublishingStrategy
+
+	var ret []quota.Constraint
+	if config.Platform.IBMCloud.VPC == "" {
+		ret = append(ret, quota.Constraint{Name: "vpc/vpcs", Region: region, Count: 1})
+	}
+	// the private load balancer of the API and the machine config server,
+	// and the public load balancer of the API of external clusters
+	loadBalancers := int64(1)
+	if external {
+		loadBalancers++
+	}
+	ret = append(ret, quota.Constraint{Name: "vpc/load-balancers", Region: region, Count: loadBalancers})
+
+	profiles, err := client.GetVSIProfiles(ctx)
+	if err != nil {
+		logrus.Warnf("Skipping the vCPU and memory quota validation: %v", err)
+	}
+	instanceConstraints := func(profileName string, count int64) []quota.Constraint {
+		profile := findProfile(profiles, profileName)
+		if profile == nil {
+			return nil
+		}
+		return []quota.Constraint{
+			{Name: "vpc/vcpu", Region: region, Count: profileVCPUs(profile) * count},
+			{Name: "vpc/memory", Region: region, Count: profileMemory(profile) * count},
+		}
+	}
+
+	for i, m := range controlPlanes {
+		spec := m.Spec.ProviderSpec.Value.Object.(*ibmcloudprovider.IBMCloudMachineProviderSpec)
+		if i == 0 {
+			// the bootstrap machine has the profile of the control plane
+			// machines, and a floating IP in the zone of the first one
+			ret = append(ret, instanceConstraints(spec.Profile, 1)...)
+			if external {
+				ret = append(ret, quota.Constraint{Name: "vpc/floating-ips", Region: spec.Zone, Count: 1})
+			}
+		}
+		ret = append(ret, instanceConstraints(spec.Profile, 1)...)
+	}
+	for _, w := range computes {
+		spec := w.Spec.Template.Spec.ProviderSpec.Value.Object.(*ibmcloudprovider.IBMCloudMachineProviderSpec)
+		ret = append(ret, instanceConstraints(spec.Profile, int64(*w.Spec.Replicas))...)
+	}
+	return aggregate(ret)
+}
+
+// findProfile returns the profile with the name, or nil.
+func findProfile(profiles []vpcv1.InstanceProfile, name string) *vpcv1.InstanceProfile {
+	for i := range profiles {
+		if profiles[i].Name != nil && *profiles[i].Name == name {
+			return &profiles[i]
+		}
+	}
+	return nil
+}
+
+// profileVCPUs returns the number of vCPUs of an instance with the profile.
+func profileVCPUs(profile *vpcv1.InstanceProfile) int64 {
+	switch vcpus := profile.VcpuCount.(type) {
+	case *vpcv1.InstanceProfileVcpuFixed:
+		return int64Value(vcpus.Value)
+	case *vpcv1.InstanceProfileVcpu:
+		if vcpus.Value != nil {
+			return *vcpus.Value
+		}
+		return int64Value(vcpus.Default)
+	}
+	return 0
+}
+
+// profileMemory returns the memory, in GiB, of an instance with the profile.
+func profileMemory(profile *vpcv1.InstanceProfile) int64 {
+	switch memory := profile.Memory.(type) {
+	case *vpcv1.InstanceProfileMemoryFixed:
+		return int64Value(memory.Value)
+	case *vpcv1.InstanceProfileMemory:
+		if memory.Value != nil {
+			return *memory.Value
+		}
+		return int64Value(memory.Default)
+	}
+	return 0
+}
+
+func int64Value(v *int64) int64 {
+	if v == nil {
+		return 0
+	}
+	return *v
+}
+
+// aggregate sums the counts of the constraints with the same name and
+// region, preserving their order.
+func aggregate(quotas []quota.Constraint) []quota.Constraint {
+	type key struct{ name, region string }
+	counts := map[key]int64{}
+	var keys []key
+	for _, q := range quotas {
+		k := key{name: q.Name, region: q.Region}
+		if _, ok := counts[k]; !ok {
+			keys = append(keys, k)
+		}
+		counts[k] += q.Count
+	}
+	aggregated := make([]quota.Constraint, 0, len(keys))
+	for _, k := range keys {
+		aggregated = append(aggregated, quota.Constraint{Name: k.name, Region: k.region, Count: counts[k]})
+	}
+	return aggregated
+}
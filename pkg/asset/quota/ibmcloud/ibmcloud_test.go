@@ -0,0 +1,96 @@
+package ibmcloud
+
+import (
+	"context"
+	"testing"
+
+	"github.com/IBM/vpc-go-sdk/vpcv1"
+	"github.com/golang/mock/gomock"
+	machineapi "github.com/openshift/api/machine/v1beta1"
+	ibmcloudprovider "github.com/openshift/cluster-api-provider-ibmcloud/pkg/apis/ibmcloudprovider/v1beta1"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/pointer"
+
+	"github.com/openshift/installer/pkg/asset/installconfig/ibmcloud/mock"
+	"github.com/openshift/installer/pkg/quota"
+	"github.com/openshift/installer/pkg/types"
+	ibmcloudtypes "github.com/openshift/installer/pkg/types/ibmcloud"
+)
+
+func machine(profile, zone string) machineapi.Machine {
+	m := machineapi.Machine{}
+	m.Spec.ProviderSpec.Value = &runtime.RawExtension{Object: &ibmcloudprovider.IBMCloudMachineProviderSpec{Profile: profile, Zone: zone}}
+	return m
+}
+
+func machineSet(profile string, replicas int32) machineapi.MachineSet {
+	ms := machineapi.MachineSet{}
+	ms.Spec.Replicas = pointer.Int32Ptr(replicas)
+	ms.Spec.Template.Spec.ProviderSpec.Value = &runtime.RawExtension{Object: &ibmcloudprovider.IBMCloudMachineProviderSpec{Profile: profile}}
+	return ms
+}
+
+func TestConstraints(t *testing.T) {
+	profiles := []vpcv1.InstanceProfile{
+		{
+			Name:      pointer.StringPtr("bx2-4x16"),
+			VcpuCount: &vpcv1.InstanceProfileVcpuFixed{Value: pointer.Int64Ptr(4)},
+			Memory:    &vpcv1.InstanceProfileMemoryFixed{Value: pointer.Int64Ptr(16)},
+		},
+		{
+			Name:      pointer.StringPtr("bx2-2x8"),
+			VcpuCount: &vpcv1.InstanceProfileVcpu{Value: pointer.Int64Ptr(2)},
+			Memory:    &vpcv1.InstanceProfileMemory{Value: pointer.Int64Ptr(8)},
+		},
+	}
+
+	cases := []struct {
+		name     string
+		publish  types.PublishingStrategy
+		vpc      string
+		expected []quota.Constraint
+	}{
+		{
+			name:    "external",
+			publish: types.ExternalPublishingStrategy,
+			expected: []quota.Constraint{
+				{Name: "vpc/vpcs", Region: "us-south", Count: 1},
+				{Name: "vpc/load-balancers", Region: "us-south", Count: 2},
+				{Name: "vpc/vcpu", Region: "us-south", Count: 22},
+				{Name: "vpc/memory", Region: "us-south", Count: 88},
+				{Name: "vpc/floating-ips", Region: "us-south-1", Count: 1},
+			},
+		},
+		{
+			name:    "internal in an existing VPC",
+			publish: types.InternalPublishingStrategy,
+			vpc:     "vpc-1",
+			expected: []quota.Constraint{
+				{Name: "vpc/load-balancers", Region: "us-south", Count: 1},
+				{Name: "vpc/vcpu", Region: "us-south", Count: 22},
+				{Name: "vpc/memory", Region: "us-south", Count: 88},
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			client := mock.NewMockAPI(mockCtrl)
+			client.EXPECT().GetVSIProfiles(gomock.Any()).Return(profiles, nil)
+
+			config := &types.InstallConfig{
+				Publish: tc.publish,
+				Platform: types.Platform{
+					IBMCloud: &ibmcloudtypes.Platform{Region: "us-south", VPC: tc.vpc},
+				},
+			}
+			controlPlanes := []machineapi.Machine{machine("bx2-4x16", "us-south-1"), machine("bx2-4x16", "us-south-2"), machine("bx2-4x16", "us-south-3")}
+			computes := []machineapi.MachineSet{machineSet("bx2-2x8", 3)}
+
+			assert.Equal(t, tc.expected, Constraints(context.TODO(), client, config, controlPlanes, computes))
+		})
+	}
+}
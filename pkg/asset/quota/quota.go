@@ -6,6 +6,7 @@ import (
 	"os"
 	"strings"
 
+	machineapi "github.com/openshift/api/machine/v1beta1"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 
@@ -13,25 +14,32 @@ import (
 	"github.com/openshift/installer/pkg/asset/installconfig"
 	configgcp "github.com/openshift/installer/pkg/asset/installconfig/gcp"
 	openstackvalidation "github.com/openshift/installer/pkg/asset/installconfig/openstack/validation"
+	configvsphere "github.com/openshift/installer/pkg/asset/installconfig/vsphere"
 	"github.com/openshift/installer/pkg/asset/machines"
 	"github.com/openshift/installer/pkg/asset/quota/aws"
+	"github.com/openshift/installer/pkg/asset/quota/azure"
 	"github.com/openshift/installer/pkg/asset/quota/gcp"
+	"github.com/openshift/installer/pkg/asset/quota/ibmcloud"
 	"github.com/openshift/installer/pkg/asset/quota/openstack"
+	"github.com/openshift/installer/pkg/asset/quota/vsphere"
 	"github.com/openshift/installer/pkg/diagnostics"
 	"github.com/openshift/installer/pkg/quota"
 	quotaaws "github.com/openshift/installer/pkg/quota/aws"
+	quotaazure "github.com/openshift/installer/pkg/quota/azure"
 	quotagcp "github.com/openshift/installer/pkg/quota/gcp"
+	quotaibmcloud "github.com/openshift/installer/pkg/quota/ibmcloud"
+	quotavsphere "github.com/openshift/installer/pkg/quota/vsphere"
 	"github.com/openshift/installer/pkg/types/alibabacloud"
 	typesaws "github.com/openshift/installer/pkg/types/aws"
-	"github.com/openshift/installer/pkg/types/azure"
+	typesazure "github.com/openshift/installer/pkg/types/azure"
 	"github.com/openshift/installer/pkg/types/baremetal"
 	typesgcp "github.com/openshift/installer/pkg/types/gcp"
-	"github.com/openshift/installer/pkg/types/ibmcloud"
+	typesibmcloud "github.com/openshift/installer/pkg/types/ibmcloud"
 	"github.com/openshift/installer/pkg/types/libvirt"
 	"github.com/openshift/installer/pkg/types/none"
 	typesopenstack "github.com/openshift/installer/pkg/types/openstack"
 	"github.com/openshift/installer/pkg/types/ovirt"
-	"github.com/openshift/installer/pkg/types/vsphere"
+	typesvsphere "github.com/openshift/installer/pkg/types/vsphere"
 )
 
 // PlatformQuotaCheck is an asset that validates the install-config platform for
@@ -67,86 +75,144 @@ func (a *PlatformQuotaCheck) Generate(dependencies asset.Parents) error {
 		return err
 	}
 
+	reports, err := Reports(ic, masters, workers)
+	if err != nil {
+		return err
+	}
+	for _, report := range reports {
+		if report.Result == quota.NotAvailable || report.Result == quota.Unknown {
+			return summarizeFailingReport(reports)
+		}
+	}
+	summarizeReport(reports)
+	return nil
+}
+
+// Reports returns the reports of the quota constraints of the cluster. The
+// reports are nil when the quotas of the platform are not checked, and a
+// warning is logged when they cannot be checked.
+func Reports(ic *installconfig.InstallConfig, masters []machineapi.Machine, workers []machineapi.MachineSet) ([]quota.ConstraintReport, error) {
+	q, constraints, err := load(ic, masters, workers)
+	if err != nil || constraints == nil {
+		return nil, err
+	}
+	// the failing constraints are reported rather than returned as an error
+	reports, _ := quota.Check(q, constraints)
+	return reports, nil
+}
+
+// load returns the quotas of the platform and the constraints of the cluster
+// on them. The constraints are nil when the quotas are not checked.
+func load(ic *installconfig.InstallConfig, masters []machineapi.Machine, workers []machineapi.MachineSet) ([]quota.Quota, []quota.Constraint, error) {
 	platform := ic.Config.Platform.Name()
 	switch platform {
 	case typesaws.Name:
 		if !quotaaws.SupportedRegions.Has(ic.AWS.Region) {
 			logrus.Debugf("%s does not support API for checking quotas, therefore skipping.", ic.AWS.Region)
-			return nil
+			return nil, nil, nil
 		}
 		services := []string{"ec2", "vpc"}
 		session, err := ic.AWS.Session(context.TODO())
 		if err != nil {
-			return errors.Wrap(err, "failed to load AWS session")
+			return nil, nil, errors.Wrap(err, "failed to load AWS session")
 		}
 		q, err := quotaaws.Load(context.TODO(), session, ic.AWS.Region, services...)
 		if quotaaws.IsUnauthorized(err) {
 			logrus.Warnf("Missing permissions to fetch Quotas and therefore will skip checking them: %v, make sure you have `servicequotas:ListAWSDefaultServiceQuotas` permission available to the user.", err)
-			return nil
+			return nil, nil, nil
 		}
 		if err != nil {
-			return errors.Wrapf(err, "failed to load Quota for services: %s", strings.Join(services, ", "))
+			return nil, nil, errors.Wrapf(err, "failed to load Quota for services: %s", strings.Join(services, ", "))
 		}
 		instanceTypes, err := aws.InstanceTypes(context.TODO(), session, ic.AWS.Region)
 		if quotaaws.IsUnauthorized(err) {
 			logrus.Warnf("Missing permissions to fetch instance types and therefore will skip checking Quotas: %v, make sure you have `ec2:DescribeInstanceTypes` permission available to the user.", err)
-			return nil
+			return nil, nil, nil
 		}
 		if err != nil {
-			return errors.Wrapf(err, "failed to load instance types for %s", ic.AWS.Region)
-		}
-		reports, err := quota.Check(q, aws.Constraints(ic.Config, masters, workers, instanceTypes))
-		if err != nil {
-			return summarizeFailingReport(reports)
+			return nil, nil, errors.Wrapf(err, "failed to load instance types for %s", ic.AWS.Region)
 		}
-		summarizeReport(reports)
+		return q, aws.Constraints(ic.Config, masters, workers, instanceTypes), nil
 	case typesgcp.Name:
 		services := []string{"compute.googleapis.com", "iam.googleapis.com"}
 		q, err := quotagcp.Load(context.TODO(), ic.Config.Platform.GCP.ProjectID, services...)
 		if quotagcp.IsUnauthorized(err) {
 			logrus.Warnf("Missing permissions to fetch Quotas and therefore will skip checking them: %v, make sure you have `roles/servicemanagement.quotaViewer` assigned to the user.", err)
-			return nil
+			return nil, nil, nil
 		}
 		if err != nil {
-			return errors.Wrapf(err, "failed to load Quota for services: %s", strings.Join(services, ", "))
+			return nil, nil, errors.Wrapf(err, "failed to load Quota for services: %s", strings.Join(services, ", "))
 		}
 		session, err := configgcp.GetSession(context.TODO())
 		if err != nil {
-			return errors.Wrap(err, "failed to load GCP session")
+			return nil, nil, errors.Wrap(err, "failed to load GCP session")
 		}
 		client, err := gcp.NewClient(context.TODO(), session, ic.Config.Platform.GCP.ProjectID)
 		if err != nil {
-			return errors.Wrap(err, "failed to create client for quota constraints")
+			return nil, nil, errors.Wrap(err, "failed to create client for quota constraints")
 		}
-		reports, err := quota.Check(q, gcp.Constraints(client, ic.Config, masters, workers))
+		return q, gcp.Constraints(client, ic.Config, masters, workers), nil
+	case typesazure.Name:
+		client, err := ic.Azure.Client()
 		if err != nil {
-			return summarizeFailingReport(reports)
+			return nil, nil, errors.Wrap(err, "failed to create Azure client")
+		}
+		q, err := quotaazure.Load(context.TODO(), client, ic.Config.Platform.Azure.Region)
+		if quotaazure.IsUnauthorized(err) {
+			logrus.Warnf("Missing permissions to fetch Quotas and therefore will skip checking them: %v, make sure you have `Microsoft.Compute/locations/usages/read` and `Microsoft.Network/locations/usages/read` permissions available to the user.", err)
+			return nil, nil, nil
+		}
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "failed to load Quota for %s", ic.Config.Platform.Azure.Region)
+		}
+		return q, azure.Constraints(context.TODO(), client, ic.Config, masters, workers), nil
+	case typesibmcloud.Name:
+		client, err := ic.IBMCloud.Client()
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "failed to create IBM Cloud client")
+		}
+		q, err := quotaibmcloud.Load(context.TODO(), client, ic.Config.Platform.IBMCloud.Region)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "failed to load Quota for %s", ic.Config.Platform.IBMCloud.Region)
 		}
-		summarizeReport(reports)
+		return q, ibmcloud.Constraints(context.TODO(), client, ic.Config, masters, workers), nil
 	case typesopenstack.Name:
 		if skip := os.Getenv("OPENSHIFT_INSTALL_SKIP_PREFLIGHT_VALIDATIONS"); skip == "1" {
 			logrus.Warnf("OVERRIDE: pre-flight validation disabled.")
-			return nil
+			return nil, nil, nil
 		}
 		ci, err := openstackvalidation.GetCloudInfo(ic.Config)
 		if err != nil {
-			return errors.Wrap(err, "failed to get cloud info")
+			return nil, nil, errors.Wrap(err, "failed to get cloud info")
 		}
 		if ci == nil {
 			logrus.Warnf("Empty OpenStack cloud info and therefore will skip checking quota validation.")
-			return nil
+			return nil, nil, nil
 		}
-		reports, err := quota.Check(ci.Quotas, openstack.Constraints(ci, masters, workers, ic.Config.NetworkType))
+		return ci.Quotas, openstack.Constraints(ci, masters, workers, ic.Config.NetworkType), nil
+	case typesvsphere.Name:
+		if skip := os.Getenv("OPENSHIFT_INSTALL_SKIP_PREFLIGHT_VALIDATIONS"); skip == "1" {
+			logrus.Warnf("OVERRIDE: pre-flight validation disabled.")
+			return nil, nil, nil
+		}
+		p := ic.Config.Platform.VSphere
+		vim25Client, _, err := typesvsphere.CreateVSphereClients(context.TODO(), p.VCenter, p.Username, p.Password)
 		if err != nil {
-			return summarizeFailingReport(reports)
+			logrus.Warnf("Failed to connect to vCenter %s and therefore will skip checking the capacity: %v", p.VCenter, err)
+			return nil, nil, nil
 		}
-		summarizeReport(reports)
-	case alibabacloud.Name, azure.Name, baremetal.Name, ibmcloud.Name, libvirt.Name, none.Name, ovirt.Name, vsphere.Name:
+		q, err := quotavsphere.Load(context.TODO(), configvsphere.NewCapacityGetter(vim25Client), p.Datacenter, p.Cluster, p.DefaultDatastore)
+		if err != nil {
+			logrus.Warnf("Failed to load the capacity of the vSphere resources and therefore will skip checking it: %v", err)
+			return nil, nil, nil
+		}
+		return q, vsphere.Constraints(ic.Config, masters, workers), nil
+	case alibabacloud.Name, baremetal.Name, libvirt.Name, none.Name, ovirt.Name:
 		// no special provisioning requirements to check
 	default:
-		err = fmt.Errorf("unknown platform type %q", platform)
+		return nil, nil, fmt.Errorf("unknown platform type %q", platform)
 	}
-	return err
+	return nil, nil, nil
 }
 
 // Name returns the human-friendly name of the asset.
@@ -190,7 +256,6 @@ func summarizeFailingReport(reports []quota.ConstraintReport) error {
 
 // summarizeReport summarizes a report when there are availble.
 func summarizeReport(reports []quota.ConstraintReport) {
-	var low []string
 	var regionMessage string
 	for _, report := range reports {
 		switch report.Result {
@@ -200,12 +265,9 @@ func summarizeReport(reports []quota.ConstraintReport) {
 			} else {
 				regionMessage = ""
 			}
-			low = append(low, fmt.Sprintf("%s%s", report.For.Name, regionMessage))
+			logrus.Warnf("Quota %s%s is available but will be completely used pretty soon: %s", report.For.Name, regionMessage, report.Message)
 		default:
 			continue
 		}
 	}
-	if len(low) > 0 {
-		logrus.Warnf("Following quotas %s are available but will be completely used pretty soon.", strings.Join(low, ", "))
-	}
 }
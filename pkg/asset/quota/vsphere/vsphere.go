@@ -0,0 +1,39 @@
+package vsphere
+
+import (
+	machineapi "github.com/openshift/api/machine/v1beta1"
+
+	"github.com/openshift/installer/pkg/quota"
+	"github.com/openshift/installer/pkg/types"
+)
+
+// Constraints returns a list of quota constraints based on the InstallConfig.
+// These constraints can be used to check if there is enough capacity for creating a cluster
+// for the install config.
+func Constraints(config *types.InstallConfig, controlPlanes []machineapi.Machine, computes []machineapi.MachineSet) []quota.Constraint {
+	var cpus, memoryMiB, diskGiB int64
+	addMachines := func(spec *machineapi.VSphereMachineProviderSpec, count int64) {
+		cpus += int64(spec.NumCPUs) * count
+		memoryMiB += spec.MemoryMiB * count
+		diskGiB += int64(spec.DiskGiB) * count
+	}
+	for i, m := range controlPlanes {
+		spec := m.Spec.ProviderSpec.Value.Object.(*machineapi.VSphereMachineProviderSpec)
+		if i == 0 {
+			// the bootstrap machine has the size of the control plane machines
+			addMachines(spec, 1)
+		}
+		addMachines(spec, 1)
+	}
+	for _, w := range computes {
+		spec := w.Spec.Template.Spec.ProviderSpec.Value.Object.(*machineapi.VSphereMachineProviderSpec)
+		addMachines(spec, int64(*w.Spec.Replicas))
+	}
+
+	platform := config.Platform.VSphere
+	return []quota.Constraint{
+		{Name: "datastore/space", Region: platform.DefaultDatastore, Count: diskGiB},
+		{Name: "cluster/cpu", Region: platform.Cluster, Count: cpus},
+		{Name: "cluster/memory", Region: platform.Cluster, Count: memoryMiB},
+	}
+}
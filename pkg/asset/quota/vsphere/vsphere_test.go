@@ -0,0 +1,42 @@
+package vsphere
+
+import (
+	"testing"
+
+	machineapi "github.com/openshift/api/machine/v1beta1"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/pointer"
+
+	"github.com/openshift/installer/pkg/quota"
+	"github.com/openshift/installer/pkg/types"
+	vspheretypes "github.com/openshift/installer/pkg/types/vsphere"
+)
+
+func providerSpec(cpus int32, memoryMiB int64, diskGiB int32) *runtime.RawExtension {
+	return &runtime.RawExtension{Object: &machineapi.VSphereMachineProviderSpec{NumCPUs: cpus, MemoryMiB: memoryMiB, DiskGiB: diskGiB}}
+}
+
+func TestConstraints(t *testing.T) {
+	config := &types.InstallConfig{
+		Platform: types.Platform{
+			VSphere: &vspheretypes.Platform{Cluster: "cluster1", DefaultDatastore: "datastore1"},
+		},
+	}
+	controlPlanes := make([]machineapi.Machine, 3)
+	for i := range controlPlanes {
+		controlPlanes[i].Spec.ProviderSpec.Value = providerSpec(4, 16384, 120)
+	}
+	computes := []machineapi.MachineSet{{}, {}}
+	computes[0].Spec.Replicas = pointer.Int32Ptr(3)
+	computes[0].Spec.Template.Spec.ProviderSpec.Value = providerSpec(2, 8192, 120)
+	computes[1].Spec.Replicas = pointer.Int32Ptr(0)
+	computes[1].Spec.Template.Spec.ProviderSpec.Value = providerSpec(8, 32768, 240)
+
+	expected := []quota.Constraint{
+		{Name: "datastore/space", Region: "datastore1", Count: 840},
+		{Name: "cluster/cpu", Region: "cluster1", Count: 22},
+		{Name: "cluster/memory", Region: "cluster1", Count: 90112},
+	}
+	assert.Equal(t, expected, Constraints(config, controlPlanes, computes))
+}
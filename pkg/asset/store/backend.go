@@ -0,0 +1,175 @@
+package store
+
+import (
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Backend stores the files of an install directory, such as the state file,
+// so that they can be shared by installers running on different machines.
+type Backend interface {
+	// Read returns the contents of the named file. If the file does not
+	// exist, the returned error satisfies os.IsNotExist.
+	Read(name string) ([]byte, error)
+
+	// Write replaces the contents of the named file.
+	Write(name string, data []byte) error
+
+	// Delete removes the named file. Deleting a file that does not exist is
+	// not an error.
+	Delete(name string) error
+
+	// List returns the slash-separated names of all of the files.
+	List() ([]string, error)
+}
+
+// NewBackend returns the Backend for the given location. An empty location
+// selects the install directory itself. A location of the form
+// s3://bucket/prefix selects an S3-compatible object store; see
+// newS3Backend for the supported query parameters.
+func NewBackend(location string, directory string) (Backend, error) {
+	if location == "" {
+		return &localBackend{directory: directory}, nil
+	}
+
+	u, err := url.Parse(location)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse store location %q", location)
+	}
+	switch u.Scheme {
+	case "file":
+		return &localBackend{directory: filepath.FromSlash(u.Path)}, nil
+	case "s3":
+		return newS3Backend(u)
+	default:
+		return nil, errors.Errorf("unsupported store location %q; must be a file:// or s3:// URL", location)
+	}
+}
+
+// localBackend stores files in a directory on the local filesystem.
+type localBackend struct {
+	directory string
+}
+
+// Read returns the contents of the named file.
+func (b *localBackend) Read(name string) ([]byte, error) {
+	return ioutil.ReadFile(filepath.Join(b.directory, filepath.FromSlash(name)))
+}
+
+// Write replaces the contents of the named file.
+func (b *localBackend) Write(name string, data []byte) error {
+	path := filepath.Join(b.directory, filepath.FromSlash(name))
+	if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, data, 0640)
+}
+
+// Delete removes the named file.
+func (b *localBackend) Delete(name string) error {
+	err := os.Remove(filepath.Join(b.directory, filepath.FromSlash(name)))
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// List returns the names of all of the files in the directory.
+func (b *localBackend) List() ([]string, error) {
+	var names []string
+	err := filepath.Walk(b.directory, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) && path == b.directory {
+				return filepath.SkipDir
+			}
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		name, err := filepath.Rel(b.directory, path)
+		if err != nil {
+			return err
+		}
+		names = append(names, filepath.ToSlash(name))
+		return nil
+	})
+	return names, err
+}
+
+// managedFile returns whether the named file is managed by the store itself
+// rather than mirrored between the directory and the backend: the state file,
+// its backups written by MigrateState, and the lock.
+func managedFile(name string) bool {
+	if strings.HasPrefix(name, stateFileName+".v") && strings.HasSuffix(name, ".backup") {
+		return true
+	}
+	return name == stateFileName || name == lockFileName
+}
+
+// Pull copies the files in the backend, other than the files managed by the
+// store, into the directory.
+func Pull(backend Backend, directory string) error {
+	names, err := backend.List()
+	if err != nil {
+		return errors.Wrap(err, "failed to list stored files")
+	}
+	local := &localBackend{directory: directory}
+	for _, name := range names {
+		if managedFile(name) {
+			continue
+		}
+		data, err := backend.Read(name)
+		if err != nil {
+			return errors.Wrapf(err, "failed to read stored file %q", name)
+		}
+		if err := local.Write(name, data); err != nil {
+			return errors.Wrapf(err, "failed to write %q", name)
+		}
+	}
+	return nil
+}
+
+// Push copies the files in the directory, other than the files managed by the
+// store, into the backend. Files in the backend that are no longer in the directory are
+// deleted from the backend.
+func Push(backend Backend, directory string) error {
+	local := &localBackend{directory: directory}
+	names, err := local.List()
+	if err != nil {
+		return errors.Wrapf(err, "failed to list files in %q", directory)
+	}
+	pushed := make(map[string]bool, len(names))
+	for _, name := range names {
+		if managedFile(name) {
+			continue
+		}
+		data, err := local.Read(name)
+		if err != nil {
+			return errors.Wrapf(err, "failed to read %q", name)
+		}
+		if err := backend.Write(name, data); err != nil {
+			return errors.Wrapf(err, "failed to store %q", name)
+		}
+		pushed[name] = true
+	}
+
+	stored, err := backend.List()
+	if err != nil {
+		return errors.Wrap(err, "failed to list stored files")
+	}
+	for _, name := range stored {
+		if managedFile(name) || pushed[name] {
+			continue
+		}
+		if err := backend.Delete(name); err != nil {
+			return errors.Wrapf(err, "failed to delete stored file %q", name)
+		}
+	}
+	return nil
+}
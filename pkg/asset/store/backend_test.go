@@ -0,0 +1,230 @@
+package store
+
+import (
+	"encoding/xml"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeS3 is an in-memory stand-in for an S3-compatible object store, serving
+// path-style requests for a single bucket.
+type fakeS3 struct {
+	bucket  string
+	mu      sync.Mutex
+	objects map[string][]byte
+
+	// ignoreConditions makes the store ignore If-None-Match, like
+	// S3-compatible stores without conditional writes.
+	ignoreConditions bool
+}
+
+type fakeS3ListResult struct {
+	XMLName     xml.Name `xml:"ListBucketResult"`
+	Name        string
+	Prefix      string
+	KeyCount    int
+	IsTruncated bool
+	Contents    []struct{ Key string }
+}
+
+func (s *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !strings.HasPrefix(r.URL.Path, "/"+s.bucket) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`<Error><Code>NoSuchBucket</Code></Error>`))
+		return
+	}
+	key := strings.TrimPrefix(strings.TrimPrefix(r.URL.Path, "/"+s.bucket), "/")
+
+	switch {
+	case r.Method == http.MethodGet && key == "":
+		result := fakeS3ListResult{Name: s.bucket, Prefix: r.URL.Query().Get("prefix")}
+		keys := make([]string, 0, len(s.objects))
+		for k := range s.objects {
+			if strings.HasPrefix(k, result.Prefix) {
+				keys = append(keys, k)
+			}
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			result.Contents = append(result.Contents, struct{ Key string }{Key: k})
+		}
+		result.KeyCount = len(keys)
+		data, _ := xml.Marshal(result)
+		w.Write(data)
+	case r.Method == http.MethodGet:
+		data, ok := s.objects[key]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`<Error><Code>NoSuchKey</Code><Message>The specified key does not exist.</Message></Error>`))
+			return
+		}
+		w.Write(data)
+	case r.Method == http.MethodPut:
+		if _, ok := s.objects[key]; ok && r.Header.Get("If-None-Match") == "*" && !s.ignoreConditions {
+			w.WriteHeader(http.StatusPreconditionFailed)
+			w.Write([]byte(`<Error><Code>PreconditionFailed</Code><Message>At least one of the pre-conditions you specified did not hold</Message></Error>`))
+			return
+		}
+		data, _ := ioutil.ReadAll(r.Body)
+		s.objects[key] = data
+	case r.Method == http.MethodDelete:
+		delete(s.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func newFakeS3Backend(t *testing.T) (*fakeS3, Backend) {
+	os.Setenv("AWS_ACCESS_KEY_ID", "id")
+	os.Setenv("AWS_SECRET_ACCESS_KEY", "secret")
+
+	fake := &fakeS3{bucket: "bucket", objects: map[string][]byte{}}
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	backend, err := NewBackend("s3://bucket/cluster?region=us-east-1&endpoint="+url.QueryEscape(server.URL), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return fake, backend
+}
+
+func TestNewBackend(t *testing.T) {
+	cases := []struct {
+		location string
+		expected Backend
+		err      string
+	}{{
+		location: "",
+		expected: &localBackend{directory: "dir"},
+	}, {
+		location: "file:///var/lib/install",
+		expected: &localBackend{directory: "/var/lib/install"},
+	}, {
+		location: "s3:///prefix",
+		err:      `^store location "s3:///prefix" does not specify a bucket$`,
+	}, {
+		location: "gs://bucket",
+		err:      `^unsupported store location "gs://bucket"; must be a file:// or s3:// URL$`,
+	}}
+	for _, tc := range cases {
+		t.Run(tc.location, func(t *testing.T) {
+			backend, err := NewBackend(tc.location, "dir")
+			if tc.err != "" {
+				assert.Regexp(t, tc.err, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, backend)
+		})
+	}
+}
+
+func TestS3Backend(t *testing.T) {
+	fake, backend := newFakeS3Backend(t)
+
+	_, err := backend.Read("metadata.json")
+	assert.True(t, os.IsNotExist(err), "expected not-exist error, got %v", err)
+
+	assert.NoError(t, backend.Write("metadata.json", []byte("{}")))
+	assert.NoError(t, backend.Write("auth/kubeconfig", []byte("kubeconfig")))
+	assert.Equal(t, map[string][]byte{
+		"cluster/metadata.json":   []byte("{}"),
+		"cluster/auth/kubeconfig": []byte("kubeconfig"),
+	}, fake.objects)
+
+	data, err := backend.Read("auth/kubeconfig")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("kubeconfig"), data)
+
+	names, err := backend.List()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"auth/kubeconfig", "metadata.json"}, names)
+
+	assert.NoError(t, backend.Delete("metadata.json"))
+	assert.NoError(t, backend.Delete("metadata.json"))
+	names, err = backend.List()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"auth/kubeconfig"}, names)
+}
+
+func TestPushPull(t *testing.T) {
+	_, backend := newFakeS3Backend(t)
+
+	src, err := ioutil.TempDir("", "TestPushPull")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(src)
+	dst, err := ioutil.TempDir("", "TestPushPull")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dst)
+
+	for name, data := range map[string]string{
+		"metadata.json":   "{}",
+		"auth/kubeconfig": "kubeconfig",
+		stateFileName:     "{}",
+	} {
+		if err := (&localBackend{directory: src}).Write(name, []byte(data)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	assert.NoError(t, backend.Write("stale.tfvars.json", []byte("{}")))
+
+	assert.NoError(t, Push(backend, src))
+	names, err := backend.List()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"auth/kubeconfig", "metadata.json"}, names, "the state file should not be pushed and stale files should be deleted")
+
+	assert.NoError(t, Pull(backend, dst))
+	data, err := ioutil.ReadFile(filepath.Join(dst, "auth", "kubeconfig"))
+	assert.NoError(t, err)
+	assert.Equal(t, "kubeconfig", string(data))
+	_, err = os.Stat(filepath.Join(dst, stateFileName))
+	assert.True(t, os.IsNotExist(err), "the state file should not be pulled")
+}
+
+func TestStoreWithBackend(t *testing.T) {
+	clearAssetBehaviors()
+	fake, backend := newFakeS3Backend(t)
+
+	tempDir, err := ioutil.TempDir("", "TestStoreWithBackend")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	store, err := newStore(tempDir, WithBackend(backend))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.NoError(t, store.Fetch(&testStoreAssetA{}))
+	assert.Contains(t, fake.objects, "cluster/"+stateFileName)
+	_, err = os.Stat(filepath.Join(tempDir, stateFileName))
+	assert.True(t, os.IsNotExist(err), "the state file should not be written to the directory")
+
+	store, err = newStore(tempDir, WithBackend(backend))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.True(t, store.isAssetInState(&testStoreAssetA{}), "the state should be loaded from the backend")
+
+	assert.NoError(t, store.DestroyState())
+	assert.NotContains(t, fake.objects, "cluster/"+stateFileName)
+}
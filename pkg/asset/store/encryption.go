@@ -0,0 +1,382 @@
+package store
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/openpgp"
+	"golang.org/x/crypto/scrypt"
+
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/kubeconfig"
+	"github.com/openshift/installer/pkg/asset/password"
+	"github.com/openshift/installer/pkg/asset/tls"
+)
+
+const (
+	// PassphraseEnvVar is the environment variable holding the passphrase
+	// used to encrypt the state file and sensitive assets.
+	PassphraseEnvVar = "OPENSHIFT_INSTALL_ENCRYPTION_PASSPHRASE"
+
+	// PGPRecipientsEnvVar is the environment variable holding the path to
+	// an armored PGP public keyring. The state file and sensitive assets are
+	// encrypted to every key in the keyring.
+	PGPRecipientsEnvVar = "OPENSHIFT_INSTALL_ENCRYPTION_PGP_RECIPIENTS"
+
+	// PGPKeyringEnvVar is the environment variable holding the path to an
+	// armored PGP secret keyring, used to decrypt the state file and
+	// sensitive assets encrypted with PGPRecipientsEnvVar.
+	PGPKeyringEnvVar = "OPENSHIFT_INSTALL_ENCRYPTION_PGP_KEYRING"
+
+	envelopeVersion = 1
+	dataKeySize     = 32
+
+	keyWrapPassphrase = "scrypt"
+	keyWrapPGP        = "pgp"
+)
+
+// Cipher encrypts and decrypts the state file and sensitive assets.
+type Cipher interface {
+	Encrypt(plaintext []byte) ([]byte, error)
+	Decrypt(ciphertext []byte) ([]byte, error)
+}
+
+// envelope is the on-disk format of encrypted data. The data is encrypted
+// with a random data key, and the data key is wrapped with a
+// key-encryption key derived from a passphrase or with PGP.
+type envelope struct {
+	Version    int    `json:"openshiftInstallEncryptionVersion"`
+	KeyWrap    string `json:"keyWrap"`
+	Salt       []byte `json:"salt,omitempty"`
+	WrappedKey []byte `json:"wrappedKey"`
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+// keyWrapper wraps and unwraps the data key of an envelope.
+type keyWrapper interface {
+	wrap(dataKey []byte, e *envelope) error
+	unwrap(e *envelope) ([]byte, error)
+}
+
+// envelopeCipher is a Cipher encrypting data into envelopes.
+type envelopeCipher struct {
+	keyWrap string
+	keys    keyWrapper
+}
+
+// NewPassphraseCipher returns a Cipher wrapping data keys with a key derived
+// from the passphrase.
+func NewPassphraseCipher(passphrase string) Cipher {
+	return &envelopeCipher{keyWrap: keyWrapPassphrase, keys: &passphraseKeys{passphrase: []byte(passphrase)}}
+}
+
+// NewPGPCipher returns a Cipher wrapping data keys for the PGP recipients.
+// Data can only be decrypted if keyring holds the secret key of one of the
+// recipients.
+func NewPGPCipher(recipients openpgp.EntityList, keyring openpgp.EntityList) Cipher {
+	return &envelopeCipher{keyWrap: keyWrapPGP, keys: &pgpKeys{recipients: recipients, keyring: keyring}}
+}
+
+// NewCipherFromEnvironment returns the Cipher configured with
+// PassphraseEnvVar, or with PGPRecipientsEnvVar and PGPKeyringEnvVar. If
+// encryption is not configured, it returns nil.
+func NewCipherFromEnvironment() (Cipher, error) {
+	if passphrase := os.Getenv(PassphraseEnvVar); passphrase != "" {
+		return NewPassphraseCipher(passphrase), nil
+	}
+
+	recipientsPath, keyringPath := os.Getenv(PGPRecipientsEnvVar), os.Getenv(PGPKeyringEnvVar)
+	if recipientsPath == "" && keyringPath == "" {
+		return nil, nil
+	}
+	var recipients, keyring openpgp.EntityList
+	var err error
+	if recipientsPath != "" {
+		if recipients, err = readArmoredKeyRing(recipientsPath); err != nil {
+			return nil, errors.Wrapf(err, "failed to read PGP recipients from %s", PGPRecipientsEnvVar)
+		}
+	}
+	if keyringPath != "" {
+		if keyring, err = readArmoredKeyRing(keyringPath); err != nil {
+			return nil, errors.Wrapf(err, "failed to read PGP keyring from %s", PGPKeyringEnvVar)
+		}
+	}
+	if recipients == nil {
+		// Encrypt to the keys of the secret keyring.
+		recipients = keyring
+	}
+	return NewPGPCipher(recipients, keyring), nil
+}
+
+func readArmoredKeyRing(path string) (openpgp.EntityList, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return openpgp.ReadArmoredKeyRing(f)
+}
+
+// Encrypt encrypts the plaintext into an envelope.
+func (c *envelopeCipher) Encrypt(plaintext []byte) ([]byte, error) {
+	dataKey := make([]byte, dataKeySize)
+	if _, err := io.ReadFull(rand.Reader, dataKey); err != nil {
+		return nil, errors.Wrap(err, "failed to generate data key")
+	}
+	e := &envelope{Version: envelopeVersion, KeyWrap: c.keyWrap}
+	if err := c.keys.wrap(dataKey, e); err != nil {
+		return nil, errors.Wrap(err, "failed to wrap data key")
+	}
+	var err error
+	e.Nonce, e.Ciphertext, err = seal(dataKey, plaintext)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(e)
+}
+
+// Decrypt decrypts the envelope.
+func (c *envelopeCipher) Decrypt(data []byte) ([]byte, error) {
+	e, ok := parseEnvelope(data)
+	if !ok {
+		return nil, errors.New("data is not encrypted")
+	}
+	if e.Version != envelopeVersion {
+		return nil, errors.Errorf("unsupported encryption version %d", e.Version)
+	}
+	if e.KeyWrap != c.keyWrap {
+		return nil, errors.Errorf("data was encrypted with %s, not %s", e.KeyWrap, c.keyWrap)
+	}
+	dataKey, err := c.keys.unwrap(e)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to unwrap data key")
+	}
+	return open(dataKey, e.Nonce, e.Ciphertext)
+}
+
+// parseEnvelope returns the envelope in data, if data is an envelope.
+func parseEnvelope(data []byte) (*envelope, bool) {
+	if !bytes.HasPrefix(bytes.TrimSpace(data), []byte("{")) {
+		return nil, false
+	}
+	e := &envelope{}
+	if err := json.Unmarshal(data, e); err != nil || e.Version == 0 {
+		return nil, false
+	}
+	return e, true
+}
+
+// isEncrypted returns whether data is an envelope.
+func isEncrypted(data []byte) bool {
+	_, ok := parseEnvelope(data)
+	return ok
+}
+
+// decrypt decrypts data if it is encrypted, and returns it unchanged
+// otherwise. The name is used in errors.
+func decrypt(c Cipher, name string, data []byte) ([]byte, error) {
+	if !isEncrypted(data) {
+		return data, nil
+	}
+	if c == nil {
+		return nil, errors.Errorf("%s is encrypted; set %s, or %s, to decrypt it", name, PassphraseEnvVar, PGPKeyringEnvVar)
+	}
+	plaintext, err := c.Decrypt(data)
+	return plaintext, errors.Wrapf(err, "failed to decrypt %s", name)
+}
+
+func seal(key []byte, plaintext []byte) (nonce []byte, ciphertext []byte, err error) {
+	aead, err := newGCM(key)
+	if err != nil {
+		return nil, nil, err
+	}
+	nonce = make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, nil, errors.Wrap(err, "failed to generate nonce")
+	}
+	return nonce, aead.Seal(nil, nonce, plaintext, nil), nil
+}
+
+func open(key []byte, nonce []byte, ciphertext []byte) ([]byte, error) {
+	aead, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := aead.Open(nil, nonce, ciphertext, nil)
+	return plaintext, errors.Wrap(err, "failed to decrypt")
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// passphraseKeys wraps data keys with a key derived from a passphrase with
+// scrypt.
+type passphraseKeys struct {
+	passphrase []byte
+}
+
+func (k *passphraseKeys) key(salt []byte) ([]byte, error) {
+	return scrypt.Key(k.passphrase, salt, 1<<15, 8, 1, dataKeySize)
+}
+
+func (k *passphraseKeys) wrap(dataKey []byte, e *envelope) error {
+	e.Salt = make([]byte, 16)
+	if _, err := io.ReadFull(rand.Reader, e.Salt); err != nil {
+		return err
+	}
+	key, err := k.key(e.Salt)
+	if err != nil {
+		return err
+	}
+	nonce, wrapped, err := seal(key, dataKey)
+	if err != nil {
+		return err
+	}
+	e.WrappedKey = append(nonce, wrapped...)
+	return nil
+}
+
+func (k *passphraseKeys) unwrap(e *envelope) ([]byte, error) {
+	key, err := k.key(e.Salt)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(e.WrappedKey) < aead.NonceSize() {
+		return nil, errors.New("wrapped key is too short")
+	}
+	dataKey, err := open(key, e.WrappedKey[:aead.NonceSize()], e.WrappedKey[aead.NonceSize():])
+	return dataKey, errors.Wrap(err, "incorrect passphrase")
+}
+
+// pgpKeys wraps data keys by encrypting them to PGP recipients.
+type pgpKeys struct {
+	recipients openpgp.EntityList
+	keyring    openpgp.EntityList
+}
+
+func (k *pgpKeys) wrap(dataKey []byte, e *envelope) error {
+	if len(k.recipients) == 0 {
+		return errors.Errorf("no PGP recipients; set %s", PGPRecipientsEnvVar)
+	}
+	buf := &bytes.Buffer{}
+	w, err := openpgp.Encrypt(buf, k.recipients, nil, nil, nil)
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(dataKey); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	e.WrappedKey = buf.Bytes()
+	return nil
+}
+
+func (k *pgpKeys) unwrap(e *envelope) ([]byte, error) {
+	if len(k.keyring) == 0 {
+		return nil, errors.Errorf("no PGP secret keys; set %s", PGPKeyringEnvVar)
+	}
+	md, err := openpgp.ReadMessage(bytes.NewReader(e.WrappedKey), k.keyring, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return ioutil.ReadAll(md.UnverifiedBody)
+}
+
+// isSensitive returns whether the file of the asset holds a secret that is
+// encrypted at rest: the private keys of the TLS assets, the kubeadmin
+// password and the admin kubeconfig. Certificates and public keys are left
+// in cleartext.
+func isSensitive(a asset.WritableAsset, f *asset.File) bool {
+	switch a.(type) {
+	case *password.KubeadminPassword, *kubeconfig.AdminClient:
+		return true
+	}
+	return reflect.TypeOf(a).Elem().PkgPath() == tlsPackagePath && strings.HasSuffix(f.Filename, ".key")
+}
+
+var tlsPackagePath = reflect.TypeOf(tls.RootCA{}).PkgPath()
+
+// persistedAsset overrides the files of a WritableAsset.
+type persistedAsset struct {
+	asset.WritableAsset
+	files []*asset.File
+}
+
+// Files returns the overridden files.
+func (a *persistedAsset) Files() []*asset.File {
+	return a.files
+}
+
+// PersistToFile writes all of the files of the specified asset into the
+// specified directory, like asset.PersistToFile. If the cipher is not nil,
+// sensitive files are encrypted with it.
+func PersistToFile(a asset.WritableAsset, directory string, c Cipher) error {
+	if c == nil {
+		return asset.PersistToFile(a, directory)
+	}
+	files := make([]*asset.File, 0, len(a.Files()))
+	for _, f := range a.Files() {
+		if isSensitive(a, f) {
+			data, err := c.Encrypt(f.Data)
+			if err != nil {
+				return errors.Wrapf(err, "failed to encrypt %s", f.Filename)
+			}
+			f = &asset.File{Filename: f.Filename, Data: data}
+		}
+		files = append(files, f)
+	}
+	return asset.PersistToFile(&persistedAsset{WritableAsset: a, files: files}, directory)
+}
+
+// ReadFile reads the file at path, decrypting it with the cipher if it is
+// encrypted.
+func ReadFile(path string, c Cipher) ([]byte, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return decrypt(c, filepath.Base(path), data)
+}
+
+// IsEncryptedFile returns whether the file at path is encrypted.
+func IsEncryptedFile(path string) (bool, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return false, err
+	}
+	return isEncrypted(data), nil
+}
+
+// WriteFile writes data to the file at path, encrypting it with the cipher
+// if the cipher is not nil.
+func WriteFile(path string, data []byte, c Cipher) error {
+	if c != nil {
+		var err error
+		if data, err = c.Encrypt(data); err != nil {
+			return errors.Wrapf(err, "failed to encrypt %s", filepath.Base(path))
+		}
+	}
+	return ioutil.WriteFile(path, data, 0640)
+}
@@ -0,0 +1,171 @@
+package store
+
+import (
+	"bytes"
+	"crypto"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/openpgp"
+	"golang.org/x/crypto/openpgp/s2k"
+
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/password"
+	"github.com/openshift/installer/pkg/asset/tls"
+)
+
+// newPGPEntity returns a PGP entity which, like keys generated with gpg,
+// prefers SHA-256.
+func newPGPEntity(t *testing.T, name string) *openpgp.Entity {
+	entity, err := openpgp.NewEntity(name, "", name+"@example.com", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sha256, _ := s2k.HashToHashId(crypto.SHA256)
+	for _, id := range entity.Identities {
+		id.SelfSignature.PreferredHash = []uint8{sha256}
+	}
+	return entity
+}
+
+func TestCipher(t *testing.T) {
+	recipient := newPGPEntity(t, "recipient")
+	other := newPGPEntity(t, "other")
+
+	cases := []struct {
+		name      string
+		encrypter Cipher
+		decrypter Cipher
+		err       string
+	}{{
+		name:      "passphrase",
+		encrypter: NewPassphraseCipher("secret"),
+		decrypter: NewPassphraseCipher("secret"),
+	}, {
+		name:      "wrong passphrase",
+		encrypter: NewPassphraseCipher("secret"),
+		decrypter: NewPassphraseCipher("guess"),
+		err:       `^failed to unwrap data key: incorrect passphrase: `,
+	}, {
+		name:      "pgp",
+		encrypter: NewPGPCipher(openpgp.EntityList{recipient}, nil),
+		decrypter: NewPGPCipher(nil, openpgp.EntityList{recipient}),
+	}, {
+		name:      "pgp without the recipient's key",
+		encrypter: NewPGPCipher(openpgp.EntityList{recipient}, nil),
+		decrypter: NewPGPCipher(nil, openpgp.EntityList{other}),
+		err:       `^failed to unwrap data key: `,
+	}, {
+		name:      "mismatched key wrap",
+		encrypter: NewPassphraseCipher("secret"),
+		decrypter: NewPGPCipher(nil, openpgp.EntityList{recipient}),
+		err:       `^data was encrypted with scrypt, not pgp$`,
+	}}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			plaintext := []byte("pull-secret")
+			ciphertext, err := tc.encrypter.Encrypt(plaintext)
+			if !assert.NoError(t, err) {
+				return
+			}
+			assert.False(t, bytes.Contains(ciphertext, plaintext), "ciphertext contains the plaintext")
+			assert.True(t, isEncrypted(ciphertext))
+
+			decrypted, err := tc.decrypter.Decrypt(ciphertext)
+			if tc.err != "" {
+				assert.Regexp(t, tc.err, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, plaintext, decrypted)
+		})
+	}
+}
+
+func TestDecrypt(t *testing.T) {
+	c := NewPassphraseCipher("secret")
+	ciphertext, err := c.Encrypt([]byte("data"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := decrypt(nil, "file", []byte(`{"*installconfig.InstallConfig": {}}`))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte(`{"*installconfig.InstallConfig": {}}`), data, "cleartext data should be returned unchanged")
+
+	_, err = decrypt(nil, "file", ciphertext)
+	assert.Regexp(t, `^file is encrypted; set OPENSHIFT_INSTALL_ENCRYPTION_PASSPHRASE, or OPENSHIFT_INSTALL_ENCRYPTION_PGP_KEYRING, to decrypt it$`, err)
+
+	data, err = decrypt(c, "file", ciphertext)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("data"), data)
+}
+
+func TestStoreWithCipher(t *testing.T) {
+	clearAssetBehaviors()
+	c := NewPassphraseCipher("secret")
+
+	tempDir, err := ioutil.TempDir("", "TestStoreWithCipher")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	store, err := newStore(tempDir, WithCipher(c))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.NoError(t, store.Fetch(&testStoreAssetA{}))
+	data, err := ioutil.ReadFile(filepath.Join(tempDir, stateFileName))
+	assert.NoError(t, err)
+	assert.True(t, isEncrypted(data), "the state file should be encrypted")
+
+	_, err = newStore(tempDir)
+	assert.Regexp(t, `^state file is encrypted; `, err)
+
+	store, err = newStore(tempDir, WithCipher(c))
+	assert.NoError(t, err)
+	assert.True(t, store.isAssetInState(&testStoreAssetA{}), "the state should be decrypted")
+}
+
+func TestPersistToFile(t *testing.T) {
+	c := NewPassphraseCipher("secret")
+
+	tempDir, err := ioutil.TempDir("", "TestPersistToFile")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	journal := &tls.JournalCertKey{}
+	journal.FileList = []*asset.File{
+		{Filename: "tls/journal-gatewayd.key", Data: []byte("key")},
+		{Filename: "tls/journal-gatewayd.crt", Data: []byte("crt")},
+	}
+	kubeadminPassword := &password.KubeadminPassword{
+		File: &asset.File{Filename: "auth/kubeadmin-password", Data: []byte("password")},
+	}
+	for _, a := range []asset.WritableAsset{journal, kubeadminPassword} {
+		if err := PersistToFile(a, tempDir, c); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for name, encrypted := range map[string]bool{
+		"tls/journal-gatewayd.key": true,
+		"tls/journal-gatewayd.crt": false,
+		"auth/kubeadmin-password":  true,
+	} {
+		isEncryptedFile, err := IsEncryptedFile(filepath.Join(tempDir, name))
+		assert.NoError(t, err)
+		assert.Equal(t, encrypted, isEncryptedFile, "unexpected encryption of %s", name)
+	}
+
+	fetcher := &fileFetcher{directory: tempDir, cipher: c}
+	f, err := fetcher.FetchByName("auth/kubeadmin-password")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("password"), f.Data, "the fetched file should be decrypted")
+}
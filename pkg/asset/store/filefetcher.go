@@ -1,7 +1,7 @@
 package store
 
 import (
-	"io/ioutil"
+	"os"
 	"path/filepath"
 
 	"github.com/openshift/installer/pkg/asset"
@@ -9,11 +9,12 @@ import (
 
 type fileFetcher struct {
 	directory string
+	cipher    Cipher
 }
 
 // FetchByName returns the file with the given name.
 func (f *fileFetcher) FetchByName(name string) (*asset.File, error) {
-	data, err := ioutil.ReadFile(filepath.Join(f.directory, name))
+	data, err := ReadFile(filepath.Join(f.directory, name), f.cipher)
 	if err != nil {
 		return nil, err
 	}
@@ -29,7 +30,7 @@ func (f *fileFetcher) FetchByPattern(pattern string) (files []*asset.File, err e
 
 	files = make([]*asset.File, 0, len(matches))
 	for _, path := range matches {
-		data, err := ioutil.ReadFile(path)
+		data, err := ReadFile(path, f.cipher)
 		if err != nil {
 			return nil, err
 		}
@@ -47,3 +48,38 @@ func (f *fileFetcher) FetchByPattern(pattern string) (files []*asset.File, err e
 
 	return files, nil
 }
+
+// FetchByDirectory returns the files in the given directory and its subdirectories.
+func (f *fileFetcher) FetchByDirectory(directory string) (files []*asset.File, err error) {
+	err = filepath.Walk(filepath.Join(f.directory, directory), func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+
+		data, err := ReadFile(path, f.cipher)
+		if err != nil {
+			return err
+		}
+
+		filename, err := filepath.Rel(f.directory, path)
+		if err != nil {
+			return err
+		}
+
+		files = append(files, &asset.File{
+			Filename: filename,
+			Data:     data,
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return files, nil
+}
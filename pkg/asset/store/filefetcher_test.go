@@ -174,3 +174,67 @@ func TestFetchByPattern(t *testing.T) {
 		})
 	}
 }
+
+func TestFetchByDirectory(t *testing.T) {
+	tempDir, err := ioutil.TempDir("", "openshift-install-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	files := map[string][]byte{
+		"overlay/a":       []byte("some data 0"),
+		"overlay/b/c/d":   []byte("some data 1"),
+		"overlay/b/e":     []byte("some data 2"),
+		"overlay-other/f": []byte("some data 3"),
+	}
+	for path, data := range files {
+		if err := os.MkdirAll(filepath.Join(tempDir, filepath.Dir(path)), 0777); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(filepath.Join(tempDir, path), data, 0666); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.MkdirAll(filepath.Join(tempDir, "overlay", "empty"), 0777); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		input       string
+		expectFiles []*asset.File
+	}{
+		{
+			input: "overlay",
+			expectFiles: []*asset.File{
+				{
+					Filename: "overlay/a",
+					Data:     []byte("some data 0"),
+				},
+				{
+					Filename: "overlay/b/c/d",
+					Data:     []byte("some data 1"),
+				},
+				{
+					Filename: "overlay/b/e",
+					Data:     []byte("some data 2"),
+				},
+			},
+		},
+		{
+			input: "missing",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			f := &fileFetcher{directory: tempDir}
+			files, err := f.FetchByDirectory(tt.input)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, tt.expectFiles, files)
+		})
+	}
+}
@@ -0,0 +1,202 @@
+package store
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	lockFileName = ".openshift_install.lock"
+
+	// lockStaleAfter is how long a lock held by a process on another host
+	// is honored. The liveness of processes on other hosts cannot be
+	// checked, so their locks are considered stale once they are older than
+	// the longest expected installer run.
+	lockStaleAfter = 24 * time.Hour
+)
+
+// LockInfo describes the holder of a lock on an install directory.
+type LockInfo struct {
+	// ID uniquely identifies the lock.
+	ID string `json:"id"`
+	// PID is the process ID of the holder.
+	PID int `json:"pid"`
+	// Hostname is the host of the holder.
+	Hostname string `json:"hostname"`
+	// Command is the installer command run by the holder.
+	Command string `json:"command,omitempty"`
+	// Created is when the lock was acquired.
+	Created time.Time `json:"created"`
+}
+
+// String describes the holder of the lock.
+func (i *LockInfo) String() string {
+	return fmt.Sprintf("%q (pid %d on %s, since %s)", i.Command, i.PID, i.Hostname, i.Created.Format(time.RFC3339))
+}
+
+// stale returns whether the holder of the lock is gone.
+func (i *LockInfo) stale(hostname string, now time.Time) bool {
+	if i.Hostname == hostname {
+		return !processExists(i.PID)
+	}
+	return now.Sub(i.Created) > lockStaleAfter
+}
+
+// LockHeldError is returned when the install directory is locked by
+// another process.
+type LockHeldError struct {
+	Holder *LockInfo
+}
+
+func (e *LockHeldError) Error() string {
+	return fmt.Sprintf("the install directory is locked by %s; if that process is no longer running, use --force-unlock to remove the lock", e.Holder)
+}
+
+// Lock is an advisory lock on an install directory, preventing installer
+// processes from modifying the same install directory concurrently. The lock
+// is created with a conditional write, so it is only best-effort on
+// S3-compatible stores that do not support conditional writes: when two
+// processes acquire the lock at the same time, both may succeed.
+type Lock struct {
+	backend Backend
+	info    LockInfo
+}
+
+// exclusiveWriter is implemented by backends that can create a file only
+// if it does not exist yet.
+type exclusiveWriter interface {
+	// WriteExclusive creates the named file. If the file exists, the
+	// returned error satisfies os.IsExist.
+	WriteExclusive(name string, data []byte) error
+}
+
+// AcquireLock locks the install directory stored in the backend on behalf
+// of the command. If the directory is locked by another process which is
+// still running, a *LockHeldError is returned. Stale locks are replaced.
+func AcquireLock(backend Backend, command string) (*Lock, error) {
+	hostname, err := os.Hostname()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get hostname")
+	}
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return nil, errors.Wrap(err, "failed to generate lock ID")
+	}
+	lock := &Lock{
+		backend: backend,
+		info: LockInfo{
+			ID:       hex.EncodeToString(id),
+			PID:      os.Getpid(),
+			Hostname: hostname,
+			Command:  command,
+			Created:  time.Now().UTC(),
+		},
+	}
+	data, err := json.Marshal(lock.info)
+	if err != nil {
+		return nil, err
+	}
+
+	holder, err := readLock(backend)
+	if err != nil {
+		return nil, err
+	}
+	if holder != nil {
+		if !holder.stale(hostname, time.Now()) {
+			return nil, &LockHeldError{Holder: holder}
+		}
+		logrus.Warnf("Removing stale lock on the install directory held by %s", holder)
+		if err := backend.Delete(lockFileName); err != nil {
+			return nil, errors.Wrap(err, "failed to remove stale lock")
+		}
+	}
+
+	w, ok := backend.(exclusiveWriter)
+	if !ok {
+		return nil, errors.New("the store does not support locking")
+	}
+	if err := w.WriteExclusive(lockFileName, data); err != nil {
+		if os.IsExist(err) {
+			return nil, lock.heldError()
+		}
+		return nil, errors.Wrap(err, "failed to write lock")
+	}
+	return lock, nil
+}
+
+// heldError returns the error for losing the race to acquire the lock.
+func (l *Lock) heldError() error {
+	holder, err := readLock(l.backend)
+	if err != nil || holder == nil {
+		return errors.New("the install directory was locked concurrently by another process")
+	}
+	return &LockHeldError{Holder: holder}
+}
+
+// Release unlocks the install directory, unless the lock has been taken
+// over by another process.
+func (l *Lock) Release() error {
+	holder, err := readLock(l.backend)
+	if err != nil {
+		return err
+	}
+	if holder == nil || holder.ID != l.info.ID {
+		logrus.Warn("The lock on the install directory was removed by another process")
+		return nil
+	}
+	return errors.Wrap(l.backend.Delete(lockFileName), "failed to remove lock")
+}
+
+// ForceUnlock removes the lock on the install directory stored in the
+// backend, regardless of its holder. It returns the removed lock, if any.
+func ForceUnlock(backend Backend) (*LockInfo, error) {
+	holder, err := readLock(backend)
+	if err != nil {
+		return nil, err
+	}
+	if holder == nil {
+		return nil, nil
+	}
+	return holder, errors.Wrap(backend.Delete(lockFileName), "failed to remove lock")
+}
+
+// readLock returns the lock stored in the backend, or nil if there is none.
+func readLock(backend Backend) (*LockInfo, error) {
+	data, err := backend.Read(lockFileName)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "failed to read lock")
+	}
+	info := &LockInfo{}
+	if err := json.Unmarshal(data, info); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse lock %s", lockFileName)
+	}
+	return info, nil
+}
+
+// WriteExclusive creates the named file, failing if it exists.
+func (b *localBackend) WriteExclusive(name string, data []byte) error {
+	path := filepath.Join(b.directory, filepath.FromSlash(name))
+	if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0640)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,156 @@
+package store
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAcquireLock(t *testing.T) {
+	hostname, err := os.Hostname()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		name     string
+		existing *LockInfo
+		err      string
+	}{{
+		name: "unlocked",
+	}, {
+		name:     "held by a running process",
+		existing: &LockInfo{ID: "other", PID: os.Getpid(), Hostname: hostname, Command: "openshift-install wait-for", Created: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)},
+		err:      `^the install directory is locked by "openshift-install wait-for" \(pid \d+ on .*, since 2021-01-01T00:00:00Z\); if that process is no longer running, use --force-unlock to remove the lock$`,
+	}, {
+		name:     "held by an exited process",
+		existing: &LockInfo{ID: "other", PID: 0x7ffffff0, Hostname: hostname, Created: time.Now()},
+	}, {
+		name:     "recently held on another host",
+		existing: &LockInfo{ID: "other", PID: 1, Hostname: "other-" + hostname, Created: time.Now()},
+		err:      `^the install directory is locked by `,
+	}, {
+		name:     "long held on another host",
+		existing: &LockInfo{ID: "other", PID: 1, Hostname: "other-" + hostname, Created: time.Now().Add(-2 * lockStaleAfter)},
+	}}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "TestAcquireLock")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.RemoveAll(dir)
+			backend := &localBackend{directory: dir}
+			if tc.existing != nil {
+				data, err := json.Marshal(tc.existing)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if err := backend.Write(lockFileName, data); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			lock, err := AcquireLock(backend, "openshift-install create cluster")
+			if tc.err != "" {
+				assert.Regexp(t, tc.err, err)
+				assert.IsType(t, &LockHeldError{}, err)
+				return
+			}
+			if !assert.NoError(t, err) {
+				return
+			}
+			holder, err := readLock(backend)
+			assert.NoError(t, err)
+			assert.Equal(t, &lock.info, holder)
+
+			_, err = AcquireLock(backend, "openshift-install destroy cluster")
+			assert.IsType(t, &LockHeldError{}, err, "the lock should not be acquired twice")
+
+			assert.NoError(t, lock.Release())
+			holder, err = readLock(backend)
+			assert.NoError(t, err)
+			assert.Nil(t, holder)
+		})
+	}
+}
+
+func TestAcquireLockS3(t *testing.T) {
+	_, backend := newFakeS3Backend(t)
+
+	lock, err := AcquireLock(backend, "openshift-install create cluster")
+	if !assert.NoError(t, err) {
+		return
+	}
+	_, err = AcquireLock(backend, "openshift-install destroy cluster")
+	assert.IsType(t, &LockHeldError{}, err)
+	assert.NoError(t, lock.Release())
+}
+
+func TestS3WriteExclusive(t *testing.T) {
+	cases := []struct {
+		name             string
+		ignoreConditions bool
+		existing         []byte
+		err              bool
+	}{{
+		name: "new",
+	}, {
+		name:     "existing",
+		existing: []byte("other"),
+		err:      true,
+	}, {
+		name:             "new without conditional writes",
+		ignoreConditions: true,
+	}}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			fake, backend := newFakeS3Backend(t)
+			fake.ignoreConditions = tc.ignoreConditions
+			if tc.existing != nil {
+				fake.objects["cluster/"+lockFileName] = tc.existing
+			}
+
+			err := backend.(exclusiveWriter).WriteExclusive(lockFileName, []byte("lock"))
+			if tc.err {
+				assert.True(t, os.IsExist(err), "expected exist error, got %v", err)
+				assert.Equal(t, tc.existing, fake.objects["cluster/"+lockFileName])
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, []byte("lock"), fake.objects["cluster/"+lockFileName])
+		})
+	}
+}
+
+func TestForceUnlock(t *testing.T) {
+	dir, err := ioutil.TempDir("", "TestForceUnlock")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	backend := &localBackend{directory: dir}
+
+	holder, err := ForceUnlock(backend)
+	assert.NoError(t, err)
+	assert.Nil(t, holder)
+
+	lock, err := AcquireLock(backend, "openshift-install wait-for install-complete")
+	if err != nil {
+		t.Fatal(err)
+	}
+	holder, err = ForceUnlock(backend)
+	assert.NoError(t, err)
+	assert.Equal(t, &lock.info, holder)
+
+	other, err := AcquireLock(backend, "openshift-install destroy cluster")
+	assert.NoError(t, err)
+	assert.NoError(t, lock.Release(), "releasing a lock taken over by another process should not fail")
+	holder, err = readLock(backend)
+	assert.NoError(t, err)
+	assert.Equal(t, &other.info, holder, "releasing a lock taken over by another process should not remove it")
+}
@@ -0,0 +1,27 @@
+//go:build windows
+// +build windows
+
+package store
+
+import (
+	"golang.org/x/sys/windows"
+)
+
+// stillActive is the exit code of a process that has not exited yet.
+const stillActive = 259
+
+// processExists returns whether a process with the given ID is running.
+func processExists(pid int) bool {
+	handle, err := windows.OpenProcess(windows.PROCESS_QUERY_LIMITED_INFORMATION, false, uint32(pid))
+	if err != nil {
+		// The process exists, but belongs to another user.
+		return err == windows.ERROR_ACCESS_DENIED
+	}
+	defer windows.CloseHandle(handle)
+
+	var exitCode uint32
+	if err := windows.GetExitCodeProcess(handle, &exitCode); err != nil {
+		return true
+	}
+	return exitCode == stillActive
+}
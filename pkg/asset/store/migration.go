@@ -0,0 +1,184 @@
+package store
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"github.com/openshift/installer/pkg/version"
+)
+
+const (
+	// legacyStateVersion is the schema version of state files written
+	// before the state file was versioned, which hold a flat map of the
+	// assets.
+	legacyStateVersion = 0
+
+	// CurrentStateVersion is the schema version of the state files written
+	// by this installer.
+	CurrentStateVersion = 1
+)
+
+// stateFile is the versioned envelope of the state file.
+type stateFile struct {
+	// SchemaVersion is the schema version of the assets.
+	SchemaVersion int `json:"schemaVersion"`
+	// InstallerVersion is the version of the installer that wrote the
+	// state file.
+	InstallerVersion string `json:"installerVersion"`
+	// Assets maps state file keys (the Go type names of the assets) to the
+	// state of the assets.
+	Assets map[string]json.RawMessage `json:"assets"`
+}
+
+// MigrateFunc upgrades the state of an asset to the next schema version. It
+// returns the state file key of the asset, which differs from the original
+// key when the asset type was renamed, and the upgraded state. A nil state
+// removes the asset from the state file.
+type MigrateFunc func(data json.RawMessage) (key string, migrated json.RawMessage, err error)
+
+// migrations maps schema versions to the migrations, by state file key, that
+// upgrade the assets from the previous schema version to that version.
+var migrations = map[int]map[string]MigrateFunc{}
+
+// RegisterMigration registers a migration upgrading the state of the asset
+// with the given state file key to the given schema version. Migrations are
+// expected to be registered by init functions, when an asset is renamed or
+// the structure of its state is changed along with CurrentStateVersion.
+func RegisterMigration(version int, key string, migrate MigrateFunc) {
+	if version <= legacyStateVersion {
+		panic(fmt.Sprintf("invalid migration version %d for %q", version, key))
+	}
+	if migrations[version] == nil {
+		migrations[version] = map[string]MigrateFunc{}
+	}
+	if _, ok := migrations[version][key]; ok {
+		panic(fmt.Sprintf("duplicate migration to version %d for %q", version, key))
+	}
+	migrations[version][key] = migrate
+}
+
+// parseStateFile parses the contents of a state file of any schema version.
+func parseStateFile(data []byte) (*stateFile, error) {
+	raw := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+	if _, ok := raw["schemaVersion"]; !ok {
+		return &stateFile{SchemaVersion: legacyStateVersion, Assets: raw}, nil
+	}
+
+	state := &stateFile{}
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, err
+	}
+	if state.Assets == nil {
+		state.Assets = map[string]json.RawMessage{}
+	}
+	return state, nil
+}
+
+// migrateStateFile upgrades the assets of the state file to
+// CurrentStateVersion.
+func migrateStateFile(state *stateFile) error {
+	if state.SchemaVersion > CurrentStateVersion {
+		return errors.Errorf("state file schema version %d was written by installer %s and is newer than the supported version %d; use a newer installer",
+			state.SchemaVersion, state.InstallerVersion, CurrentStateVersion)
+	}
+	for v := state.SchemaVersion + 1; v <= CurrentStateVersion; v++ {
+		keys := make([]string, 0, len(migrations[v]))
+		for key := range migrations[v] {
+			if _, ok := state.Assets[key]; ok {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			newKey, migrated, err := migrations[v][key](state.Assets[key])
+			if err != nil {
+				return errors.Wrapf(err, "failed to migrate %q to state file schema version %d", key, v)
+			}
+			logrus.Debugf("Migrated %q to state file schema version %d", key, v)
+			delete(state.Assets, key)
+			if migrated != nil {
+				state.Assets[newKey] = migrated
+			}
+		}
+		state.SchemaVersion = v
+	}
+	return nil
+}
+
+// newStateFile returns the envelope for the assets at CurrentStateVersion.
+func newStateFile(assets map[string]json.RawMessage) *stateFile {
+	return &stateFile{
+		SchemaVersion:    CurrentStateVersion,
+		InstallerVersion: version.Raw,
+		Assets:           assets,
+	}
+}
+
+// StateMigration describes the upgrade of a state file by MigrateState.
+type StateMigration struct {
+	// From is the schema version of the original state file.
+	From int
+	// To is the schema version of the upgraded state file.
+	To int
+	// Backup is the name of the backup of the original state file.
+	Backup string
+}
+
+// MigrateState upgrades the state file of the asset store in dir to
+// CurrentStateVersion in place, after writing a backup of the original
+// state file next to it. If there is no state file or it is already at
+// CurrentStateVersion, nothing is changed and a nil migration is returned.
+func MigrateState(dir string, opts ...Option) (*StateMigration, error) {
+	s, err := newStore(dir, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if s.stateFileVersion == CurrentStateVersion || s.stateFileAssets == nil {
+		return nil, nil
+	}
+
+	migration := &StateMigration{
+		From:   s.stateFileVersion,
+		To:     CurrentStateVersion,
+		Backup: stateFileBackupName(s.stateFileVersion),
+	}
+	if err := s.saveStateFile(); err != nil {
+		return nil, errors.Wrap(err, "failed to save state")
+	}
+	return migration, nil
+}
+
+// stateFileBackupName returns the name of the backup of a state file of the
+// given schema version.
+func stateFileBackupName(version int) string {
+	return fmt.Sprintf("%s.v%d.backup", stateFileName, version)
+}
+
+// backUpStateFile writes a backup of the state file next to it, before it is
+// first overwritten with the assets migrated from an older schema version.
+func (s *storeImpl) backUpStateFile() error {
+	if s.stateFileVersion == CurrentStateVersion {
+		return nil
+	}
+	original, err := s.backend.Read(stateFileName)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if err == nil {
+		backup := stateFileBackupName(s.stateFileVersion)
+		if err := s.backend.Write(backup, original); err != nil {
+			return errors.Wrap(err, "failed to back up state file")
+		}
+		logrus.Infof("Backed up the state file of schema version %d to %s before upgrading it to version %d", s.stateFileVersion, backup, CurrentStateVersion)
+	}
+	s.stateFileVersion = CurrentStateVersion
+	return nil
+}
@@ -0,0 +1,222 @@
+package store
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseStateFile(t *testing.T) {
+	cases := []struct {
+		name            string
+		data            string
+		expectedVersion int
+		expectedAssets  map[string]json.RawMessage
+		expectedError   bool
+	}{
+		{
+			name:            "legacy",
+			data:            `{"*installconfig.InstallConfig":{"a":1}}`,
+			expectedVersion: legacyStateVersion,
+			expectedAssets:  map[string]json.RawMessage{"*installconfig.InstallConfig": json.RawMessage(`{"a":1}`)},
+		},
+		{
+			name:            "legacy empty",
+			data:            `{}`,
+			expectedVersion: legacyStateVersion,
+			expectedAssets:  map[string]json.RawMessage{},
+		},
+		{
+			name:            "versioned",
+			data:            `{"schemaVersion":1,"installerVersion":"v4.10.0","assets":{"*installconfig.InstallConfig":{"a":1}}}`,
+			expectedVersion: 1,
+			expectedAssets:  map[string]json.RawMessage{"*installconfig.InstallConfig": json.RawMessage(`{"a":1}`)},
+		},
+		{
+			name:            "versioned without assets",
+			data:            `{"schemaVersion":1}`,
+			expectedVersion: 1,
+			expectedAssets:  map[string]json.RawMessage{},
+		},
+		{
+			name:          "invalid",
+			data:          `[]`,
+			expectedError: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			state, err := parseStateFile([]byte(tc.data))
+			if tc.expectedError {
+				assert.Error(t, err)
+				return
+			}
+			if assert.NoError(t, err) {
+				assert.Equal(t, tc.expectedVersion, state.SchemaVersion)
+				assert.Equal(t, tc.expectedAssets, state.Assets)
+			}
+		})
+	}
+}
+
+func TestMigrateStateFile(t *testing.T) {
+	defer func(m map[int]map[string]MigrateFunc) { migrations = m }(migrations)
+	migrations = map[int]map[string]MigrateFunc{}
+	RegisterMigration(CurrentStateVersion, "*old.Renamed", func(data json.RawMessage) (string, json.RawMessage, error) {
+		return "*new.Renamed", data, nil
+	})
+	RegisterMigration(CurrentStateVersion, "*old.Removed", func(data json.RawMessage) (string, json.RawMessage, error) {
+		return "*old.Removed", nil, nil
+	})
+	RegisterMigration(CurrentStateVersion, "*old.Broken", func(data json.RawMessage) (string, json.RawMessage, error) {
+		return "", nil, errors.New("broken")
+	})
+
+	state := &stateFile{
+		SchemaVersion: legacyStateVersion,
+		Assets: map[string]json.RawMessage{
+			"*old.Renamed": json.RawMessage(`"renamed"`),
+			"*old.Removed": json.RawMessage(`"removed"`),
+			"*old.Kept":    json.RawMessage(`"kept"`),
+		},
+	}
+	if assert.NoError(t, migrateStateFile(state)) {
+		assert.Equal(t, CurrentStateVersion, state.SchemaVersion)
+		assert.Equal(t, map[string]json.RawMessage{
+			"*new.Renamed": json.RawMessage(`"renamed"`),
+			"*old.Kept":    json.RawMessage(`"kept"`),
+		}, state.Assets)
+	}
+
+	state = &stateFile{
+		SchemaVersion: legacyStateVersion,
+		Assets:        map[string]json.RawMessage{"*old.Broken": json.RawMessage(`{}`)},
+	}
+	assert.EqualError(t, migrateStateFile(state), `failed to migrate "*old.Broken" to state file schema version 1: broken`)
+
+	state = &stateFile{SchemaVersion: CurrentStateVersion + 1, InstallerVersion: "v9.9.9"}
+	assert.EqualError(t, migrateStateFile(state), "state file schema version 2 was written by installer v9.9.9 and is newer than the supported version 1; use a newer installer")
+
+	assert.Panics(t, func() {
+		RegisterMigration(CurrentStateVersion, "*old.Kept", nil)
+		RegisterMigration(CurrentStateVersion, "*old.Kept", nil)
+	})
+	assert.Panics(t, func() { RegisterMigration(legacyStateVersion, "*old.Kept", nil) })
+}
+
+func TestMigrateState(t *testing.T) {
+	dir, err := ioutil.TempDir("", "TestMigrateState")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	migration, err := MigrateState(dir)
+	assert.NoError(t, err)
+	assert.Nil(t, migration, "nothing to migrate without a state file")
+
+	legacy := []byte(`{"*store.a":{"a":"a"}}`)
+	if err := ioutil.WriteFile(filepath.Join(dir, stateFileName), legacy, 0640); err != nil {
+		t.Fatal(err)
+	}
+
+	migration, err = MigrateState(dir)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, &StateMigration{From: legacyStateVersion, To: CurrentStateVersion, Backup: stateFileName + ".v0.backup"}, migration)
+
+	backup, err := ioutil.ReadFile(filepath.Join(dir, migration.Backup))
+	assert.NoError(t, err)
+	assert.Equal(t, legacy, backup)
+
+	data, err := ioutil.ReadFile(filepath.Join(dir, stateFileName))
+	assert.NoError(t, err)
+	state, err := parseStateFile(data)
+	if assert.NoError(t, err) {
+		assert.Equal(t, CurrentStateVersion, state.SchemaVersion)
+		assert.Equal(t, map[string]json.RawMessage{"*store.a": json.RawMessage(`{"a":"a"}`)}, compactAssets(t, state.Assets))
+	}
+
+	migration, err = MigrateState(dir)
+	assert.NoError(t, err)
+	assert.Nil(t, migration, "nothing to migrate at the current version")
+}
+
+func TestMigrateStateWithBackend(t *testing.T) {
+	fake, backend := newFakeS3Backend(t)
+
+	dir, err := ioutil.TempDir("", "TestMigrateStateWithBackend")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	legacy := []byte(`{"*store.a":{"a":"a"}}`)
+	assert.NoError(t, backend.Write(stateFileName, legacy))
+
+	migration, err := MigrateState(dir, WithBackend(backend))
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.NoError(t, Push(backend, dir))
+
+	backup, err := backend.Read(migration.Backup)
+	assert.NoError(t, err, "the backup of the state file should not be deleted by Push")
+	assert.Equal(t, legacy, backup)
+	assert.Contains(t, fake.objects, "cluster/"+stateFileName)
+}
+
+func compactAssets(t *testing.T, assets map[string]json.RawMessage) map[string]json.RawMessage {
+	compacted := make(map[string]json.RawMessage, len(assets))
+	for k, v := range assets {
+		data, err := json.Marshal(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		compacted[k] = data
+	}
+	return compacted
+}
+
+func TestSaveMigratedState(t *testing.T) {
+	dir, err := ioutil.TempDir("", "TestSaveMigratedState")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	legacy := []byte(`{"*store.a":{"a":"a"}}`)
+	if err := ioutil.WriteFile(filepath.Join(dir, stateFileName), legacy, 0640); err != nil {
+		t.Fatal(err)
+	}
+
+	s, err := newStore(dir)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.NoError(t, s.saveStateFile())
+
+	backupPath := filepath.Join(dir, stateFileName+".v0.backup")
+	backup, err := ioutil.ReadFile(backupPath)
+	assert.NoError(t, err, "the state file should be backed up before it is first saved")
+	assert.Equal(t, legacy, backup)
+
+	// later saves do not overwrite the backup
+	assert.NoError(t, os.Remove(backupPath))
+	assert.NoError(t, s.saveStateFile())
+	_, err = os.Stat(backupPath)
+	assert.True(t, os.IsNotExist(err))
+
+	data, err := ioutil.ReadFile(filepath.Join(dir, stateFileName))
+	assert.NoError(t, err)
+	state, err := parseStateFile(data)
+	if assert.NoError(t, err) {
+		assert.Equal(t, CurrentStateVersion, state.SchemaVersion)
+	}
+}
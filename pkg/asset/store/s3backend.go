@@ -0,0 +1,183 @@
+package store
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/pkg/errors"
+)
+
+// s3Backend stores files as objects in a bucket of an S3-compatible object
+// store, under a common key prefix.
+type s3Backend struct {
+	client *s3.S3
+	bucket string
+	prefix string
+}
+
+// newS3Backend returns a Backend for a location of the form
+// s3://bucket/prefix. The region and endpoint query parameters override the
+// region and endpoint of the object store, e.g.
+// s3://bucket/prefix?region=us-east-1&endpoint=http://127.0.0.1:9000 for
+// an S3-compatible store other than AWS. Credentials are taken from the
+// usual AWS configuration (AWS_PROFILE, AWS_ACCESS_KEY_ID, etc.).
+func newS3Backend(location *url.URL) (*s3Backend, error) {
+	if location.Host == "" {
+		return nil, errors.Errorf("store location %q does not specify a bucket", location)
+	}
+
+	config := aws.NewConfig()
+	query := location.Query()
+	if region := query.Get("region"); region != "" {
+		config = config.WithRegion(region)
+	}
+	if endpoint := query.Get("endpoint"); endpoint != "" {
+		// S3-compatible stores generally do not support virtual-hosted-style
+		// bucket addressing.
+		config = config.WithEndpoint(endpoint).WithS3ForcePathStyle(true)
+	}
+
+	awsSession, err := session.NewSessionWithOptions(session.Options{
+		Config:            *config,
+		SharedConfigState: session.SharedConfigEnable,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create AWS session")
+	}
+	if aws.StringValue(awsSession.Config.Region) == "" {
+		awsSession.Config.Region = aws.String("us-east-1")
+	}
+
+	return &s3Backend{
+		client: s3.New(awsSession),
+		bucket: location.Host,
+		prefix: strings.Trim(location.Path, "/"),
+	}, nil
+}
+
+func (b *s3Backend) key(name string) string {
+	return path.Join(b.prefix, name)
+}
+
+// Read returns the contents of the named file.
+func (b *s3Backend) Read(name string) ([]byte, error) {
+	output, err := b.client.GetObject(&s3.GetObjectInput{
+		Bucket: aws.String(b.bucket),
+		Key:    aws.String(b.key(name)),
+	})
+	if err != nil {
+		if isS3NotFound(err) {
+			return nil, &os.PathError{Op: "read", Path: b.key(name), Err: os.ErrNotExist}
+		}
+		return nil, errors.Wrapf(err, "failed to get s3://%s/%s", b.bucket, b.key(name))
+	}
+	defer output.Body.Close()
+	return ioutil.ReadAll(output.Body)
+}
+
+// Write replaces the contents of the named file.
+func (b *s3Backend) Write(name string, data []byte) error {
+	_, err := b.client.PutObject(&s3.PutObjectInput{
+		Bucket: aws.String(b.bucket),
+		Key:    aws.String(b.key(name)),
+		Body:   bytes.NewReader(data),
+	})
+	return errors.Wrapf(err, "failed to put s3://%s/%s", b.bucket, b.key(name))
+}
+
+// WriteExclusive creates the named file, failing if it exists. The object is
+// put with If-None-Match: *, which S3 rejects when the object exists. Stores
+// that ignore the condition overwrite the object instead, so the object is
+// read back to detect at least the writers that raced ahead of this one.
+func (b *s3Backend) WriteExclusive(name string, data []byte) error {
+	req, _ := b.client.PutObjectRequest(&s3.PutObjectInput{
+		Bucket: aws.String(b.bucket),
+		Key:    aws.String(b.key(name)),
+		Body:   bytes.NewReader(data),
+	})
+	req.HTTPRequest.Header.Set("If-None-Match", "*")
+	if err := req.Send(); err != nil {
+		if isS3PreconditionFailed(err) {
+			return &os.PathError{Op: "write", Path: b.key(name), Err: os.ErrExist}
+		}
+		return errors.Wrapf(err, "failed to put s3://%s/%s", b.bucket, b.key(name))
+	}
+
+	written, err := b.Read(name)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(written, data) {
+		return &os.PathError{Op: "write", Path: b.key(name), Err: os.ErrExist}
+	}
+	return nil
+}
+
+// Delete removes the named file.
+func (b *s3Backend) Delete(name string) error {
+	_, err := b.client.DeleteObject(&s3.DeleteObjectInput{
+		Bucket: aws.String(b.bucket),
+		Key:    aws.String(b.key(name)),
+	})
+	if err != nil && !isS3NotFound(err) {
+		return errors.Wrapf(err, "failed to delete s3://%s/%s", b.bucket, b.key(name))
+	}
+	return nil
+}
+
+// List returns the names of all of the files under the prefix.
+func (b *s3Backend) List() ([]string, error) {
+	prefix := b.prefix
+	if prefix != "" {
+		prefix += "/"
+	}
+	var names []string
+	err := b.client.ListObjectsV2Pages(
+		&s3.ListObjectsV2Input{
+			Bucket: aws.String(b.bucket),
+			Prefix: aws.String(prefix),
+		},
+		func(results *s3.ListObjectsV2Output, lastPage bool) bool {
+			for _, object := range results.Contents {
+				names = append(names, strings.TrimPrefix(aws.StringValue(object.Key), prefix))
+			}
+			return !lastPage
+		},
+	)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list s3://%s/%s", b.bucket, prefix)
+	}
+	return names, nil
+}
+
+func isS3NotFound(err error) bool {
+	var awsErr awserr.Error
+	if errors.As(err, &awsErr) {
+		switch awsErr.Code() {
+		case s3.ErrCodeNoSuchKey, "NotFound":
+			return true
+		}
+	}
+	return false
+}
+
+// isS3PreconditionFailed returns whether a conditional request failed
+// because the object exists, or is being written concurrently.
+func isS3PreconditionFailed(err error) bool {
+	var awsErr awserr.Error
+	if errors.As(err, &awsErr) {
+		switch awsErr.Code() {
+		case "PreconditionFailed", "ConditionalRequestConflict":
+			return true
+		}
+	}
+	return false
+}
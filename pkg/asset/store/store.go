@@ -2,15 +2,14 @@ package store
 
 import (
 	"encoding/json"
-	"io/ioutil"
 	"os"
-	"path/filepath"
 	"reflect"
 
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 
 	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/events"
 )
 
 const (
@@ -49,20 +48,49 @@ type storeImpl struct {
 	directory       string
 	assets          map[reflect.Type]*assetState
 	stateFileAssets map[string]json.RawMessage
-	fileFetcher     asset.FileFetcher
+	// stateFileVersion is the schema version of the state file as it was
+	// loaded, before the assets were migrated to CurrentStateVersion. It is
+	// set to CurrentStateVersion once the original state file is backed up.
+	stateFileVersion int
+	fileFetcher      asset.FileFetcher
+	backend          Backend
+	cipher           Cipher
+}
+
+// Option configures an asset store.
+type Option func(*storeImpl)
+
+// WithBackend keeps the state file in the given backend rather than in the
+// directory.
+func WithBackend(backend Backend) Option {
+	return func(s *storeImpl) {
+		s.backend = backend
+	}
+}
+
+// WithCipher encrypts the state file with the given cipher. Encrypted files
+// in the directory are decrypted with the cipher when assets are loaded.
+func WithCipher(c Cipher) Option {
+	return func(s *storeImpl) {
+		s.cipher = c
+	}
 }
 
 // NewStore returns an asset store that implements the asset.Store interface.
-func NewStore(dir string) (asset.Store, error) {
-	return newStore(dir)
+func NewStore(dir string, opts ...Option) (asset.Store, error) {
+	return newStore(dir, opts...)
 }
 
-func newStore(dir string) (*storeImpl, error) {
+func newStore(dir string, opts ...Option) (*storeImpl, error) {
 	store := &storeImpl{
-		directory:   dir,
-		fileFetcher: &fileFetcher{directory: dir},
-		assets:      map[reflect.Type]*assetState{},
+		directory: dir,
+		assets:    map[reflect.Type]*assetState{},
+		backend:   &localBackend{directory: dir},
 	}
+	for _, opt := range opts {
+		opt(store)
+	}
+	store.fileFetcher = &fileFetcher{directory: dir, cipher: store.cipher}
 
 	if err := store.loadStateFile(); err != nil {
 		return nil, err
@@ -111,37 +139,36 @@ func (s *storeImpl) Destroy(a asset.Asset) error {
 	return s.saveStateFile()
 }
 
-// DestroyState removes the state file from disk
+// DestroyState removes the state file from the backend
 func (s *storeImpl) DestroyState() error {
 	s.stateFileAssets = nil
-	path := filepath.Join(s.directory, stateFileName)
-	err := os.Remove(path)
-	if err != nil {
-		if os.IsNotExist(err) {
-			return nil
-		}
-		return err
-	}
-	return nil
+	return s.backend.Delete(stateFileName)
 }
 
-// loadStateFile retrieves the state from the state file present in the given directory
+// loadStateFile retrieves the state from the state file present in the backend
 // and returns the assets map
 func (s *storeImpl) loadStateFile() error {
-	path := filepath.Join(s.directory, stateFileName)
-	assets := map[string]json.RawMessage{}
-	data, err := ioutil.ReadFile(path)
+	data, err := s.backend.Read(stateFileName)
 	if err != nil {
 		if os.IsNotExist(err) {
+			s.stateFileVersion = CurrentStateVersion
 			return nil
 		}
 		return err
 	}
-	err = json.Unmarshal(data, &assets)
+	data, err = decrypt(s.cipher, "state file", data)
+	if err != nil {
+		return err
+	}
+	state, err := parseStateFile(data)
 	if err != nil {
-		return errors.Wrapf(err, "failed to unmarshal state file %q", path)
+		return errors.Wrapf(err, "failed to unmarshal state file %q", stateFileName)
 	}
-	s.stateFileAssets = assets
+	s.stateFileVersion = state.SchemaVersion
+	if err := migrateStateFile(state); err != nil {
+		return err
+	}
+	s.stateFileAssets = state.Assets
 	return nil
 }
 
@@ -162,6 +189,9 @@ func (s *storeImpl) isAssetInState(a asset.Asset) bool {
 
 // saveStateFile dumps the entire state map into a file
 func (s *storeImpl) saveStateFile() error {
+	if err := s.backUpStateFile(); err != nil {
+		return err
+	}
 	if s.stateFileAssets == nil {
 		s.stateFileAssets = map[string]json.RawMessage{}
 	}
@@ -175,19 +205,17 @@ func (s *storeImpl) saveStateFile() error {
 		}
 		s.stateFileAssets[k.String()] = json.RawMessage(data)
 	}
-	data, err := json.MarshalIndent(s.stateFileAssets, "", "    ")
+	data, err := json.MarshalIndent(newStateFile(s.stateFileAssets), "", "    ")
 	if err != nil {
 		return err
 	}
 
-	path := filepath.Join(s.directory, stateFileName)
-	if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
-		return err
-	}
-	if err := ioutil.WriteFile(path, data, 0640); err != nil {
-		return err
+	if s.cipher != nil {
+		if data, err = s.cipher.Encrypt(data); err != nil {
+			return errors.Wrap(err, "failed to encrypt state file")
+		}
 	}
-	return nil
+	return s.backend.Write(stateFileName, data)
 }
 
 // fetch populates the given asset, generating it and its dependencies if
@@ -227,6 +255,7 @@ func (s *storeImpl) fetch(a asset.Asset, indent string) error {
 	if err := a.Generate(parents); err != nil {
 		return errors.Wrapf(err, "failed to generate asset %q", a.Name())
 	}
+	events.New(events.AssetGenerated, map[string]interface{}{"asset": a.Name()}).Tracef("%sGenerated %s", indent, a.Name())
 	assetState.asset = a
 	assetState.source = generatedSource
 	return nil
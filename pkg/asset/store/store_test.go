@@ -268,6 +268,7 @@ func TestStoreFetch(t *testing.T) {
 			store := &storeImpl{
 				directory: dir,
 				assets:    map[reflect.Type]*assetState{},
+				backend:   &localBackend{directory: dir},
 			}
 			assets := make(map[string]asset.Asset, len(tc.assets))
 			for name := range tc.assets {
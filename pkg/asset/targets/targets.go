@@ -21,6 +21,18 @@ var (
 		&installconfig.InstallConfig{},
 	}
 
+	// IAMPolicy are the iam-policy targeted assets. The install config is
+	// targeted so that it is not consumed.
+	IAMPolicy = []asset.WritableAsset{
+		&installconfig.InstallConfig{},
+		&installconfig.IAMPolicy{},
+	}
+
+	// SignerCSRs are the signer-csrs targeted assets.
+	SignerCSRs = []asset.WritableAsset{
+		&tls.SignerCSRs{},
+	}
+
 	// Manifests are the manifests targeted assets.
 	Manifests = []asset.WritableAsset{
 		&machines.Master{},
@@ -5,6 +5,7 @@ import (
 	"crypto/x509/pkix"
 
 	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/installconfig"
 )
 
 // AdminKubeConfigSignerCertKey is a key/cert pair that signs the admin kubeconfig client certs.
@@ -14,18 +15,22 @@ type AdminKubeConfigSignerCertKey struct {
 
 var _ asset.WritableAsset = (*AdminKubeConfigSignerCertKey)(nil)
 
-// Dependencies returns the dependency of the root-ca, which is empty.
+// Dependencies returns the dependency of the CA, which is the install config
+// for the key algorithm.
 func (c *AdminKubeConfigSignerCertKey) Dependencies() []asset.Asset {
-	return []asset.Asset{}
+	return []asset.Asset{
+		&installconfig.InstallConfig{},
+	}
 }
 
 // Generate generates the root-ca key and cert pair.
 func (c *AdminKubeConfigSignerCertKey) Generate(parents asset.Parents) error {
 	cfg := &CertCfg{
-		Subject:   pkix.Name{CommonName: "admin-kubeconfig-signer", OrganizationalUnit: []string{"openshift"}},
-		KeyUsages: x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
-		Validity:  ValidityTenYears,
-		IsCA:      true,
+		Subject:      pkix.Name{CommonName: "admin-kubeconfig-signer", OrganizationalUnit: []string{"openshift"}},
+		KeyUsages:    x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		Validity:     ValidityTenYears,
+		IsCA:         true,
+		KeyAlgorithm: keyAlgorithm(parents),
 	}
 
 	return c.SelfSignedCertKey.Generate(cfg, "admin-kubeconfig-signer")
@@ -36,6 +41,11 @@ func (c *AdminKubeConfigSignerCertKey) Name() string {
 	return "Certificate (admin-kubeconfig-signer)"
 }
 
+// Load loads the user-provided CA from the tls directory, if any.
+func (c *AdminKubeConfigSignerCertKey) Load(f asset.FileFetcher) (bool, error) {
+	return c.SelfSignedCertKey.Load(f, "admin-kubeconfig-signer")
+}
+
 // AdminKubeConfigCABundle is the asset the generates the admin-kubeconfig-ca-bundle,
 // which contains all the individual client CAs.
 type AdminKubeConfigCABundle struct {
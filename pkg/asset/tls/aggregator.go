@@ -5,6 +5,7 @@ import (
 	"crypto/x509/pkix"
 
 	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/installconfig"
 )
 
 // AggregatorCA is the asset that generates the aggregator-ca key/cert pair.
@@ -15,20 +16,22 @@ type AggregatorCA struct {
 
 var _ asset.Asset = (*AggregatorCA)(nil)
 
-// Dependencies returns the dependency of the the cert/key pair, which includes
-// the parent CA, and install config if it depends on the install config for
-// DNS names, etc.
+// Dependencies returns the dependency of the CA, which is the install config
+// for the key algorithm.
 func (a *AggregatorCA) Dependencies() []asset.Asset {
-	return []asset.Asset{}
+	return []asset.Asset{
+		&installconfig.InstallConfig{},
+	}
 }
 
 // Generate generates the cert/key pair based on its dependencies.
 func (a *AggregatorCA) Generate(dependencies asset.Parents) error {
 	cfg := &CertCfg{
-		Subject:   pkix.Name{CommonName: "aggregator", OrganizationalUnit: []string{"bootkube"}},
-		KeyUsages: x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
-		Validity:  ValidityOneDay,
-		IsCA:      true,
+		Subject:      pkix.Name{CommonName: "aggregator", OrganizationalUnit: []string{"bootkube"}},
+		KeyUsages:    x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		Validity:     ValidityOneDay,
+		IsCA:         true,
+		KeyAlgorithm: keyAlgorithm(dependencies),
 	}
 
 	return a.SelfSignedCertKey.Generate(cfg, "aggregator-ca")
@@ -39,6 +42,11 @@ func (a *AggregatorCA) Name() string {
 	return "Certificate (aggregator)"
 }
 
+// Load loads the user-provided CA from the tls directory, if any.
+func (a *AggregatorCA) Load(f asset.FileFetcher) (bool, error) {
+	return a.SelfSignedCertKey.Load(f, "aggregator-ca")
+}
+
 // APIServerProxyCertKey is the asset that generates the API server proxy key/cert pair.
 // [DEPRECATED]
 type APIServerProxyCertKey struct {
@@ -83,18 +91,22 @@ type AggregatorSignerCertKey struct {
 
 var _ asset.WritableAsset = (*AggregatorSignerCertKey)(nil)
 
-// Dependencies returns the dependency of the root-ca, which is empty.
+// Dependencies returns the dependency of the CA, which is the install config
+// for the key algorithm.
 func (c *AggregatorSignerCertKey) Dependencies() []asset.Asset {
-	return []asset.Asset{}
+	return []asset.Asset{
+		&installconfig.InstallConfig{},
+	}
 }
 
 // Generate generates the root-ca key and cert pair.
 func (c *AggregatorSignerCertKey) Generate(parents asset.Parents) error {
 	cfg := &CertCfg{
-		Subject:   pkix.Name{CommonName: "aggregator-signer", OrganizationalUnit: []string{"openshift"}},
-		KeyUsages: x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
-		Validity:  ValidityOneDay,
-		IsCA:      true,
+		Subject:      pkix.Name{CommonName: "aggregator-signer", OrganizationalUnit: []string{"openshift"}},
+		KeyUsages:    x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		Validity:     ValidityOneDay,
+		IsCA:         true,
+		KeyAlgorithm: keyAlgorithm(parents),
 	}
 
 	return c.SelfSignedCertKey.Generate(cfg, "aggregator-signer")
@@ -105,6 +117,11 @@ func (c *AggregatorSignerCertKey) Name() string {
 	return "Certificate (aggregator-signer)"
 }
 
+// Load loads the user-provided CA from the tls directory, if any.
+func (c *AggregatorSignerCertKey) Load(f asset.FileFetcher) (bool, error) {
+	return c.SelfSignedCertKey.Load(f, "aggregator-signer")
+}
+
 // AggregatorCABundle is the asset the generates the aggregator-ca-bundle,
 // which contains all the individual client CAs.
 type AggregatorCABundle struct {
@@ -17,18 +17,22 @@ type KubeAPIServerToKubeletSignerCertKey struct {
 
 var _ asset.WritableAsset = (*KubeAPIServerToKubeletSignerCertKey)(nil)
 
-// Dependencies returns the dependency of the root-ca, which is empty.
+// Dependencies returns the dependency of the CA, which is the install config
+// for the key algorithm.
 func (c *KubeAPIServerToKubeletSignerCertKey) Dependencies() []asset.Asset {
-	return []asset.Asset{}
+	return []asset.Asset{
+		&installconfig.InstallConfig{},
+	}
 }
 
 // Generate generates the root-ca key and cert pair.
 func (c *KubeAPIServerToKubeletSignerCertKey) Generate(parents asset.Parents) error {
 	cfg := &CertCfg{
-		Subject:   pkix.Name{CommonName: "kube-apiserver-to-kubelet-signer", OrganizationalUnit: []string{"openshift"}},
-		KeyUsages: x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
-		Validity:  ValidityOneYear,
-		IsCA:      true,
+		Subject:      pkix.Name{CommonName: "kube-apiserver-to-kubelet-signer", OrganizationalUnit: []string{"openshift"}},
+		KeyUsages:    x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		Validity:     ValidityOneYear,
+		IsCA:         true,
+		KeyAlgorithm: keyAlgorithm(parents),
 	}
 
 	return c.SelfSignedCertKey.Generate(cfg, "kube-apiserver-to-kubelet-signer")
@@ -39,6 +43,11 @@ func (c *KubeAPIServerToKubeletSignerCertKey) Name() string {
 	return "Certificate (kube-apiserver-to-kubelet-signer)"
 }
 
+// Load loads the user-provided CA from the tls directory, if any.
+func (c *KubeAPIServerToKubeletSignerCertKey) Load(f asset.FileFetcher) (bool, error) {
+	return c.SelfSignedCertKey.Load(f, "kube-apiserver-to-kubelet-signer")
+}
+
 // KubeAPIServerToKubeletCABundle is the asset the generates the kube-apiserver-to-kubelet-ca-bundle,
 // which contains all the individual client CAs.
 type KubeAPIServerToKubeletCABundle struct {
@@ -110,18 +119,22 @@ type KubeAPIServerLocalhostSignerCertKey struct {
 
 var _ asset.WritableAsset = (*KubeAPIServerLocalhostSignerCertKey)(nil)
 
-// Dependencies returns the dependency of the root-ca, which is empty.
+// Dependencies returns the dependency of the CA, which is the install config
+// for the key algorithm.
 func (c *KubeAPIServerLocalhostSignerCertKey) Dependencies() []asset.Asset {
-	return []asset.Asset{}
+	return []asset.Asset{
+		&installconfig.InstallConfig{},
+	}
 }
 
 // Generate generates the root-ca key and cert pair.
 func (c *KubeAPIServerLocalhostSignerCertKey) Generate(parents asset.Parents) error {
 	cfg := &CertCfg{
-		Subject:   pkix.Name{CommonName: "kube-apiserver-localhost-signer", OrganizationalUnit: []string{"openshift"}},
-		KeyUsages: x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
-		Validity:  ValidityTenYears,
-		IsCA:      true,
+		Subject:      pkix.Name{CommonName: "kube-apiserver-localhost-signer", OrganizationalUnit: []string{"openshift"}},
+		KeyUsages:    x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		Validity:     ValidityTenYears,
+		IsCA:         true,
+		KeyAlgorithm: keyAlgorithm(parents),
 	}
 
 	return c.SelfSignedCertKey.Generate(cfg, "kube-apiserver-localhost-signer")
@@ -132,6 +145,11 @@ func (c *KubeAPIServerLocalhostSignerCertKey) Name() string {
 	return "Certificate (kube-apiserver-localhost-signer)"
 }
 
+// Load loads the user-provided CA from the tls directory, if any.
+func (c *KubeAPIServerLocalhostSignerCertKey) Load(f asset.FileFetcher) (bool, error) {
+	return c.SelfSignedCertKey.Load(f, "kube-apiserver-localhost-signer")
+}
+
 // KubeAPIServerLocalhostCABundle is the asset the generates the kube-apiserver-localhost-ca-bundle,
 // which contains all the individual client CAs.
 type KubeAPIServerLocalhostCABundle struct {
@@ -207,18 +225,22 @@ type KubeAPIServerServiceNetworkSignerCertKey struct {
 
 var _ asset.WritableAsset = (*KubeAPIServerServiceNetworkSignerCertKey)(nil)
 
-// Dependencies returns the dependency of the root-ca, which is empty.
+// Dependencies returns the dependency of the CA, which is the install config
+// for the key algorithm.
 func (c *KubeAPIServerServiceNetworkSignerCertKey) Dependencies() []asset.Asset {
-	return []asset.Asset{}
+	return []asset.Asset{
+		&installconfig.InstallConfig{},
+	}
 }
 
 // Generate generates the root-ca key and cert pair.
 func (c *KubeAPIServerServiceNetworkSignerCertKey) Generate(parents asset.Parents) error {
 	cfg := &CertCfg{
-		Subject:   pkix.Name{CommonName: "kube-apiserver-service-network-signer", OrganizationalUnit: []string{"openshift"}},
-		KeyUsages: x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
-		Validity:  ValidityTenYears,
-		IsCA:      true,
+		Subject:      pkix.Name{CommonName: "kube-apiserver-service-network-signer", OrganizationalUnit: []string{"openshift"}},
+		KeyUsages:    x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		Validity:     ValidityTenYears,
+		IsCA:         true,
+		KeyAlgorithm: keyAlgorithm(parents),
 	}
 
 	return c.SelfSignedCertKey.Generate(cfg, "kube-apiserver-service-network-signer")
@@ -229,6 +251,11 @@ func (c *KubeAPIServerServiceNetworkSignerCertKey) Name() string {
 	return "Certificate (kube-apiserver-service-network-signer)"
 }
 
+// Load loads the user-provided CA from the tls directory, if any.
+func (c *KubeAPIServerServiceNetworkSignerCertKey) Load(f asset.FileFetcher) (bool, error) {
+	return c.SelfSignedCertKey.Load(f, "kube-apiserver-service-network-signer")
+}
+
 // KubeAPIServerServiceNetworkCABundle is the asset the generates the kube-apiserver-service-network-ca-bundle,
 // which contains all the individual client CAs.
 type KubeAPIServerServiceNetworkCABundle struct {
@@ -315,18 +342,22 @@ type KubeAPIServerLBSignerCertKey struct {
 
 var _ asset.WritableAsset = (*KubeAPIServerLBSignerCertKey)(nil)
 
-// Dependencies returns the dependency of the root-ca, which is empty.
+// Dependencies returns the dependency of the CA, which is the install config
+// for the key algorithm.
 func (c *KubeAPIServerLBSignerCertKey) Dependencies() []asset.Asset {
-	return []asset.Asset{}
+	return []asset.Asset{
+		&installconfig.InstallConfig{},
+	}
 }
 
 // Generate generates the root-ca key and cert pair.
 func (c *KubeAPIServerLBSignerCertKey) Generate(parents asset.Parents) error {
 	cfg := &CertCfg{
-		Subject:   pkix.Name{CommonName: "kube-apiserver-lb-signer", OrganizationalUnit: []string{"openshift"}},
-		KeyUsages: x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
-		Validity:  ValidityTenYears,
-		IsCA:      true,
+		Subject:      pkix.Name{CommonName: "kube-apiserver-lb-signer", OrganizationalUnit: []string{"openshift"}},
+		KeyUsages:    x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		Validity:     ValidityTenYears,
+		IsCA:         true,
+		KeyAlgorithm: keyAlgorithm(parents),
 	}
 
 	return c.SelfSignedCertKey.Generate(cfg, "kube-apiserver-lb-signer")
@@ -337,6 +368,11 @@ func (c *KubeAPIServerLBSignerCertKey) Name() string {
 	return "Certificate (kube-apiserver-lb-signer)"
 }
 
+// Load loads the user-provided CA from the tls directory, if any.
+func (c *KubeAPIServerLBSignerCertKey) Load(f asset.FileFetcher) (bool, error) {
+	return c.SelfSignedCertKey.Load(f, "kube-apiserver-lb-signer")
+}
+
 // KubeAPIServerLBCABundle is the asset the generates the kube-apiserver-lb-ca-bundle,
 // which contains all the individual client CAs.
 type KubeAPIServerLBCABundle struct {
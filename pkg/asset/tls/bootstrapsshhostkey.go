@@ -0,0 +1,132 @@
+package tls
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/ssh"
+
+	"github.com/openshift/installer/pkg/asset"
+)
+
+// BootstrapSSHHostKey generates the ECDSA host key of the SSH server of the bootstrap-host.
+// The public key is pinned when connecting to the bootstrap-host, so that the installer
+// does not need to trust the host key presented on first connection.
+type BootstrapSSHHostKey struct {
+	Priv []byte // private key, in PEM format
+	Pub  []byte // public ssh key, in authorized_keys format
+}
+
+const (
+	bootstrapSSHHostKeyFilenameBase = "bootstrap-ssh-host"
+
+	// BootstrapSSHHostKeyPath is the path of the host key on the bootstrap-host. sshd-keygen does
+	// not generate a key when the file already exists.
+	BootstrapSSHHostKeyPath = "/etc/ssh/ssh_host_ecdsa_key"
+)
+
+var _ asset.WritableAsset = (*BootstrapSSHHostKey)(nil)
+
+// Dependencies lists the assets required to generate the BootstrapSSHHostKey.
+func (a *BootstrapSSHHostKey) Dependencies() []asset.Asset {
+	return []asset.Asset{}
+}
+
+// Name defines a user friendly name for BootstrapSSHHostKey.
+func (a *BootstrapSSHHostKey) Name() string {
+	return "Bootstrap SSH Host Key"
+}
+
+// Generate generates the host key.
+func (a *BootstrapSSHHostKey) Generate(dependencies asset.Parents) error {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return errors.Wrap(err, "failed to generate private key")
+	}
+
+	der, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal private key")
+	}
+
+	publicSSHKey, err := ssh.NewPublicKey(&key.PublicKey)
+	if err != nil {
+		return errors.Wrap(err, "failed to create public SSH key from public ECDSA key")
+	}
+
+	a.Priv = pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der})
+	a.Pub = ssh.MarshalAuthorizedKey(publicSSHKey)
+
+	return nil
+}
+
+// Public returns the public SSH key.
+func (a *BootstrapSSHHostKey) Public() []byte {
+	return a.Pub
+}
+
+// Private returns the private key.
+func (a *BootstrapSSHHostKey) Private() []byte {
+	return a.Priv
+}
+
+// Files returns the files generated by the asset.
+func (a *BootstrapSSHHostKey) Files() []*asset.File {
+	return []*asset.File{{
+		Filename: assetFilePath(bootstrapSSHHostKeyFilenameBase + ".key"),
+		Data:     a.Priv,
+	}, {
+		Filename: assetFilePath(bootstrapSSHHostKeyFilenameBase + ".pub"),
+		Data:     a.Pub,
+	}}
+}
+
+// Load loads the host key from tls/bootstrap-ssh-host.key, with the public key derived from it. The
+// public key in tls/bootstrap-ssh-host.pub, if any, must match.
+func (a *BootstrapSSHHostKey) Load(f asset.FileFetcher) (bool, error) {
+	keyFile, err := f.FetchByName(assetFilePath(bootstrapSSHHostKeyFilenameBase + ".key"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	key, err := PemToPrivateKey(keyFile.Data)
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to parse the private key in %s", keyFile.Filename)
+	}
+	ecdsaKey, ok := key.(*ecdsa.PrivateKey)
+	if !ok {
+		return false, errors.Errorf("the private key in %s is not an ECDSA key", keyFile.Filename)
+	}
+	publicSSHKey, err := ssh.NewPublicKey(&ecdsaKey.PublicKey)
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to create public SSH key from the private key in %s", keyFile.Filename)
+	}
+	pub := ssh.MarshalAuthorizedKey(publicSSHKey)
+
+	pubFile, err := f.FetchByName(assetFilePath(bootstrapSSHHostKeyFilenameBase + ".pub"))
+	switch {
+	case err == nil:
+		filePublicKey, _, _, _, err := ssh.ParseAuthorizedKey(pubFile.Data)
+		if err != nil {
+			return false, errors.Wrapf(err, "failed to parse the public key in %s", pubFile.Filename)
+		}
+		if !bytes.Equal(filePublicKey.Marshal(), publicSSHKey.Marshal()) {
+			return false, errors.Errorf("the public key in %s does not match the private key in %s", pubFile.Filename, keyFile.Filename)
+		}
+	case !os.IsNotExist(err):
+		return false, err
+	}
+
+	a.Priv = keyFile.Data
+	a.Pub = pub
+	return true, nil
+}
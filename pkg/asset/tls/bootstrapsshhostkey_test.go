@@ -0,0 +1,90 @@
+package tls
+
+import (
+	"os"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/mock"
+)
+
+func TestBootstrapSSHHostKeyLoad(t *testing.T) {
+	hostKey := &BootstrapSSHHostKey{}
+	require.NoError(t, hostKey.Generate(nil))
+	otherHostKey := &BootstrapSSHHostKey{}
+	require.NoError(t, otherHostKey.Generate(nil))
+	u := newUserCA(t)
+
+	cases := []struct {
+		name          string
+		files         map[string][]byte
+		expectedFound bool
+		expectedError string
+	}{
+		{
+			name: "not provided",
+		},
+		{
+			name: "private key",
+			files: map[string][]byte{
+				"tls/bootstrap-ssh-host.key": hostKey.Private(),
+			},
+			expectedFound: true,
+		},
+		{
+			name: "private and public keys",
+			files: map[string][]byte{
+				"tls/bootstrap-ssh-host.key": hostKey.Private(),
+				"tls/bootstrap-ssh-host.pub": hostKey.Public(),
+			},
+			expectedFound: true,
+		},
+		{
+			name: "mismatched public key",
+			files: map[string][]byte{
+				"tls/bootstrap-ssh-host.key": hostKey.Private(),
+				"tls/bootstrap-ssh-host.pub": otherHostKey.Public(),
+			},
+			expectedError: "the public key in tls/bootstrap-ssh-host.pub does not match the private key in tls/bootstrap-ssh-host.key",
+		},
+		{
+			name: "rsa key",
+			files: map[string][]byte{
+				"tls/bootstrap-ssh-host.key": u.rootKey,
+			},
+			expectedError: "the private key in tls/bootstrap-ssh-host.key is not an ECDSA key",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			fileFetcher := mock.NewMockFileFetcher(mockCtrl)
+			fileFetcher.EXPECT().FetchByName(gomock.Any()).DoAndReturn(func(name string) (*asset.File, error) {
+				data, ok := tc.files[name]
+				if !ok {
+					return nil, os.ErrNotExist
+				}
+				return &asset.File{Filename: name, Data: data}, nil
+			}).AnyTimes()
+
+			loaded := &BootstrapSSHHostKey{}
+			found, err := loaded.Load(fileFetcher)
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedFound, found)
+			if found {
+				assert.Equal(t, hostKey.Private(), loaded.Private())
+				assert.Equal(t, hostKey.Public(), loaded.Public())
+			}
+		})
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"golang.org/x/crypto/ssh"
 
 	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/installconfig"
 )
 
 // BootstrapSSHKeyPair generates a private, public key pair for SSH.
@@ -22,7 +23,9 @@ var _ asset.Asset = (*BootstrapSSHKeyPair)(nil)
 
 // Dependencies lists the assets required to generate the BootstrapSSHKeyPair.
 func (a *BootstrapSSHKeyPair) Dependencies() []asset.Asset {
-	return []asset.Asset{}
+	return []asset.Asset{
+		&installconfig.InstallConfig{},
+	}
 }
 
 // Name defines a user freindly name for BootstrapSSHKeyPair.
@@ -33,19 +36,19 @@ func (a *BootstrapSSHKeyPair) Name() string {
 // Generate generates the key pair based on its dependencies.
 func (a *BootstrapSSHKeyPair) Generate(dependencies asset.Parents) error {
 	kp := KeyPair{}
-	if err := kp.Generate(bootstrapSSHKeyPairFilenameBase); err != nil {
+	if err := kp.Generate(keyAlgorithm(dependencies), bootstrapSSHKeyPairFilenameBase); err != nil {
 		return errors.Wrap(err, "failed to generate key pair")
 	}
 
-	publicRSAKey, err := PemToPublicKey(kp.Pub)
+	publicKey, err := PemToPublicKey(kp.Pub)
 	if err != nil {
-		logrus.Debugf("Failed to parse the public RSA key: %s", err)
-		return errors.Wrap(err, "failed to parse the public RSA key")
+		logrus.Debugf("Failed to parse the public key: %s", err)
+		return errors.Wrap(err, "failed to parse the public key")
 	}
 
-	publicSSHKey, err := ssh.NewPublicKey(publicRSAKey)
+	publicSSHKey, err := ssh.NewPublicKey(publicKey)
 	if err != nil {
-		return errors.Wrap(err, "failed to create public SSH key from public RSA key")
+		return errors.Wrap(err, "failed to create public SSH key from public key")
 	}
 
 	a.Priv = kp.Private()
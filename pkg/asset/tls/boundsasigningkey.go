@@ -39,7 +39,7 @@ func (sk *BoundSASigningKey) Files() []*asset.File {
 }
 
 // Load reads the private key from the disk.
-// It ensures that the key provided is a valid RSA key.
+// It ensures that the key provided is a valid RSA or ECDSA key.
 func (sk *BoundSASigningKey) Load(f asset.FileFetcher) (bool, error) {
 	keyFile, err := f.FetchByName(filepath.Join(tlsDir, "bound-service-account-signing-key.key"))
 	if err != nil {
@@ -49,12 +49,12 @@ func (sk *BoundSASigningKey) Load(f asset.FileFetcher) (bool, error) {
 		return false, err
 	}
 
-	rsaKey, err := PemToPrivateKey(keyFile.Data)
+	key, err := PemToPrivateKey(keyFile.Data)
 	if err != nil {
-		logrus.Debugf("Failed to load rsa.PrivateKey from file: %s", err)
-		return false, errors.Wrap(err, "failed to load rsa.PrivateKey from the file")
+		logrus.Debugf("Failed to load private key from file: %s", err)
+		return false, errors.Wrap(err, "failed to load private key from the file")
 	}
-	pubData, err := PublicKeyToPem(&rsaKey.PublicKey)
+	pubData, err := PublicKeyToPem(key.Public())
 	if err != nil {
 		return false, errors.Wrap(err, "failed to extract public key from the key")
 	}
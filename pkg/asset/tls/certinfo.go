@@ -0,0 +1,89 @@
+package tls
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// CertificateInfo describes a certificate, for troubleshooting.
+type CertificateInfo struct {
+	// Source is the asset or the Ignition config that contains the certificate.
+	Source string `json:"source"`
+	// File is the path of the file of the certificate, in the asset directory or on the host.
+	File        string    `json:"file,omitempty"`
+	Subject     string    `json:"subject"`
+	Issuer      string    `json:"issuer"`
+	DNSNames    []string  `json:"dnsNames,omitempty"`
+	IPAddresses []string  `json:"ipAddresses,omitempty"`
+	KeyType     string    `json:"keyType"`
+	IsCA        bool      `json:"isCA"`
+	NotBefore   time.Time `json:"notBefore"`
+	NotAfter    time.Time `json:"notAfter"`
+	// ConsumedBy are the names of the assets that depend on the asset of the certificate.
+	ConsumedBy []string `json:"consumedBy,omitempty"`
+}
+
+// DescribeCertificate returns the description of the certificate.
+func DescribeCertificate(cert *x509.Certificate) *CertificateInfo {
+	info := &CertificateInfo{
+		Subject:   cert.Subject.String(),
+		Issuer:    cert.Issuer.String(),
+		DNSNames:  cert.DNSNames,
+		KeyType:   PublicKeyType(cert.PublicKey),
+		IsCA:      cert.IsCA,
+		NotBefore: cert.NotBefore.UTC(),
+		NotAfter:  cert.NotAfter.UTC(),
+	}
+	for _, ip := range cert.IPAddresses {
+		info.IPAddresses = append(info.IPAddresses, ip.String())
+	}
+	return info
+}
+
+// Expired returns whether the certificate is expired at now.
+func (i *CertificateInfo) Expired(now time.Time) bool {
+	return now.After(i.NotAfter)
+}
+
+// PublicKeyType returns the algorithm and the size of the public key, e.g. "RSA 2048" or "ECDSA P-256".
+func PublicKeyType(key crypto.PublicKey) string {
+	switch k := key.(type) {
+	case *rsa.PublicKey:
+		return fmt.Sprintf("RSA %d", k.N.BitLen())
+	case *ecdsa.PublicKey:
+		return fmt.Sprintf("ECDSA %s", k.Curve.Params().Name)
+	case ed25519.PublicKey:
+		return "Ed25519"
+	default:
+		return fmt.Sprintf("%T", key)
+	}
+}
+
+// PemToCertificates converts all the CERTIFICATE blocks of the PEM data to x509.Certificates. The
+// certificates before the first one that cannot be parsed are returned with the error.
+func PemToCertificates(data []byte) ([]*x509.Certificate, error) {
+	var certs []*x509.Certificate
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			return certs, nil
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return certs, errors.Wrap(err, "failed to parse the certificate")
+		}
+		certs = append(certs, cert)
+	}
+}
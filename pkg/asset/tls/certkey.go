@@ -2,13 +2,15 @@ package tls
 
 import (
 	"bytes"
-	"crypto/rsa"
+	"crypto"
 	"crypto/x509"
 
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 
 	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/installconfig"
+	"github.com/openshift/installer/pkg/types"
 )
 
 // CertInterface contains cert.
@@ -93,14 +95,14 @@ func (c *SignedCertKey) Generate(
 	filenameBase string,
 	appendParent AppendParentChoice,
 ) error {
-	var key *rsa.PrivateKey
+	var key crypto.Signer
 	var crt *x509.Certificate
 	var err error
 
 	caKey, err := PemToPrivateKey(parentCA.Key())
 	if err != nil {
-		logrus.Debugf("Failed to parse RSA private key: %s", err)
-		return errors.Wrap(err, "failed to parse rsa private key")
+		logrus.Debugf("Failed to parse private key: %s", err)
+		return errors.Wrap(err, "failed to parse private key")
 	}
 
 	caCert, err := PemToCertificate(parentCA.Cert())
@@ -115,7 +117,10 @@ func (c *SignedCertKey) Generate(
 		return errors.Wrap(err, "failed to generate signed cert/key pair")
 	}
 
-	c.KeyRaw = PrivateKeyToPem(key)
+	c.KeyRaw, err = PrivateKeyToPem(key)
+	if err != nil {
+		return errors.Wrap(err, "failed to encode private key")
+	}
 	c.CertRaw = CertToPem(crt)
 
 	if appendParent {
@@ -142,10 +147,25 @@ func (c *SelfSignedCertKey) Generate(
 		return errors.Wrap(err, "failed to generate self-signed cert/key pair")
 	}
 
-	c.KeyRaw = PrivateKeyToPem(key)
+	c.KeyRaw, err = PrivateKeyToPem(key)
+	if err != nil {
+		return errors.Wrap(err, "failed to encode private key")
+	}
 	c.CertRaw = CertToPem(crt)
 
 	c.generateFiles(filenameBase)
 
 	return nil
 }
+
+// keyAlgorithm returns the key algorithm of the install config in the parents, or RSA 2048 when
+// the install config sets none. The keys that are signed by a CA do not need it, as they use the
+// algorithm of the key of their CA.
+func keyAlgorithm(parents asset.Parents) types.KeyAlgorithm {
+	installConfig := &installconfig.InstallConfig{}
+	parents.Get(installConfig)
+	if installConfig.Config == nil || installConfig.Config.KeyAlgorithm == "" {
+		return types.KeyAlgorithmRSA2048
+	}
+	return installConfig.Config.KeyAlgorithm
+}
@@ -7,6 +7,10 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/installconfig"
+	"github.com/openshift/installer/pkg/types"
 )
 
 func TestSignedCertKeyGenerate(t *testing.T) {
@@ -45,8 +49,11 @@ func TestSignedCertKeyGenerate(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			parents := asset.Parents{}
+			parents.Add(&installconfig.InstallConfig{Config: &types.InstallConfig{}})
+
 			rootCA := &RootCA{}
-			err := rootCA.Generate(nil)
+			err := rootCA.Generate(parents)
 			assert.NoError(t, err, "failed to generate root CA")
 
 			certKey := &SignedCertKey{}
@@ -89,3 +96,34 @@ func TestSignedCertKeyGenerate(t *testing.T) {
 		})
 	}
 }
+
+func TestKeyAlgorithm(t *testing.T) {
+	cases := []struct {
+		name     string
+		parents  []asset.Asset
+		expected types.KeyAlgorithm
+	}{
+		{
+			name:     "install config without a config",
+			parents:  []asset.Asset{&installconfig.InstallConfig{}},
+			expected: types.KeyAlgorithmRSA2048,
+		},
+		{
+			name:     "default key algorithm",
+			parents:  []asset.Asset{&installconfig.InstallConfig{Config: &types.InstallConfig{}}},
+			expected: types.KeyAlgorithmRSA2048,
+		},
+		{
+			name:     "key algorithm",
+			parents:  []asset.Asset{&installconfig.InstallConfig{Config: &types.InstallConfig{KeyAlgorithm: types.KeyAlgorithmECDSAP256}}},
+			expected: types.KeyAlgorithmECDSAP256,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			parents := asset.Parents{}
+			parents.Add(tc.parents...)
+			assert.Equal(t, tc.expected, keyAlgorithm(parents))
+		})
+	}
+}
@@ -2,6 +2,7 @@ package tls
 
 import (
 	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/types"
 	"github.com/pkg/errors"
 )
 
@@ -20,19 +21,22 @@ type KeyPair struct {
 	FileList []*asset.File
 }
 
-// Generate generates the rsa private / public key pair.
-func (k *KeyPair) Generate(filenameBase string) error {
-	key, err := PrivateKey()
+// Generate generates the private / public key pair with the algorithm.
+func (k *KeyPair) Generate(algorithm types.KeyAlgorithm, filenameBase string) error {
+	key, err := GeneratePrivateKey(algorithm)
 	if err != nil {
 		return errors.Wrap(err, "failed to generate private key")
 	}
 
-	pubkeyData, err := PublicKeyToPem(&key.PublicKey)
+	pubkeyData, err := PublicKeyToPem(key.Public())
 	if err != nil {
 		return errors.Wrap(err, "failed to get public key data from private key")
 	}
 
-	k.Pvt = PrivateKeyToPem(key)
+	k.Pvt, err = PrivateKeyToPem(key)
+	if err != nil {
+		return errors.Wrap(err, "failed to encode private key")
+	}
 	k.Pub = pubkeyData
 
 	k.FileList = []*asset.File{
@@ -5,6 +5,7 @@ import (
 	"crypto/x509/pkix"
 
 	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/installconfig"
 )
 
 // KubeControlPlaneSignerCertKey is a key/cert pair that signs the kube control-plane client certs.
@@ -14,18 +15,22 @@ type KubeControlPlaneSignerCertKey struct {
 
 var _ asset.WritableAsset = (*KubeControlPlaneSignerCertKey)(nil)
 
-// Dependencies returns the dependency of the root-ca, which is empty.
+// Dependencies returns the dependency of the CA, which is the install config
+// for the key algorithm.
 func (c *KubeControlPlaneSignerCertKey) Dependencies() []asset.Asset {
-	return []asset.Asset{}
+	return []asset.Asset{
+		&installconfig.InstallConfig{},
+	}
 }
 
 // Generate generates the root-ca key and cert pair.
 func (c *KubeControlPlaneSignerCertKey) Generate(parents asset.Parents) error {
 	cfg := &CertCfg{
-		Subject:   pkix.Name{CommonName: "kube-control-plane-signer", OrganizationalUnit: []string{"openshift"}},
-		KeyUsages: x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
-		Validity:  ValidityOneYear,
-		IsCA:      true,
+		Subject:      pkix.Name{CommonName: "kube-control-plane-signer", OrganizationalUnit: []string{"openshift"}},
+		KeyUsages:    x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		Validity:     ValidityOneYear,
+		IsCA:         true,
+		KeyAlgorithm: keyAlgorithm(parents),
 	}
 
 	return c.SelfSignedCertKey.Generate(cfg, "kube-control-plane-signer")
@@ -36,6 +41,11 @@ func (c *KubeControlPlaneSignerCertKey) Name() string {
 	return "Certificate (kube-control-plane-signer)"
 }
 
+// Load loads the user-provided CA from the tls directory, if any.
+func (c *KubeControlPlaneSignerCertKey) Load(f asset.FileFetcher) (bool, error) {
+	return c.SelfSignedCertKey.Load(f, "kube-control-plane-signer")
+}
+
 // KubeControlPlaneCABundle is the asset the generates the kube-control-plane-ca-bundle,
 // which contains all the individual client CAs.
 type KubeControlPlaneCABundle struct {
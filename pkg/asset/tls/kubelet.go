@@ -5,6 +5,7 @@ import (
 	"crypto/x509/pkix"
 
 	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/installconfig"
 )
 
 // KubeletCSRSignerCertKey is a key/cert pair that signs the kubelet client certs.
@@ -14,18 +15,22 @@ type KubeletCSRSignerCertKey struct {
 
 var _ asset.WritableAsset = (*KubeletCSRSignerCertKey)(nil)
 
-// Dependencies returns the dependency of the root-ca, which is empty.
+// Dependencies returns the dependency of the CA, which is the install config
+// for the key algorithm.
 func (c *KubeletCSRSignerCertKey) Dependencies() []asset.Asset {
-	return []asset.Asset{}
+	return []asset.Asset{
+		&installconfig.InstallConfig{},
+	}
 }
 
 // Generate generates the root-ca key and cert pair.
 func (c *KubeletCSRSignerCertKey) Generate(parents asset.Parents) error {
 	cfg := &CertCfg{
-		Subject:   pkix.Name{CommonName: "kubelet-signer", OrganizationalUnit: []string{"openshift"}},
-		KeyUsages: x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
-		Validity:  ValidityOneDay,
-		IsCA:      true,
+		Subject:      pkix.Name{CommonName: "kubelet-signer", OrganizationalUnit: []string{"openshift"}},
+		KeyUsages:    x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		Validity:     ValidityOneDay,
+		IsCA:         true,
+		KeyAlgorithm: keyAlgorithm(parents),
 	}
 
 	return c.SelfSignedCertKey.Generate(cfg, "kubelet-signer")
@@ -36,6 +41,11 @@ func (c *KubeletCSRSignerCertKey) Name() string {
 	return "Certificate (kubelet-signer)"
 }
 
+// Load loads the user-provided CA from the tls directory, if any.
+func (c *KubeletCSRSignerCertKey) Load(f asset.FileFetcher) (bool, error) {
+	return c.SelfSignedCertKey.Load(f, "kubelet-signer")
+}
+
 // KubeletClientCABundle is the asset the generates the kubelet-client-ca-bundle,
 // which contains all the individual client CAs.
 type KubeletClientCABundle struct {
@@ -104,18 +114,22 @@ type KubeletBootstrapCertSigner struct {
 
 var _ asset.WritableAsset = (*KubeletBootstrapCertSigner)(nil)
 
-// Dependencies returns the dependency of the root-ca, which is empty.
+// Dependencies returns the dependency of the CA, which is the install config
+// for the key algorithm.
 func (c *KubeletBootstrapCertSigner) Dependencies() []asset.Asset {
-	return []asset.Asset{}
+	return []asset.Asset{
+		&installconfig.InstallConfig{},
+	}
 }
 
 // Generate generates the root-ca key and cert pair.
 func (c *KubeletBootstrapCertSigner) Generate(parents asset.Parents) error {
 	cfg := &CertCfg{
-		Subject:   pkix.Name{CommonName: "kubelet-bootstrap-kubeconfig-signer", OrganizationalUnit: []string{"openshift"}},
-		KeyUsages: x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
-		Validity:  ValidityTenYears,
-		IsCA:      true,
+		Subject:      pkix.Name{CommonName: "kubelet-bootstrap-kubeconfig-signer", OrganizationalUnit: []string{"openshift"}},
+		KeyUsages:    x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		Validity:     ValidityTenYears,
+		IsCA:         true,
+		KeyAlgorithm: keyAlgorithm(parents),
 	}
 
 	return c.SelfSignedCertKey.Generate(cfg, "kubelet-bootstrap-kubeconfig-signer")
@@ -126,6 +140,11 @@ func (c *KubeletBootstrapCertSigner) Name() string {
 	return "Certificate (kubelet-bootstrap-kubeconfig-signer)"
 }
 
+// Load loads the user-provided CA from the tls directory, if any.
+func (c *KubeletBootstrapCertSigner) Load(f asset.FileFetcher) (bool, error) {
+	return c.SelfSignedCertKey.Load(f, "kubelet-bootstrap-kubeconfig-signer")
+}
+
 // KubeletBootstrapCABundle is the asset the generates the admin-kubeconfig-ca-bundle,
 // which contains all the individual client CAs.
 type KubeletBootstrapCABundle struct {
@@ -1,6 +1,9 @@
 package tls
 
-import "github.com/openshift/installer/pkg/asset"
+import (
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/installconfig"
+)
 
 // ServiceAccountKeyPair is the asset that generates the service-account public/private key pair.
 type ServiceAccountKeyPair struct {
@@ -9,16 +12,17 @@ type ServiceAccountKeyPair struct {
 
 var _ asset.WritableAsset = (*ServiceAccountKeyPair)(nil)
 
-// Dependencies returns the dependency of the the cert/key pair, which includes
-// the parent CA, and install config if it depends on the install config for
-// DNS names, etc.
+// Dependencies returns the dependency of the key pair, which is the install
+// config for the key algorithm.
 func (a *ServiceAccountKeyPair) Dependencies() []asset.Asset {
-	return []asset.Asset{}
+	return []asset.Asset{
+		&installconfig.InstallConfig{},
+	}
 }
 
 // Generate generates the cert/key pair based on its dependencies.
 func (a *ServiceAccountKeyPair) Generate(dependencies asset.Parents) error {
-	return a.KeyPair.Generate("service-account")
+	return a.KeyPair.Generate(keyAlgorithm(dependencies), "service-account")
 }
 
 // Name returns the human-friendly name of the asset.
@@ -0,0 +1,115 @@
+package tls
+
+import (
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"os"
+	"sort"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"github.com/openshift/installer/pkg/asset"
+)
+
+// signerSubjects are the subjects of the self-signed CAs that the user can replace, by the base of
+// their filenames in the tls directory.
+var signerSubjects = map[string]pkix.Name{
+	"admin-kubeconfig-signer":               {CommonName: "admin-kubeconfig-signer", OrganizationalUnit: []string{"openshift"}},
+	"aggregator-ca":                         {CommonName: "aggregator", OrganizationalUnit: []string{"bootkube"}},
+	"aggregator-signer":                     {CommonName: "aggregator-signer", OrganizationalUnit: []string{"openshift"}},
+	"kube-apiserver-lb-signer":              {CommonName: "kube-apiserver-lb-signer", OrganizationalUnit: []string{"openshift"}},
+	"kube-apiserver-localhost-signer":       {CommonName: "kube-apiserver-localhost-signer", OrganizationalUnit: []string{"openshift"}},
+	"kube-apiserver-service-network-signer": {CommonName: "kube-apiserver-service-network-signer", OrganizationalUnit: []string{"openshift"}},
+	"kube-apiserver-to-kubelet-signer":      {CommonName: "kube-apiserver-to-kubelet-signer", OrganizationalUnit: []string{"openshift"}},
+	"kube-control-plane-signer":             {CommonName: "kube-control-plane-signer", OrganizationalUnit: []string{"openshift"}},
+	"kubelet-bootstrap-kubeconfig-signer":   {CommonName: "kubelet-bootstrap-kubeconfig-signer", OrganizationalUnit: []string{"openshift"}},
+	"kubelet-signer":                        {CommonName: "kubelet-signer", OrganizationalUnit: []string{"openshift"}},
+	"root-ca":                               {CommonName: "root-ca", OrganizationalUnit: []string{"openshift"}},
+}
+
+// SignerCSRs is the asset for the certificate signing requests of the signers whose private key is
+// provided by the user in the tls directory without a certificate, for the CAs to be signed out-of-band.
+type SignerCSRs struct {
+	FileList []*asset.File
+}
+
+var _ asset.WritableAsset = (*SignerCSRs)(nil)
+
+// Dependencies returns the dependency of the asset.
+func (a *SignerCSRs) Dependencies() []asset.Asset {
+	return []asset.Asset{}
+}
+
+// Generate generates no certificate signing request, as there is no user-provided private key.
+func (a *SignerCSRs) Generate(dependencies asset.Parents) error {
+	logrus.Warnf("No private key of a signer is provided without its certificate in the %s directory", tlsDir)
+	return nil
+}
+
+// Name returns the human-friendly name of the asset.
+func (a *SignerCSRs) Name() string {
+	return "Signer Certificate Signing Requests"
+}
+
+// Files returns the files generated by the asset.
+func (a *SignerCSRs) Files() []*asset.File {
+	return a.FileList
+}
+
+// Load creates a certificate signing request in tls/<signer>.csr for each tls/<signer>.key that is
+// provided without tls/<signer>.crt.
+func (a *SignerCSRs) Load(f asset.FileFetcher) (bool, error) {
+	signers := make([]string, 0, len(signerSubjects))
+	for signer := range signerSubjects {
+		signers = append(signers, signer)
+	}
+	sort.Strings(signers)
+
+	var files []*asset.File
+	for _, signer := range signers {
+		keyFile, err := f.FetchByName(assetFilePath(signer + ".key"))
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return false, err
+		}
+		if _, err := f.FetchByName(assetFilePath(signer + ".crt")); err == nil {
+			continue
+		} else if !os.IsNotExist(err) {
+			return false, err
+		}
+
+		csr, err := signerCSR(keyFile.Data, signerSubjects[signer])
+		if err != nil {
+			return false, errors.Wrapf(err, "failed to create a certificate signing request for %s", keyFile.Filename)
+		}
+		files = append(files, &asset.File{Filename: assetFilePath(signer + ".csr"), Data: csr})
+	}
+	if len(files) == 0 {
+		return false, nil
+	}
+
+	a.FileList = files
+	return true, nil
+}
+
+// signerCSR returns the PEM-encoded certificate signing request of a CA with the subject and the private key.
+func signerCSR(keyPEM []byte, subject pkix.Name) ([]byte, error) {
+	key, err := PemToPrivateKey(keyPEM)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse the private key")
+	}
+	if _, err := KeyAlgorithmOf(key); err != nil {
+		return nil, err
+	}
+
+	der, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{Subject: subject}, key)
+	if err != nil {
+		return nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: der}), nil
+}
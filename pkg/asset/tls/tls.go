@@ -17,6 +17,8 @@ import (
 
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
+
+	"github.com/openshift/installer/pkg/types"
 )
 
 const (
@@ -41,6 +43,9 @@ type CertCfg struct {
 	Subject      pkix.Name
 	Validity     time.Duration
 	IsCA         bool
+	// KeyAlgorithm is the algorithm of the generated key. When empty, a signed certificate uses
+	// the algorithm of the key of its CA, and a self-signed certificate uses RSA 2048.
+	KeyAlgorithm types.KeyAlgorithm
 }
 
 // rsaPublicKey reflects the ASN.1 structure of a PKCS#1 public key.
@@ -59,8 +64,68 @@ func PrivateKey() (*rsa.PrivateKey, error) {
 	return rsaKey, nil
 }
 
+// GeneratePrivateKey generates a private key with the algorithm. The empty algorithm generates
+// an RSA key of 2048 bits.
+func GeneratePrivateKey(algorithm types.KeyAlgorithm) (crypto.Signer, error) {
+	var key crypto.Signer
+	var err error
+	switch algorithm {
+	case "", types.KeyAlgorithmRSA2048:
+		key, err = rsa.GenerateKey(rand.Reader, 2048)
+	case types.KeyAlgorithmRSA3072:
+		key, err = rsa.GenerateKey(rand.Reader, 3072)
+	case types.KeyAlgorithmRSA4096:
+		key, err = rsa.GenerateKey(rand.Reader, 4096)
+	case types.KeyAlgorithmECDSAP256:
+		key, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	case types.KeyAlgorithmECDSAP384:
+		key, err = ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	default:
+		return nil, errors.Errorf("unsupported key algorithm %q", algorithm)
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "error generating %s private key", algorithm)
+	}
+	return key, nil
+}
+
+// KeyAlgorithmOf returns the algorithm of the private key.
+func KeyAlgorithmOf(key crypto.Signer) (types.KeyAlgorithm, error) {
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		switch k.N.BitLen() {
+		case 2048:
+			return types.KeyAlgorithmRSA2048, nil
+		case 3072:
+			return types.KeyAlgorithmRSA3072, nil
+		case 4096:
+			return types.KeyAlgorithmRSA4096, nil
+		}
+		return "", errors.Errorf("unsupported RSA key size %d", k.N.BitLen())
+	case *ecdsa.PrivateKey:
+		switch k.Curve {
+		case elliptic.P256():
+			return types.KeyAlgorithmECDSAP256, nil
+		case elliptic.P384():
+			return types.KeyAlgorithmECDSAP384, nil
+		}
+		return "", errors.Errorf("unsupported ECDSA curve %s", k.Curve.Params().Name)
+	default:
+		return "", errors.Errorf("unsupported private key type %T", key)
+	}
+}
+
+// keyUsages returns the key usages of the certificate for the public key. Key encipherment only
+// applies to RSA keys.
+func keyUsages(usages x509.KeyUsage, pub crypto.PublicKey) x509.KeyUsage {
+	if _, ok := pub.(*rsa.PublicKey); !ok {
+		usages &^= x509.KeyUsageKeyEncipherment
+	}
+	return usages
+}
+
 // SelfSignedCertificate creates a self signed certificate
-func SelfSignedCertificate(cfg *CertCfg, key *rsa.PrivateKey) (*x509.Certificate, error) {
+func SelfSignedCertificate(cfg *CertCfg, key crypto.Signer) (*x509.Certificate, error) {
 	serial, err := rand.Int(rand.Reader, new(big.Int).SetInt64(math.MaxInt64))
 	if err != nil {
 		return nil, err
@@ -68,7 +133,7 @@ func SelfSignedCertificate(cfg *CertCfg, key *rsa.PrivateKey) (*x509.Certificate
 	cert := x509.Certificate{
 		BasicConstraintsValid: true,
 		IsCA:                  cfg.IsCA,
-		KeyUsage:              cfg.KeyUsages,
+		KeyUsage:              keyUsages(cfg.KeyUsages, key.Public()),
 		NotAfter:              time.Now().Add(cfg.Validity),
 		NotBefore:             time.Now(),
 		SerialNumber:          serial,
@@ -94,9 +159,9 @@ func SelfSignedCertificate(cfg *CertCfg, key *rsa.PrivateKey) (*x509.Certificate
 func SignedCertificate(
 	cfg *CertCfg,
 	csr *x509.CertificateRequest,
-	key *rsa.PrivateKey,
+	key crypto.Signer,
 	caCert *x509.Certificate,
-	caKey *rsa.PrivateKey,
+	caKey crypto.Signer,
 ) (*x509.Certificate, error) {
 	serial, err := rand.Int(rand.Reader, new(big.Int).SetInt64(math.MaxInt64))
 	if err != nil {
@@ -107,7 +172,7 @@ func SignedCertificate(
 		DNSNames:              csr.DNSNames,
 		ExtKeyUsage:           cfg.ExtKeyUsages,
 		IPAddresses:           csr.IPAddresses,
-		KeyUsage:              cfg.KeyUsages,
+		KeyUsage:              keyUsages(cfg.KeyUsages, key.Public()),
 		NotAfter:              time.Now().Add(cfg.Validity),
 		NotBefore:             caCert.NotBefore,
 		SerialNumber:          serial,
@@ -150,11 +215,20 @@ func generateSubjectKeyID(pub crypto.PublicKey) ([]byte, error) {
 }
 
 // GenerateSignedCertificate generate a key and cert defined by CertCfg and signed by CA.
-func GenerateSignedCertificate(caKey *rsa.PrivateKey, caCert *x509.Certificate,
-	cfg *CertCfg) (*rsa.PrivateKey, *x509.Certificate, error) {
+func GenerateSignedCertificate(caKey crypto.Signer, caCert *x509.Certificate,
+	cfg *CertCfg) (crypto.Signer, *x509.Certificate, error) {
+
+	algorithm := cfg.KeyAlgorithm
+	if algorithm == "" {
+		var err error
+		algorithm, err = KeyAlgorithmOf(caKey)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "failed to get the key algorithm of the CA")
+		}
+	}
 
 	// create a private key
-	key, err := PrivateKey()
+	key, err := GeneratePrivateKey(algorithm)
 	if err != nil {
 		return nil, nil, errors.Wrap(err, "failed to generate private key")
 	}
@@ -182,8 +256,8 @@ func GenerateSignedCertificate(caKey *rsa.PrivateKey, caCert *x509.Certificate,
 }
 
 // GenerateSelfSignedCertificate generates a key/cert pair defined by CertCfg.
-func GenerateSelfSignedCertificate(cfg *CertCfg) (*rsa.PrivateKey, *x509.Certificate, error) {
-	key, err := PrivateKey()
+func GenerateSelfSignedCertificate(cfg *CertCfg) (crypto.Signer, *x509.Certificate, error) {
+	key, err := GeneratePrivateKey(cfg.KeyAlgorithm)
 	if err != nil {
 		logrus.Debugf("Failed to generate a private key: %s", err)
 		return nil, nil, errors.Wrap(err, "failed to generate private key")
@@ -2,10 +2,14 @@ package tls
 
 import (
 	"crypto/rand"
+	"crypto/rsa"
 	"crypto/x509"
 	"crypto/x509/pkix"
+	"reflect"
 	"testing"
 	"time"
+
+	"github.com/openshift/installer/pkg/types"
 )
 
 func TestSelfSignedCertificate(t *testing.T) {
@@ -105,3 +109,70 @@ func TestSignedCertificate(t *testing.T) {
 		}
 	}
 }
+
+func TestGeneratePrivateKey(t *testing.T) {
+	cases := []struct {
+		algorithm types.KeyAlgorithm
+		expected  types.KeyAlgorithm
+		err       bool
+	}{
+		{algorithm: "", expected: types.KeyAlgorithmRSA2048},
+		{algorithm: types.KeyAlgorithmRSA2048, expected: types.KeyAlgorithmRSA2048},
+		{algorithm: types.KeyAlgorithmRSA3072, expected: types.KeyAlgorithmRSA3072},
+		{algorithm: types.KeyAlgorithmRSA4096, expected: types.KeyAlgorithmRSA4096},
+		{algorithm: types.KeyAlgorithmECDSAP256, expected: types.KeyAlgorithmECDSAP256},
+		{algorithm: types.KeyAlgorithmECDSAP384, expected: types.KeyAlgorithmECDSAP384},
+		{algorithm: "DSA", err: true},
+	}
+	for _, c := range cases {
+		key, err := GeneratePrivateKey(c.algorithm)
+		if (err != nil) != c.err {
+			t.Errorf("%q: unexpected error: %v", c.algorithm, err)
+		}
+		if err != nil {
+			continue
+		}
+
+		algorithm, err := KeyAlgorithmOf(key)
+		if err != nil || algorithm != c.expected {
+			t.Errorf("%q: expected algorithm %q, got %q (%v)", c.algorithm, c.expected, algorithm, err)
+		}
+
+		privatePem, err := PrivateKeyToPem(key)
+		if err != nil {
+			t.Fatalf("%q: failed to encode the private key: %v", c.algorithm, err)
+		}
+		parsedKey, err := PemToPrivateKey(privatePem)
+		if err != nil {
+			t.Fatalf("%q: failed to decode the private key: %v", c.algorithm, err)
+		}
+		if algorithm, _ := KeyAlgorithmOf(parsedKey); algorithm != c.expected {
+			t.Errorf("%q: expected decoded algorithm %q, got %q", c.algorithm, c.expected, algorithm)
+		}
+
+		publicPem, err := PublicKeyToPem(key.Public())
+		if err != nil {
+			t.Fatalf("%q: failed to encode the public key: %v", c.algorithm, err)
+		}
+		parsedPublicKey, err := PemToPublicKey(publicPem)
+		if err != nil {
+			t.Fatalf("%q: failed to decode the public key: %v", c.algorithm, err)
+		}
+		if !reflect.DeepEqual(parsedPublicKey, key.Public()) {
+			t.Errorf("%q: decoded public key does not match", c.algorithm)
+		}
+
+		cert, err := SelfSignedCertificate(&CertCfg{
+			Validity:  time.Hour,
+			KeyUsages: x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+			Subject:   pkix.Name{CommonName: "root_ca", OrganizationalUnit: []string{"openshift"}},
+			IsCA:      true,
+		}, key)
+		if err != nil {
+			t.Fatalf("%q: failed to create the certificate: %v", c.algorithm, err)
+		}
+		if _, rsaKey := key.(*rsa.PrivateKey); rsaKey != (cert.KeyUsage&x509.KeyUsageKeyEncipherment != 0) {
+			t.Errorf("%q: unexpected key usages %v", c.algorithm, cert.KeyUsage)
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package tls
+
+import (
+	"crypto"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"github.com/openshift/installer/pkg/asset"
+)
+
+// Load loads the user-provided CA from the <filenameBase>.crt and <filenameBase>.key files of the tls
+// directory, which replaces the self-signed CA that would otherwise be generated. The .crt file may be
+// followed by the certificates of the issuers of the CA, such as a corporate intermediate CA, which are
+// only used to validate the chain: only the CA itself is added to the trust bundles, not its issuers.
+// A .key file provided without its .crt file is for a CA to be signed out-of-band, from the
+// certificate signing request that the signer-csrs target writes.
+func (c *SelfSignedCertKey) Load(f asset.FileFetcher, filenameBase string) (bool, error) {
+	keyFile, err := f.FetchByName(assetFilePath(filenameBase + ".key"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	certFile, err := f.FetchByName(assetFilePath(filenameBase + ".crt"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, errors.Errorf("%s is provided without %s, create the certificate signing request of the CA with the signer-csrs target and write the signed certificate to %[2]s", keyFile.Filename, assetFilePath(filenameBase+".crt"))
+		}
+		return false, err
+	}
+
+	cert, key, err := validateCA(certFile.Data, keyFile.Data, time.Now())
+	if err != nil {
+		return false, errors.Wrapf(err, "invalid CA in %s", certFile.Filename)
+	}
+	logrus.Infof("Using the user-provided CA %q from %s", cert.Subject.CommonName, certFile.Filename)
+
+	c.KeyRaw, err = PrivateKeyToPem(key)
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to encode the private key of %s", keyFile.Filename)
+	}
+	c.CertRaw = CertToPem(cert)
+	c.generateFiles(filenameBase)
+	return true, nil
+}
+
+// validateCA validates that the first certificate of the PEM chain is a CA valid at now, that can sign
+// certificates with the private key, and that each certificate of the chain is signed by the next one.
+func validateCA(chainPEM []byte, keyPEM []byte, now time.Time) (*x509.Certificate, crypto.Signer, error) {
+	var chain []*x509.Certificate
+	for rest := chainPEM; ; {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			return nil, nil, errors.Errorf("unexpected %s PEM block in the certificate", block.Type)
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "failed to parse the certificate")
+		}
+		chain = append(chain, cert)
+	}
+	if len(chain) == 0 {
+		return nil, nil, errors.New("could not find a PEM block in the certificate")
+	}
+
+	key, err := PemToPrivateKey(keyPEM)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to parse the private key")
+	}
+	if _, err := KeyAlgorithmOf(key); err != nil {
+		return nil, nil, err
+	}
+
+	ca := chain[0]
+	if !ca.BasicConstraintsValid || !ca.IsCA {
+		return nil, nil, errors.New("the certificate is not a CA")
+	}
+	if ca.KeyUsage&x509.KeyUsageCertSign == 0 {
+		return nil, nil, errors.New("the key usages of the certificate do not include certificate signing")
+	}
+	if caKey, ok := ca.PublicKey.(interface{ Equal(crypto.PublicKey) bool }); !ok || !caKey.Equal(key.Public()) {
+		return nil, nil, errors.New("the private key does not match the certificate")
+	}
+
+	for i, cert := range chain {
+		if now.Before(cert.NotBefore) {
+			return nil, nil, errors.Errorf("the certificate %q is not valid before %s", cert.Subject.CommonName, cert.NotBefore.UTC())
+		}
+		if now.After(cert.NotAfter) {
+			return nil, nil, errors.Errorf("the certificate %q expired on %s", cert.Subject.CommonName, cert.NotAfter.UTC())
+		}
+		if i+1 < len(chain) {
+			if err := cert.CheckSignatureFrom(chain[i+1]); err != nil {
+				return nil, nil, errors.Wrapf(err, "the certificate %q is not signed by %q", cert.Subject.CommonName, chain[i+1].Subject.CommonName)
+			}
+		}
+	}
+	if ca.NotAfter.Before(now.Add(ValidityOneYear)) {
+		logrus.Warnf("The CA %q expires on %s, in less than a year", ca.Subject.CommonName, ca.NotAfter.UTC())
+	}
+
+	return ca, key, nil
+}
@@ -0,0 +1,297 @@
+package tls
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/mock"
+)
+
+// userCA is a CA issued by a corporate root CA.
+type userCA struct {
+	root    []byte
+	rootKey []byte
+	ca      []byte
+	caKey   []byte
+	leaf    []byte
+	leafKey []byte
+}
+
+func newUserCA(t *testing.T) *userCA {
+	caUsages := x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign
+	rootKey, root, err := GenerateSelfSignedCertificate(&CertCfg{
+		Subject:   pkix.Name{CommonName: "corporate-root", OrganizationalUnit: []string{"corporate"}},
+		KeyUsages: caUsages,
+		Validity:  ValidityTenYears,
+		IsCA:      true,
+	})
+	require.NoError(t, err)
+	caKey, ca, err := GenerateSignedCertificate(rootKey, root, &CertCfg{
+		Subject:   pkix.Name{CommonName: "corporate-intermediate", OrganizationalUnit: []string{"corporate"}},
+		KeyUsages: caUsages,
+		Validity:  ValidityTenYears,
+		IsCA:      true,
+	})
+	require.NoError(t, err)
+	leafKey, leaf, err := GenerateSignedCertificate(rootKey, root, &CertCfg{
+		Subject:   pkix.Name{CommonName: "leaf", OrganizationalUnit: []string{"corporate"}},
+		KeyUsages: x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature,
+		Validity:  ValidityTenYears,
+	})
+	require.NoError(t, err)
+	return &userCA{
+		root:    CertToPem(root),
+		rootKey: mustPrivateKeyToPem(t, rootKey),
+		ca:      CertToPem(ca),
+		caKey:   mustPrivateKeyToPem(t, caKey),
+		leaf:    CertToPem(leaf),
+		leafKey: mustPrivateKeyToPem(t, leafKey),
+	}
+}
+
+func mustPrivateKeyToPem(t *testing.T, key crypto.Signer) []byte {
+	data, err := PrivateKeyToPem(key)
+	require.NoError(t, err)
+	return data
+}
+
+func TestValidateCA(t *testing.T) {
+	u := newUserCA(t)
+	other := newUserCA(t)
+
+	cases := []struct {
+		name          string
+		cert          []byte
+		key           []byte
+		now           time.Time
+		expectedError string
+	}{
+		{
+			name: "self-signed CA",
+			cert: u.root,
+			key:  u.rootKey,
+		},
+		{
+			name: "CA with chain",
+			cert: bytes.Join([][]byte{u.ca, u.root}, nil),
+			key:  u.caKey,
+		},
+		{
+			name:          "no certificate",
+			key:           u.caKey,
+			expectedError: "could not find a PEM block in the certificate",
+		},
+		{
+			name:          "key instead of certificate",
+			cert:          u.caKey,
+			key:           u.caKey,
+			expectedError: "unexpected RSA PRIVATE KEY PEM block in the certificate",
+		},
+		{
+			name:          "not a CA",
+			cert:          u.leaf,
+			key:           u.leafKey,
+			expectedError: "the certificate is not a CA",
+		},
+		{
+			name:          "mismatched key",
+			cert:          u.ca,
+			key:           u.rootKey,
+			expectedError: "the private key does not match the certificate",
+		},
+		{
+			name:          "broken chain",
+			cert:          bytes.Join([][]byte{u.ca, other.root}, nil),
+			key:           u.caKey,
+			expectedError: `the certificate "corporate-intermediate" is not signed by "corporate-root": crypto/rsa: verification error`,
+		},
+		{
+			name:          "expired",
+			cert:          bytes.Join([][]byte{u.ca, u.root}, nil),
+			key:           u.caKey,
+			now:           time.Now().Add(2 * ValidityTenYears),
+			expectedError: `the certificate "corporate-intermediate" expired on`,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			now := tc.now
+			if now.IsZero() {
+				now = time.Now()
+			}
+			cert, key, err := validateCA(tc.cert, tc.key, now)
+			if tc.expectedError != "" {
+				if assert.Error(t, err) {
+					assert.Contains(t, err.Error(), tc.expectedError)
+				}
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, cert.Raw, mustPemToCertificate(t, tc.cert).Raw)
+			assert.Equal(t, key.Public(), cert.PublicKey)
+		})
+	}
+}
+
+func mustPemToCertificate(t *testing.T, data []byte) *x509.Certificate {
+	cert, err := PemToCertificate(data)
+	require.NoError(t, err)
+	return cert
+}
+
+func TestSelfSignedCertKeyLoad(t *testing.T) {
+	u := newUserCA(t)
+
+	cases := []struct {
+		name          string
+		files         map[string][]byte
+		expectedFound bool
+		expectedError string
+	}{
+		{
+			name: "not provided",
+		},
+		{
+			name: "provided",
+			files: map[string][]byte{
+				"tls/root-ca.crt": bytes.Join([][]byte{u.ca, u.root}, nil),
+				"tls/root-ca.key": u.caKey,
+			},
+			expectedFound: true,
+		},
+		{
+			name: "key without certificate",
+			files: map[string][]byte{
+				"tls/root-ca.key": u.caKey,
+			},
+			expectedError: "tls/root-ca.key is provided without tls/root-ca.crt, create the certificate signing request of the CA with the signer-csrs target and write the signed certificate to tls/root-ca.crt",
+		},
+		{
+			name: "invalid",
+			files: map[string][]byte{
+				"tls/root-ca.crt": u.leaf,
+				"tls/root-ca.key": u.leafKey,
+			},
+			expectedError: "invalid CA in tls/root-ca.crt: the certificate is not a CA",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			fileFetcher := mock.NewMockFileFetcher(mockCtrl)
+			fileFetcher.EXPECT().FetchByName(gomock.Any()).DoAndReturn(func(name string) (*asset.File, error) {
+				data, ok := tc.files[name]
+				if !ok {
+					return nil, os.ErrNotExist
+				}
+				return &asset.File{Filename: name, Data: data}, nil
+			}).AnyTimes()
+
+			rootCA := &RootCA{}
+			found, err := rootCA.Load(fileFetcher)
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedFound, found)
+			if found {
+				// only the CA is trusted, not its issuers
+				assert.Equal(t, u.ca, rootCA.Cert())
+				assert.Equal(t, u.caKey, rootCA.Key())
+				assert.Len(t, rootCA.Files(), 2)
+			}
+		})
+	}
+}
+
+func TestSignerCSRsLoad(t *testing.T) {
+	u := newUserCA(t)
+
+	cases := []struct {
+		name          string
+		files         map[string][]byte
+		expectedFiles []string
+		expectedError string
+	}{
+		{
+			name: "no key",
+		},
+		{
+			name: "key with certificate",
+			files: map[string][]byte{
+				"tls/root-ca.crt": u.ca,
+				"tls/root-ca.key": u.caKey,
+			},
+		},
+		{
+			name: "keys without certificates",
+			files: map[string][]byte{
+				"tls/root-ca.key":        u.caKey,
+				"tls/kubelet-signer.key": u.leafKey,
+				"tls/aggregator-ca.crt":  u.ca,
+				"tls/aggregator-ca.key":  u.caKey,
+			},
+			expectedFiles: []string{"tls/kubelet-signer.csr", "tls/root-ca.csr"},
+		},
+		{
+			name: "invalid key",
+			files: map[string][]byte{
+				"tls/root-ca.key": u.ca,
+			},
+			expectedError: "failed to create a certificate signing request for tls/root-ca.key: failed to parse the private key",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			fileFetcher := mock.NewMockFileFetcher(mockCtrl)
+			fileFetcher.EXPECT().FetchByName(gomock.Any()).DoAndReturn(func(name string) (*asset.File, error) {
+				data, ok := tc.files[name]
+				if !ok {
+					return nil, os.ErrNotExist
+				}
+				return &asset.File{Filename: name, Data: data}, nil
+			}).AnyTimes()
+
+			csrs := &SignerCSRs{}
+			found, err := csrs.Load(fileFetcher)
+			if tc.expectedError != "" {
+				if assert.Error(t, err) {
+					assert.Contains(t, err.Error(), tc.expectedError)
+				}
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, len(tc.expectedFiles) > 0, found)
+			var filenames []string
+			for _, f := range csrs.Files() {
+				filenames = append(filenames, f.Filename)
+				block, _ := pem.Decode(f.Data)
+				require.NotNil(t, block)
+				csr, err := x509.ParseCertificateRequest(block.Bytes)
+				require.NoError(t, err)
+				assert.NoError(t, csr.CheckSignature())
+				assert.Equal(t, signerSubjects[strings.TrimSuffix(filepath.Base(f.Filename), ".csr")].CommonName, csr.Subject.CommonName)
+			}
+			assert.Equal(t, tc.expectedFiles, filenames)
+		})
+	}
+}
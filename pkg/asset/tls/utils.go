@@ -1,6 +1,8 @@
 package tls
 
 import (
+	"crypto"
+	"crypto/ecdsa"
 	"crypto/rsa"
 	"crypto/x509"
 	"encoding/pem"
@@ -9,16 +11,22 @@ import (
 	"github.com/sirupsen/logrus"
 )
 
-// PrivateKeyToPem converts an rsa.PrivateKey object to pem string
-func PrivateKeyToPem(key *rsa.PrivateKey) []byte {
-	keyInBytes := x509.MarshalPKCS1PrivateKey(key)
-	keyinPem := pem.EncodeToMemory(
-		&pem.Block{
-			Type:  "RSA PRIVATE KEY",
-			Bytes: keyInBytes,
-		},
-	)
-	return keyinPem
+// PrivateKeyToPem converts an rsa.PrivateKey or ecdsa.PrivateKey object to pem string
+func PrivateKeyToPem(key crypto.Signer) ([]byte, error) {
+	var block *pem.Block
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		block = &pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(k)}
+	case *ecdsa.PrivateKey:
+		keyInBytes, err := x509.MarshalECPrivateKey(k)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to MarshalECPrivateKey")
+		}
+		block = &pem.Block{Type: "EC PRIVATE KEY", Bytes: keyInBytes}
+	default:
+		return nil, errors.Errorf("unsupported private key type %T", key)
+	}
+	return pem.EncodeToMemory(block), nil
 }
 
 // CertToPem converts an x509.Certificate object to a pem string
@@ -43,33 +51,58 @@ func CSRToPem(cert *x509.CertificateRequest) []byte {
 	return certInPem
 }
 
-// PublicKeyToPem converts an rsa.PublicKey object to pem string
-func PublicKeyToPem(key *rsa.PublicKey) ([]byte, error) {
+// PublicKeyToPem converts an rsa.PublicKey or ecdsa.PublicKey object to pem string
+func PublicKeyToPem(key crypto.PublicKey) ([]byte, error) {
 	keyInBytes, err := x509.MarshalPKIXPublicKey(key)
-	logrus.Debugf("Failed to marshal PKIX public key: %s", err)
 	if err != nil {
+		logrus.Debugf("Failed to marshal PKIX public key: %s", err)
 		return nil, errors.Wrap(err, "failed to MarshalPKIXPublicKey")
 	}
+	blockType := "PUBLIC KEY"
+	if _, ok := key.(*rsa.PublicKey); ok {
+		blockType = "RSA PUBLIC KEY"
+	}
 	keyinPem := pem.EncodeToMemory(
 		&pem.Block{
-			Type:  "RSA PUBLIC KEY",
+			Type:  blockType,
 			Bytes: keyInBytes,
 		},
 	)
 	return keyinPem, nil
 }
 
-// PemToPrivateKey converts a data block to rsa.PrivateKey.
-func PemToPrivateKey(data []byte) (*rsa.PrivateKey, error) {
+// PemToPrivateKey converts a PKCS#1, SEC 1 or PKCS#8 data block to an rsa.PrivateKey or
+// ecdsa.PrivateKey.
+func PemToPrivateKey(data []byte) (crypto.Signer, error) {
 	block, _ := pem.Decode(data)
 	if block == nil {
 		return nil, errors.Errorf("could not find a PEM block in the private key")
 	}
-	return x509.ParsePKCS1PrivateKey(block.Bytes)
+	var key interface{}
+	var err error
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	default:
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	}
+	if err != nil {
+		return nil, err
+	}
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		return k, nil
+	case *ecdsa.PrivateKey:
+		return k, nil
+	default:
+		return nil, errors.Errorf("invalid private key format, expected RSA or ECDSA")
+	}
 }
 
-// PemToPublicKey converts a data block to rsa.PublicKey.
-func PemToPublicKey(data []byte) (*rsa.PublicKey, error) {
+// PemToPublicKey converts a data block to rsa.PublicKey or ecdsa.PublicKey.
+func PemToPublicKey(data []byte) (crypto.PublicKey, error) {
 	block, _ := pem.Decode(data)
 	if block == nil {
 		return nil, errors.Errorf("could not find a PEM block in the public key")
@@ -78,11 +111,12 @@ func PemToPublicKey(data []byte) (*rsa.PublicKey, error) {
 	if err != nil {
 		return nil, err
 	}
-	publicKey, ok := obji.(*rsa.PublicKey)
-	if !ok {
-		return nil, errors.Errorf("invalid public key format, expected RSA")
+	switch obji.(type) {
+	case *rsa.PublicKey, *ecdsa.PublicKey:
+		return obji, nil
+	default:
+		return nil, errors.Errorf("invalid public key format, expected RSA or ECDSA")
 	}
-	return publicKey, nil
 }
 
 // PemToCertificate converts a data block to x509.Certificate.
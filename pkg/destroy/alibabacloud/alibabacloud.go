@@ -10,6 +10,7 @@ import (
 	"github.com/aliyun/alibaba-cloud-sdk-go/sdk"
 	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/auth"
 	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/auth/credentials"
+	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/requests"
 	"github.com/aliyun/alibaba-cloud-sdk-go/services/alidns"
 	"github.com/aliyun/alibaba-cloud-sdk-go/services/ecs"
 	"github.com/aliyun/alibaba-cloud-sdk-go/services/pvtz"
@@ -25,6 +26,7 @@ import (
 
 	icalibabacloud "github.com/openshift/installer/pkg/asset/installconfig/alibabacloud"
 	"github.com/openshift/installer/pkg/destroy/providers"
+	"github.com/openshift/installer/pkg/destroy/quota"
 	"github.com/openshift/installer/pkg/types"
 )
 
@@ -59,6 +61,9 @@ type ClusterUninstaller struct {
 	slbClient      *slb.Client
 	ossClient      *oss.Client
 	rmanagerClient *resourcemanager.Client
+
+	// footprint records the quota released by the deleted resources.
+	footprint *quota.Footprint
 }
 
 // ResourceArn holds the information contained in the cloud resource Arn string
@@ -152,6 +157,7 @@ func New(logger logrus.FieldLogger, metadata *types.ClusterMetadata) (providers.
 				"ack.aliyun.com": metadata.InfraID,
 			},
 		},
+		footprint: quota.NewFootprint(),
 	}, nil
 }
 
@@ -174,7 +180,7 @@ func (o *ClusterUninstaller) Run() (*types.ClusterQuota, error) {
 		return nil, errors.Wrap(err, "failed to destroy cluster")
 	}
 
-	return nil, nil
+	return &types.ClusterQuota{AlibabaCloud: o.footprint.Quota()}, nil
 }
 
 func (o *ClusterUninstaller) destroyCluster() error {
@@ -515,6 +521,11 @@ func (o *ClusterUninstaller) deleteSlbs(logger logrus.FieldLogger) (err error) {
 			return false, nil
 		},
 	)
+	if err == nil {
+		for _, slbID := range slbIDs {
+			o.footprint.Record(slbID, quota.LoadBalancer())
+		}
+	}
 
 	logger.Info("SLB instances deleted")
 	return
@@ -716,6 +727,11 @@ func (o *ClusterUninstaller) deleteEips(logger logrus.FieldLogger) (err error) {
 			return false, nil
 		},
 	)
+	if err == nil {
+		for _, eipID := range eipIDs {
+			o.footprint.Record(eipID, quota.PublicIP())
+		}
+	}
 	logger.Info("EIPs deleted")
 	return err
 }
@@ -977,6 +993,8 @@ func (o *ClusterUninstaller) deleteEcsInstances(logger logrus.FieldLogger) (err
 		return err
 	}
 
+	usage := o.ecsInstancesQuota(instanceIDs, logger)
+
 	logger.WithField("ecsIDs", instanceIDs).Debug("Deleting ECS instances")
 	request := ecs.CreateDeleteInstancesRequest()
 	request.InstanceId = &instanceIDs
@@ -1000,11 +1018,54 @@ func (o *ClusterUninstaller) deleteEcsInstances(logger logrus.FieldLogger) (err
 			return false, nil
 		},
 	)
+	if err == nil {
+		for id, instanceUsage := range usage {
+			o.footprint.Record(id, instanceUsage)
+		}
+	}
 
 	logger.Info("ECS instances deleted")
 	return
 }
 
+// ecsInstancesQuota returns the usage of the ECS instances, and of the disks
+// that are released along with them, by instance or disk ID. The footprint is
+// best-effort, so resources that cannot be described are left out.
+func (o *ClusterUninstaller) ecsInstancesQuota(instanceIDs []string, logger logrus.FieldLogger) map[string]types.PlatformQuota {
+	usage := map[string]types.PlatformQuota{}
+
+	request := ecs.CreateDescribeInstancesRequest()
+	instanceIDsString, err := json.Marshal(instanceIDs)
+	if err != nil {
+		return usage
+	}
+	request.InstanceIds = string(instanceIDsString)
+	request.PageSize = requests.NewInteger(100)
+	response, err := o.ecsClient.DescribeInstances(request)
+	if err != nil {
+		logger.WithError(err).Debug("Could not describe ECS instances to record their quota")
+		return usage
+	}
+
+	for _, instance := range response.Instances.Instance {
+		usage[instance.InstanceId] = quota.Instance(instance.InstanceType, int64(instance.Cpu))
+
+		disksRequest := ecs.CreateDescribeDisksRequest()
+		disksRequest.InstanceId = instance.InstanceId
+		disksRequest.DeleteWithInstance = requests.NewBoolean(true)
+		disksRequest.PageSize = requests.NewInteger(100)
+		disksResponse, err := o.ecsClient.DescribeDisks(disksRequest)
+		if err != nil {
+			logger.WithError(err).WithField("ecsID", instance.InstanceId).Debug("Could not describe disks to record their quota")
+			continue
+		}
+		for _, disk := range disksResponse.Disks.Disk {
+			usage[disk.DiskId] = quota.Volume(int64(disk.Size))
+		}
+	}
+	return usage
+}
+
 func (o *ClusterUninstaller) findResourcesByTag() (tagResources []tag.TagResource, err error) {
 	for _, tags := range o.Tags {
 		resources, err := o.listTagResources(tags)
@@ -29,6 +29,7 @@ import (
 
 	awssession "github.com/openshift/installer/pkg/asset/installconfig/aws"
 	"github.com/openshift/installer/pkg/destroy/providers"
+	"github.com/openshift/installer/pkg/destroy/quota"
 	"github.com/openshift/installer/pkg/types"
 	"github.com/openshift/installer/pkg/version"
 )
@@ -70,6 +71,17 @@ type ClusterUninstaller struct {
 	// new session will be created based on the usual credential
 	// configuration (AWS_PROFILE, AWS_ACCESS_KEY_ID, etc.).
 	Session *session.Session
+
+	// Parallelism is the number of resources deleted at once.  If
+	// zero, defaultParallelism is used.
+	Parallelism int
+
+	// RequestRate is the number of AWS API requests per second sent
+	// to delete resources.  If zero, defaultRequestRate is used.
+	RequestRate float64
+
+	// footprint records the quota released by the deleted resources.
+	footprint *quota.Footprint
 }
 
 // New returns an AWS destroyer from ClusterMetadata.
@@ -110,8 +122,25 @@ func (o *ClusterUninstaller) validate() error {
 
 // Run is the entrypoint to start the uninstall process
 func (o *ClusterUninstaller) Run() (*types.ClusterQuota, error) {
-	_, err := o.RunWithContext(context.Background())
-	return nil, err
+	return o.RunContext(context.Background())
+}
+
+// RunContext runs the uninstall process until the context is done. The
+// resources that could not be destroyed by then are returned in a
+// *providers.LeftoversError.
+func (o *ClusterUninstaller) RunContext(ctx context.Context) (*types.ClusterQuota, error) {
+	leftovers, err := o.RunWithContext(ctx)
+	if err != nil {
+		if ctx.Err() == nil {
+			return nil, err
+		}
+		inventory := providers.Inventory{}
+		for _, arnString := range leftovers {
+			inventory.Add(resourceType(arnString), arnString)
+		}
+		return nil, &providers.LeftoversError{Err: err, Leftovers: inventory}
+	}
+	return &types.ClusterQuota{AWS: o.footprint.Quota()}, nil
 }
 
 // RunWithContext runs the uninstall process with a context.
@@ -122,37 +151,12 @@ func (o *ClusterUninstaller) RunWithContext(ctx context.Context) ([]string, erro
 		return nil, err
 	}
 
-	awsSession := o.Session
-	if awsSession == nil {
-		// Relying on appropriate AWS ENV vars (eg AWS_PROFILE, AWS_ACCESS_KEY_ID, etc)
-		awsSession, err = session.NewSession(aws.NewConfig().WithRegion(o.Region))
-		if err != nil {
-			return nil, err
-		}
-	}
-	awsSession.Handlers.Build.PushBackNamed(request.NamedHandler{
-		Name: "openshiftInstaller.OpenshiftInstallerUserAgentHandler",
-		Fn:   request.MakeAddToUserAgentHandler("OpenShift/4.x Destroyer", version.Raw),
-	})
-
-	tagClients := []*resourcegroupstaggingapi.ResourceGroupsTaggingAPI{
-		resourcegroupstaggingapi.New(awsSession),
-	}
-
-	switch o.Region {
-	case endpoints.CnNorth1RegionID, endpoints.CnNorthwest1RegionID:
-		break
-	case endpoints.UsGovEast1RegionID, endpoints.UsGovWest1RegionID:
-		if o.Region != endpoints.UsGovWest1RegionID {
-			tagClients = append(tagClients,
-				resourcegroupstaggingapi.New(awsSession, aws.NewConfig().WithRegion(endpoints.UsGovWest1RegionID)))
-		}
-	default:
-		if o.Region != endpoints.UsEast1RegionID {
-			tagClients = append(tagClients,
-				resourcegroupstaggingapi.New(awsSession, aws.NewConfig().WithRegion(endpoints.UsEast1RegionID)))
-		}
+	awsSession, err := o.session()
+	if err != nil {
+		return nil, err
 	}
+	tagClients := o.tagClients(awsSession)
+	o.footprint = quota.NewFootprint()
 
 	iamClient := iam.New(awsSession)
 	iamRoleSearch := &iamRoleSearch{
@@ -178,6 +182,13 @@ func (o *ClusterUninstaller) RunWithContext(ctx context.Context) ([]string, erro
 	}
 
 	tracker := new(errorTracker)
+	engine := newDeletionEngine(o.Parallelism, tracker, o.Logger)
+	deleteSession := engine.throttledSession(awsSession, o.RequestRate)
+	engine.delete = func(ctx context.Context, arn arn.ARN, logger logrus.FieldLogger) error {
+		return deleteARN(ctx, deleteSession, arn, o.footprint, logger)
+	}
+	defer engine.logStats()
+	progress := &providers.ProgressReporter{Logger: o.Logger}
 
 	// Terminate EC2 instances. The instances need to be terminated first so that we can ensure that there is nothing
 	// running on the cluster creating new resources while we are attempting to delete resources, which could leak
@@ -202,7 +213,7 @@ func (o *ClusterUninstaller) RunWithContext(ctx context.Context) ([]string, erro
 				instancesToDelete = instancesNotTerminated
 				lastTerminateTime = time.Now()
 			}
-			newlyDeleted, err := o.deleteResources(ctx, awsSession, instancesToDelete, tracker)
+			newlyDeleted, err := engine.deleteResources(ctx, instancesToDelete)
 			// Delete from the resources-to-delete set so that the current state of the resources to delete can be
 			// returned if the context is completed.
 			resourcesToDelete = resourcesToDelete.Difference(newlyDeleted)
@@ -212,6 +223,7 @@ func (o *ClusterUninstaller) RunWithContext(ctx context.Context) ([]string, erro
 					return false, err
 				}
 			}
+			reportProgress(progress, resourcesToDelete, tracker)
 			return false, nil
 		},
 		ctx.Done(),
@@ -224,7 +236,7 @@ func (o *ClusterUninstaller) RunWithContext(ctx context.Context) ([]string, erro
 	err = wait.PollImmediateUntil(
 		time.Second*10,
 		func() (done bool, err error) {
-			newlyDeleted, loopError := o.deleteResources(ctx, awsSession, resourcesToDelete.UnsortedList(), tracker)
+			newlyDeleted, loopError := engine.deleteResources(ctx, resourcesToDelete.UnsortedList())
 			// Delete from the resources-to-delete set so that the current state of the resources to delete can be
 			// returned if the context is completed.
 			resourcesToDelete = resourcesToDelete.Difference(newlyDeleted)
@@ -246,6 +258,7 @@ func (o *ClusterUninstaller) RunWithContext(ctx context.Context) ([]string, erro
 			}
 			resourcesToDelete = nextResourcesToDelete
 			tagClientsWithResources = nextTagClients
+			reportProgress(progress, resourcesToDelete, tracker)
 			return len(resourcesToDelete) == 0 && loopError == nil, nil
 		},
 		ctx.Done(),
@@ -262,6 +275,110 @@ func (o *ClusterUninstaller) RunWithContext(ctx context.Context) ([]string, erro
 	return nil, nil
 }
 
+// Inventory returns the resources that would be deleted, grouped by type,
+// without deleting any of them. Resources whose shared tags would be removed
+// are not included.
+func (o *ClusterUninstaller) Inventory() (providers.Inventory, error) {
+	return o.InventoryWithContext(context.Background())
+}
+
+// InventoryWithContext runs the discovery half of the uninstall process with
+// a context.
+func (o *ClusterUninstaller) InventoryWithContext(ctx context.Context) (providers.Inventory, error) {
+	err := o.validate()
+	if err != nil {
+		return nil, err
+	}
+
+	awsSession, err := o.session()
+	if err != nil {
+		return nil, err
+	}
+
+	iamClient := iam.New(awsSession)
+	iamRoleSearch := &iamRoleSearch{
+		client:  iamClient,
+		filters: o.Filters,
+		logger:  o.Logger,
+	}
+	iamUserSearch := &iamUserSearch{
+		client:  iamClient,
+		filters: o.Filters,
+		logger:  o.Logger,
+	}
+
+	resources, _, err := o.findResourcesToDelete(ctx, o.tagClients(awsSession), iamClient, iamRoleSearch, iamUserSearch, sets.NewString())
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to find resources to delete")
+	}
+
+	inventory := providers.Inventory{}
+	for _, arnString := range resources.List() {
+		inventory.Add(resourceType(arnString), arnString)
+	}
+	return inventory, nil
+}
+
+// session returns the AWS session to be used for deletion. The user agent
+// handler is added to a copy of o.Session, so that repeated calls do not add
+// it to o.Session more than once.
+func (o *ClusterUninstaller) session() (*session.Session, error) {
+	var awsSession *session.Session
+	if o.Session != nil {
+		awsSession = o.Session.Copy()
+	} else {
+		// Relying on appropriate AWS ENV vars (eg AWS_PROFILE, AWS_ACCESS_KEY_ID, etc)
+		var err error
+		awsSession, err = session.NewSession(aws.NewConfig().WithRegion(o.Region))
+		if err != nil {
+			return nil, err
+		}
+	}
+	awsSession.Handlers.Build.PushBackNamed(request.NamedHandler{
+		Name: "openshiftInstaller.OpenshiftInstallerUserAgentHandler",
+		Fn:   request.MakeAddToUserAgentHandler("OpenShift/4.x Destroyer", version.Raw),
+	})
+	return awsSession, nil
+}
+
+// tagClients returns the clients of the tagging API to use to search for
+// resources. Global resources (e.g. Route 53 hosted zones) are tagged in the
+// partition's main region, so a client for that region is included as well.
+func (o *ClusterUninstaller) tagClients(awsSession *session.Session) []*resourcegroupstaggingapi.ResourceGroupsTaggingAPI {
+	tagClients := []*resourcegroupstaggingapi.ResourceGroupsTaggingAPI{
+		resourcegroupstaggingapi.New(awsSession),
+	}
+
+	switch o.Region {
+	case endpoints.CnNorth1RegionID, endpoints.CnNorthwest1RegionID:
+		break
+	case endpoints.UsGovEast1RegionID, endpoints.UsGovWest1RegionID:
+		if o.Region != endpoints.UsGovWest1RegionID {
+			tagClients = append(tagClients,
+				resourcegroupstaggingapi.New(awsSession, aws.NewConfig().WithRegion(endpoints.UsGovWest1RegionID)))
+		}
+	default:
+		if o.Region != endpoints.UsEast1RegionID {
+			tagClients = append(tagClients,
+				resourcegroupstaggingapi.New(awsSession, aws.NewConfig().WithRegion(endpoints.UsEast1RegionID)))
+		}
+	}
+	return tagClients
+}
+
+// resourceType returns the type of the resource with the given ARN, e.g.
+// "ec2:instance" or "s3".
+func resourceType(arnString string) string {
+	parsedARN, err := arn.Parse(arnString)
+	if err != nil {
+		return "unknown"
+	}
+	if i := strings.IndexAny(parsedARN.Resource, "/:"); i > 0 {
+		return fmt.Sprintf("%s:%s", parsedARN.Service, parsedARN.Resource[:i])
+	}
+	return parsedARN.Service
+}
+
 // findEC2Instances returns the EC2 instances with tags that satisfy the filters.
 // returns two lists, first one is the list of all resources that are not terminated and are not in shutdown
 // stage and the second list is the list of resources that are not terminated.
@@ -469,28 +586,18 @@ func (o *ClusterUninstaller) findUntaggableResources(ctx context.Context, iamCli
 	return resources, nil
 }
 
-// deleteResources deletes the specified resources.
-//   resources - the resources to be deleted.
-// The first return is the ARNs of the resources that were successfully deleted
-func (o *ClusterUninstaller) deleteResources(ctx context.Context, awsSession *session.Session, resources []string, tracker *errorTracker) (sets.String, error) {
-	deleted := sets.NewString()
-	for _, arnString := range resources {
-		logger := o.Logger.WithField("arn", arnString)
-		parsedARN, err := arn.Parse(arnString)
-		if err != nil {
-			logger.WithError(err).Debug("could not parse ARN")
-			continue
-		}
-		if err := deleteARN(ctx, awsSession, parsedARN, o.Logger); err != nil {
-			tracker.suppressWarning(arnString, err, logger)
-			if err := ctx.Err(); err != nil {
-				return deleted, err
-			}
-			continue
+// reportProgress reports the remaining resources, and those that are stuck
+// with repeated errors.
+func reportProgress(progress *providers.ProgressReporter, remaining sets.String, tracker *errorTracker) {
+	inventory := providers.Inventory{}
+	stuck := map[string]error{}
+	for _, arnString := range remaining.List() {
+		inventory.Add(resourceType(arnString), arnString)
+		if err := tracker.stuckError(arnString); err != nil {
+			stuck[arnString] = err
 		}
-		deleted.Insert(arnString)
 	}
-	return deleted, nil
+	progress.Report(inventory, stuck)
 }
 
 func splitSlash(name string, input string) (base string, suffix string, err error) {
@@ -719,12 +826,12 @@ func findPublicRoute53(ctx context.Context, client *route53.Route53, dnsName str
 	return "", nil
 }
 
-func deleteARN(ctx context.Context, session *session.Session, arn arn.ARN, logger logrus.FieldLogger) error {
+func deleteARN(ctx context.Context, session *session.Session, arn arn.ARN, footprint *quota.Footprint, logger logrus.FieldLogger) error {
 	switch arn.Service {
 	case "ec2":
-		return deleteEC2(ctx, session, arn, logger)
+		return deleteEC2(ctx, session, arn, footprint, logger)
 	case "elasticloadbalancing":
-		return deleteElasticLoadBalancing(ctx, session, arn, logger)
+		return deleteElasticLoadBalancing(ctx, session, arn, footprint, logger)
 	case "iam":
 		return deleteIAM(ctx, session, arn, logger)
 	case "route53":
@@ -738,7 +845,7 @@ func deleteARN(ctx context.Context, session *session.Session, arn arn.ARN, logge
 	}
 }
 
-func deleteEC2(ctx context.Context, session *session.Session, arn arn.ARN, logger logrus.FieldLogger) error {
+func deleteEC2(ctx context.Context, session *session.Session, arn arn.ARN, footprint *quota.Footprint, logger logrus.FieldLogger) error {
 	client := ec2.New(session)
 
 	resourceType, id, err := splitSlash("resource", arn.Resource)
@@ -751,11 +858,11 @@ func deleteEC2(ctx context.Context, session *session.Session, arn arn.ARN, logge
 	case "dhcp-options":
 		return deleteEC2DHCPOptions(ctx, client, id, logger)
 	case "elastic-ip":
-		return deleteEC2ElasticIP(ctx, client, id, logger)
+		return deleteEC2ElasticIP(ctx, client, id, footprint, logger)
 	case "image":
 		return deleteEC2Image(ctx, client, id, logger)
 	case "instance":
-		return terminateEC2Instance(ctx, client, iam.New(session), id, logger)
+		return terminateEC2Instance(ctx, client, iam.New(session), id, footprint, logger)
 	case "internet-gateway":
 		return deleteEC2InternetGateway(ctx, client, id, logger)
 	case "natgateway":
@@ -771,9 +878,9 @@ func deleteEC2(ctx context.Context, session *session.Session, arn arn.ARN, logge
 	case "subnet":
 		return deleteEC2Subnet(ctx, client, id, logger)
 	case "volume":
-		return deleteEC2Volume(ctx, client, id, logger)
+		return deleteEC2Volume(ctx, client, id, footprint, logger)
 	case "vpc":
-		return deleteEC2VPC(ctx, client, elb.New(session), elbv2.New(session), id, logger)
+		return deleteEC2VPC(ctx, client, elb.New(session), elbv2.New(session), id, footprint, logger)
 	case "vpc-endpoint":
 		return deleteEC2VPCEndpoint(ctx, client, id, logger)
 	case "vpc-peering-connection":
@@ -842,7 +949,7 @@ func deleteEC2Image(ctx context.Context, client *ec2.EC2, id string, logger logr
 	return nil
 }
 
-func deleteEC2ElasticIP(ctx context.Context, client *ec2.EC2, id string, logger logrus.FieldLogger) error {
+func deleteEC2ElasticIP(ctx context.Context, client *ec2.EC2, id string, footprint *quota.Footprint, logger logrus.FieldLogger) error {
 	_, err := client.ReleaseAddressWithContext(ctx, &ec2.ReleaseAddressInput{
 		AllocationId: aws.String(id),
 	})
@@ -853,11 +960,12 @@ func deleteEC2ElasticIP(ctx context.Context, client *ec2.EC2, id string, logger
 		return err
 	}
 
+	footprint.Record(id, quota.PublicIP())
 	logger.Info("Released")
 	return nil
 }
 
-func terminateEC2Instance(ctx context.Context, ec2Client *ec2.EC2, iamClient *iam.IAM, id string, logger logrus.FieldLogger) error {
+func terminateEC2Instance(ctx context.Context, ec2Client *ec2.EC2, iamClient *iam.IAM, id string, footprint *quota.Footprint, logger logrus.FieldLogger) error {
 	response, err := ec2Client.DescribeInstancesWithContext(ctx, &ec2.DescribeInstancesInput{
 		InstanceIds: []*string{aws.String(id)},
 	})
@@ -870,7 +978,7 @@ func terminateEC2Instance(ctx context.Context, ec2Client *ec2.EC2, iamClient *ia
 
 	for _, reservation := range response.Reservations {
 		for _, instance := range reservation.Instances {
-			err = terminateEC2InstanceByInstance(ctx, ec2Client, iamClient, instance, logger)
+			err = terminateEC2InstanceByInstance(ctx, ec2Client, iamClient, instance, footprint, logger)
 			if err != nil {
 				return err
 			}
@@ -879,7 +987,7 @@ func terminateEC2Instance(ctx context.Context, ec2Client *ec2.EC2, iamClient *ia
 	return nil
 }
 
-func terminateEC2InstanceByInstance(ctx context.Context, ec2Client *ec2.EC2, iamClient *iam.IAM, instance *ec2.Instance, logger logrus.FieldLogger) error {
+func terminateEC2InstanceByInstance(ctx context.Context, ec2Client *ec2.EC2, iamClient *iam.IAM, instance *ec2.Instance, footprint *quota.Footprint, logger logrus.FieldLogger) error {
 	// Ignore instances that are already terminated
 	if instance.State == nil || *instance.State.Name == "terminated" {
 		return nil
@@ -897,6 +1005,16 @@ func terminateEC2InstanceByInstance(ctx context.Context, ec2Client *ec2.EC2, iam
 		}
 	}
 
+	// Look up the volumes that are deleted along with the instance before
+	// they start to disappear.
+	var volumeIDs []*string
+	for _, mapping := range instance.BlockDeviceMappings {
+		if mapping.Ebs != nil && aws.BoolValue(mapping.Ebs.DeleteOnTermination) {
+			volumeIDs = append(volumeIDs, mapping.Ebs.VolumeId)
+		}
+	}
+	volumes := ec2VolumeUsage(ctx, ec2Client, volumeIDs, footprint, logger)
+
 	_, err := ec2Client.TerminateInstancesWithContext(ctx, &ec2.TerminateInstancesInput{
 		InstanceIds: []*string{instance.InstanceId},
 	})
@@ -904,10 +1022,38 @@ func terminateEC2InstanceByInstance(ctx context.Context, ec2Client *ec2.EC2, iam
 		return err
 	}
 
+	var vCPUs int64
+	if instance.CpuOptions != nil {
+		vCPUs = aws.Int64Value(instance.CpuOptions.CoreCount) * aws.Int64Value(instance.CpuOptions.ThreadsPerCore)
+	}
+	footprint.Record(*instance.InstanceId, quota.Instance(aws.StringValue(instance.InstanceType), vCPUs))
+	for id, usage := range volumes {
+		footprint.Record(id, usage)
+	}
+
 	logger.Debug("Terminating")
 	return nil
 }
 
+// ec2VolumeUsage returns the usage of the EBS volumes with the given IDs by
+// volume ID. The footprint is best-effort, so volumes that cannot be described
+// are left out. Nothing is looked up if the footprint is not being recorded.
+func ec2VolumeUsage(ctx context.Context, client *ec2.EC2, ids []*string, footprint *quota.Footprint, logger logrus.FieldLogger) map[string]types.PlatformQuota {
+	if footprint == nil || len(ids) == 0 {
+		return nil
+	}
+	response, err := client.DescribeVolumesWithContext(ctx, &ec2.DescribeVolumesInput{VolumeIds: ids})
+	if err != nil {
+		logger.WithError(err).Debug("could not describe volumes to record their quota")
+		return nil
+	}
+	usage := make(map[string]types.PlatformQuota, len(response.Volumes))
+	for _, volume := range response.Volumes {
+		usage[aws.StringValue(volume.VolumeId)] = quota.Volume(aws.Int64Value(volume.Size))
+	}
+	return usage
+}
+
 func deleteEC2InternetGateway(ctx context.Context, client *ec2.EC2, id string, logger logrus.FieldLogger) error {
 	response, err := client.DescribeInternetGatewaysWithContext(ctx, &ec2.DescribeInternetGatewaysInput{
 		InternetGatewayIds: []*string{aws.String(id)},
@@ -1302,7 +1448,8 @@ func deleteEC2SubnetsByVPC(ctx context.Context, client *ec2.EC2, vpc string, fai
 	return lastError
 }
 
-func deleteEC2Volume(ctx context.Context, client *ec2.EC2, id string, logger logrus.FieldLogger) error {
+func deleteEC2Volume(ctx context.Context, client *ec2.EC2, id string, footprint *quota.Footprint, logger logrus.FieldLogger) error {
+	volumes := ec2VolumeUsage(ctx, client, []*string{aws.String(id)}, footprint, logger)
 	_, err := client.DeleteVolumeWithContext(ctx, &ec2.DeleteVolumeInput{
 		VolumeId: aws.String(id),
 	})
@@ -1313,14 +1460,17 @@ func deleteEC2Volume(ctx context.Context, client *ec2.EC2, id string, logger log
 		return err
 	}
 
+	if usage, ok := volumes[id]; ok {
+		footprint.Record(id, usage)
+	}
 	logger.Info("Deleted")
 	return nil
 }
 
-func deleteEC2VPC(ctx context.Context, ec2Client *ec2.EC2, elbClient *elb.ELB, elbv2Client *elbv2.ELBV2, id string, logger logrus.FieldLogger) error {
+func deleteEC2VPC(ctx context.Context, ec2Client *ec2.EC2, elbClient *elb.ELB, elbv2Client *elbv2.ELBV2, id string, footprint *quota.Footprint, logger logrus.FieldLogger) error {
 	// first delete any Load Balancers under this VPC (not all of them are tagged)
-	v1lbError := deleteElasticLoadBalancerClassicByVPC(ctx, elbClient, id, logger)
-	v2lbError := deleteElasticLoadBalancerV2ByVPC(ctx, elbv2Client, id, logger)
+	v1lbError := deleteElasticLoadBalancerClassicByVPC(ctx, elbClient, id, footprint, logger)
+	v2lbError := deleteElasticLoadBalancerV2ByVPC(ctx, elbv2Client, id, footprint, logger)
 	if v1lbError != nil {
 		if v2lbError != nil {
 			logger.Info(v2lbError)
@@ -1411,7 +1561,7 @@ func deleteEC2VPCPeeringConnection(ctx context.Context, client *ec2.EC2, id stri
 	return nil
 }
 
-func deleteElasticLoadBalancing(ctx context.Context, session *session.Session, arn arn.ARN, logger logrus.FieldLogger) error {
+func deleteElasticLoadBalancing(ctx context.Context, session *session.Session, arn arn.ARN, footprint *quota.Footprint, logger logrus.FieldLogger) error {
 	resourceType, id, err := splitSlash("resource", arn.Resource)
 	if err != nil {
 		return err
@@ -1422,7 +1572,7 @@ func deleteElasticLoadBalancing(ctx context.Context, session *session.Session, a
 	case "loadbalancer":
 		segments := strings.SplitN(id, "/", 2)
 		if len(segments) == 1 {
-			return deleteElasticLoadBalancerClassic(ctx, elb.New(session), id, logger)
+			return deleteElasticLoadBalancerClassic(ctx, elb.New(session), id, footprint, logger)
 		} else if len(segments) != 2 {
 			return errors.Errorf("cannot parse subresource %q into {subtype}/{id}", id)
 		}
@@ -1430,7 +1580,7 @@ func deleteElasticLoadBalancing(ctx context.Context, session *session.Session, a
 		id = segments[1]
 		switch subtype {
 		case "net":
-			return deleteElasticLoadBalancerV2(ctx, elbv2.New(session), arn, logger)
+			return deleteElasticLoadBalancerV2(ctx, elbv2.New(session), arn, footprint, logger)
 		default:
 			return errors.Errorf("unrecognized elastic load balancing resource subtype %s", subtype)
 		}
@@ -1441,7 +1591,7 @@ func deleteElasticLoadBalancing(ctx context.Context, session *session.Session, a
 	}
 }
 
-func deleteElasticLoadBalancerClassic(ctx context.Context, client *elb.ELB, name string, logger logrus.FieldLogger) error {
+func deleteElasticLoadBalancerClassic(ctx context.Context, client *elb.ELB, name string, footprint *quota.Footprint, logger logrus.FieldLogger) error {
 	_, err := client.DeleteLoadBalancerWithContext(ctx, &elb.DeleteLoadBalancerInput{
 		LoadBalancerName: aws.String(name),
 	})
@@ -1449,11 +1599,12 @@ func deleteElasticLoadBalancerClassic(ctx context.Context, client *elb.ELB, name
 		return err
 	}
 
+	footprint.Record(name, quota.LoadBalancer())
 	logger.Info("Deleted")
 	return nil
 }
 
-func deleteElasticLoadBalancerClassicByVPC(ctx context.Context, client *elb.ELB, vpc string, logger logrus.FieldLogger) error {
+func deleteElasticLoadBalancerClassicByVPC(ctx context.Context, client *elb.ELB, vpc string, footprint *quota.Footprint, logger logrus.FieldLogger) error {
 	var lastError error
 	err := client.DescribeLoadBalancersPagesWithContext(
 		ctx,
@@ -1471,7 +1622,7 @@ func deleteElasticLoadBalancerClassicByVPC(ctx context.Context, client *elb.ELB,
 					continue
 				}
 
-				err := deleteElasticLoadBalancerClassic(ctx, client, *lb.LoadBalancerName, lbLogger)
+				err := deleteElasticLoadBalancerClassic(ctx, client, *lb.LoadBalancerName, footprint, lbLogger)
 				if err != nil {
 					if lastError != nil {
 						logger.Debug(lastError)
@@ -1502,7 +1653,7 @@ func deleteElasticLoadBalancerTargetGroup(ctx context.Context, client *elbv2.ELB
 	return nil
 }
 
-func deleteElasticLoadBalancerV2(ctx context.Context, client *elbv2.ELBV2, arn arn.ARN, logger logrus.FieldLogger) error {
+func deleteElasticLoadBalancerV2(ctx context.Context, client *elbv2.ELBV2, arn arn.ARN, footprint *quota.Footprint, logger logrus.FieldLogger) error {
 	_, err := client.DeleteLoadBalancerWithContext(ctx, &elbv2.DeleteLoadBalancerInput{
 		LoadBalancerArn: aws.String(arn.String()),
 	})
@@ -1510,11 +1661,12 @@ func deleteElasticLoadBalancerV2(ctx context.Context, client *elbv2.ELBV2, arn a
 		return err
 	}
 
+	footprint.Record(arn.String(), quota.LoadBalancer())
 	logger.Info("Deleted")
 	return nil
 }
 
-func deleteElasticLoadBalancerV2ByVPC(ctx context.Context, client *elbv2.ELBV2, vpc string, logger logrus.FieldLogger) error {
+func deleteElasticLoadBalancerV2ByVPC(ctx context.Context, client *elbv2.ELBV2, vpc string, footprint *quota.Footprint, logger logrus.FieldLogger) error {
 	var lastError error
 	err := client.DescribeLoadBalancersPagesWithContext(
 		ctx,
@@ -1539,7 +1691,7 @@ func deleteElasticLoadBalancerV2ByVPC(ctx context.Context, client *elbv2.ELBV2,
 					continue
 				}
 
-				err = deleteElasticLoadBalancerV2(ctx, client, parsed, logger.WithField("load balancer", parsed.Resource))
+				err = deleteElasticLoadBalancerV2(ctx, client, parsed, footprint, logger.WithField("load balancer", parsed.Resource))
 				if err != nil {
 					if lastError != nil {
 						logger.Debug(lastError)
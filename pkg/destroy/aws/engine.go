@@ -0,0 +1,285 @@
+package aws
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/time/rate"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+const (
+	// defaultParallelism is the number of resources deleted at once when
+	// ClusterUninstaller.Parallelism is not set.
+	defaultParallelism = 10
+
+	// defaultRequestRate is the number of AWS API requests sent per second
+	// when ClusterUninstaller.RequestRate is not set.
+	defaultRequestRate = 20
+
+	// retryBaseDelay and retryMaxDelay bound the exponential delay before a
+	// resource whose deletion failed is tried again.
+	retryBaseDelay = 5 * time.Second
+	retryMaxDelay  = 2 * time.Minute
+)
+
+// deletionTiers orders the deletion of resource types that depend on each
+// other: instances hold network interfaces, which along with load balancers,
+// NAT gateways and VPC endpoints hold the subnets and security groups, which
+// hold the VPC. A tier is only started once every resource of the previous
+// tiers was tried. Types that are not listed are in defaultDeletionTier.
+var deletionTiers = map[string]int{
+	"ec2:instance":          0,
+	"ec2:elastic-ip":        2,
+	"ec2:network-interface": 2,
+	"ec2:internet-gateway":  3,
+	"ec2:route-table":       3,
+	"ec2:security-group":    3,
+	"ec2:subnet":            3,
+	"ec2:vpc":               4,
+	"ec2:dhcp-options":      5,
+}
+
+const defaultDeletionTier = 1
+
+// resourceDeleter deletes a single resource.
+type resourceDeleter func(ctx context.Context, arn arn.ARN, logger logrus.FieldLogger) error
+
+// deletionStats are the deletion attempts for the resources of a type.
+type deletionStats struct {
+	// Attempts is the number of deletion attempts.
+	Attempts int
+	// Deleted is the number of resources that were deleted.
+	Deleted int
+	// Retries is the number of attempts after a failed attempt.
+	Retries int
+	// Throttled is the number of API requests that were throttled.
+	Throttled int
+	// Elapsed is the time spent in deletion attempts.
+	Elapsed time.Duration
+}
+
+// retryState is the backoff of a resource whose deletion failed.
+type retryState struct {
+	failures int
+	next     time.Time
+}
+
+// resourceTypeKey is the context key of the type of the resource that an AWS
+// API request is sent for.
+type resourceTypeKey struct{}
+
+// deletionEngine deletes resources with a pool of workers, in the order of
+// deletionTiers, backing off the resources whose deletion fails.
+type deletionEngine struct {
+	parallelism int
+	delete      resourceDeleter
+	logger      logrus.FieldLogger
+	tracker     *errorTracker
+	now         func() time.Time
+
+	mu      sync.Mutex
+	retries map[string]*retryState
+	stats   map[string]*deletionStats
+}
+
+// newDeletionEngine returns an engine running parallelism workers, or
+// defaultParallelism if it is not positive. Its delete function must be set
+// before deleting resources.
+func newDeletionEngine(parallelism int, tracker *errorTracker, logger logrus.FieldLogger) *deletionEngine {
+	if parallelism <= 0 {
+		parallelism = defaultParallelism
+	}
+	return &deletionEngine{
+		parallelism: parallelism,
+		logger:      logger,
+		tracker:     tracker,
+		now:         time.Now,
+		retries:     map[string]*retryState{},
+		stats:       map[string]*deletionStats{},
+	}
+}
+
+// throttledSession returns a copy of the session whose API requests, retries
+// included, share a token bucket of requestRate requests per second. The
+// throttled requests are counted in the stats of the engine.
+func (e *deletionEngine) throttledSession(awsSession *session.Session, requestRate float64) *session.Session {
+	if requestRate <= 0 {
+		requestRate = defaultRequestRate
+	}
+	// A burst below one request would fail every request in Wait.
+	burst := int(requestRate)
+	if burst < 1 {
+		burst = 1
+	}
+	limiter := rate.NewLimiter(rate.Limit(requestRate), burst)
+
+	throttled := awsSession.Copy()
+	throttled.Handlers.Sign.PushFront(func(r *request.Request) {
+		if err := limiter.Wait(r.Context()); err != nil {
+			r.Error = err
+		}
+	})
+	throttled.Handlers.Retry.PushBack(func(r *request.Request) {
+		if !request.IsErrorThrottle(r.Error) {
+			return
+		}
+		if resourceType, ok := r.Context().Value(resourceTypeKey{}).(string); ok {
+			e.mu.Lock()
+			e.statsFor(resourceType).Throttled++
+			e.mu.Unlock()
+		}
+	})
+	return throttled
+}
+
+// deleteResources tries to delete the resources that are not backing off,
+// one tier after the other.
+// The first return is the ARNs of the resources that were successfully deleted.
+func (e *deletionEngine) deleteResources(ctx context.Context, resources []string) (sets.String, error) {
+	tiers := map[int][]arn.ARN{}
+	now := e.now()
+	e.mu.Lock()
+	for _, arnString := range resources {
+		parsedARN, err := arn.Parse(arnString)
+		if err != nil {
+			e.logger.WithField("arn", arnString).WithError(err).Debug("could not parse ARN")
+			continue
+		}
+		if retry, ok := e.retries[arnString]; ok && now.Before(retry.next) {
+			continue
+		}
+		tier, ok := deletionTiers[resourceType(arnString)]
+		if !ok {
+			tier = defaultDeletionTier
+		}
+		tiers[tier] = append(tiers[tier], parsedARN)
+	}
+	e.mu.Unlock()
+
+	order := make([]int, 0, len(tiers))
+	for tier := range tiers {
+		order = append(order, tier)
+	}
+	sort.Ints(order)
+
+	deleted := sets.NewString()
+	for _, tier := range order {
+		e.deleteTier(ctx, tiers[tier], deleted)
+		if err := ctx.Err(); err != nil {
+			return deleted, err
+		}
+	}
+	return deleted, nil
+}
+
+// deleteTier deletes the resources concurrently, adding those that were
+// deleted to the deleted set.
+func (e *deletionEngine) deleteTier(ctx context.Context, resources []arn.ARN, deleted sets.String) {
+	queue := make(chan arn.ARN)
+	var wg sync.WaitGroup
+	for i := 0; i < e.parallelism && i < len(resources); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for parsedARN := range queue {
+				if e.deleteOne(ctx, parsedARN) {
+					e.mu.Lock()
+					deleted.Insert(parsedARN.String())
+					e.mu.Unlock()
+				}
+			}
+		}()
+	}
+
+	for _, parsedARN := range resources {
+		if ctx.Err() != nil {
+			break
+		}
+		queue <- parsedARN
+	}
+	close(queue)
+	wg.Wait()
+}
+
+// deleteOne tries to delete the resource, and returns whether it was deleted.
+func (e *deletionEngine) deleteOne(ctx context.Context, parsedARN arn.ARN) bool {
+	arnString := parsedARN.String()
+	resourceType := resourceType(arnString)
+	logger := e.logger.WithField("arn", arnString)
+
+	start := e.now()
+	err := e.delete(context.WithValue(ctx, resourceTypeKey{}, resourceType), parsedARN, e.logger)
+	elapsed := e.now().Sub(start)
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	stats := e.statsFor(resourceType)
+	stats.Attempts++
+	stats.Elapsed += elapsed
+	retry, retrying := e.retries[arnString]
+	if retrying {
+		stats.Retries++
+	}
+
+	if err == nil {
+		stats.Deleted++
+		delete(e.retries, arnString)
+		return true
+	}
+
+	e.tracker.suppressWarning(arnString, err, logger)
+	if !retrying {
+		retry = &retryState{}
+		e.retries[arnString] = retry
+	}
+	retry.failures++
+	retry.next = e.now().Add(retryDelay(retry.failures))
+	return false
+}
+
+// statsFor returns the stats of the resource type. The caller must hold e.mu.
+func (e *deletionEngine) statsFor(resourceType string) *deletionStats {
+	stats, ok := e.stats[resourceType]
+	if !ok {
+		stats = &deletionStats{}
+		e.stats[resourceType] = stats
+	}
+	return stats
+}
+
+// logStats logs the deletion stats of every resource type.
+func (e *deletionEngine) logStats() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	resourceTypes := make([]string, 0, len(e.stats))
+	for resourceType := range e.stats {
+		resourceTypes = append(resourceTypes, resourceType)
+	}
+	sort.Strings(resourceTypes)
+	for _, resourceType := range resourceTypes {
+		stats := e.stats[resourceType]
+		e.logger.Infof("%s: %d deleted in %d attempts (%d retries, %d throttled requests) taking %s",
+			resourceType, stats.Deleted, stats.Attempts, stats.Retries, stats.Throttled, stats.Elapsed.Round(time.Millisecond))
+	}
+}
+
+// retryDelay returns the delay before the next attempt to delete a resource
+// whose deletion failed the given number of times.
+func retryDelay(failures int) time.Duration {
+	delay := retryBaseDelay
+	for i := 1; i < failures && delay < retryMaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > retryMaxDelay {
+		delay = retryMaxDelay
+	}
+	return delay
+}
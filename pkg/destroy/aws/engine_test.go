@@ -0,0 +1,175 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeletionEngineOrder(t *testing.T) {
+	resources := []string{
+		"arn:aws:ec2:us-east-1:123456789012:vpc/vpc-1",
+		"arn:aws:ec2:us-east-1:123456789012:subnet/subnet-1",
+		"arn:aws:ec2:us-east-1:123456789012:subnet/subnet-2",
+		"arn:aws:ec2:us-east-1:123456789012:network-interface/eni-1",
+		"arn:aws:elasticloadbalancing:us-east-1:123456789012:loadbalancer/net/a/1",
+		"arn:aws:s3:::bucket",
+		"arn:aws:ec2:us-east-1:123456789012:instance/i-1",
+		"arn:aws:ec2:us-east-1:123456789012:instance/i-2",
+		"arn:aws:ec2:us-east-1:123456789012:instance/i-3",
+		"not-an-arn",
+	}
+
+	var mu sync.Mutex
+	var tiers []int
+	engine := newDeletionEngine(4, new(errorTracker), logrus.StandardLogger())
+	engine.delete = func(_ context.Context, parsedARN arn.ARN, _ logrus.FieldLogger) error {
+		tier, ok := deletionTiers[resourceType(parsedARN.String())]
+		if !ok {
+			tier = defaultDeletionTier
+		}
+		mu.Lock()
+		tiers = append(tiers, tier)
+		mu.Unlock()
+		return nil
+	}
+
+	deleted, err := engine.deleteResources(context.Background(), resources)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, resources[:len(resources)-1], deleted.List())
+	assert.IsNonDecreasing(t, tiers)
+	assert.Equal(t, 3, engine.stats["ec2:instance"].Deleted)
+}
+
+func TestDeletionEngineBackoff(t *testing.T) {
+	const failing = "arn:aws:ec2:us-east-1:123456789012:subnet/subnet-1"
+	now := time.Date(2021, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	fail := true
+	attempts := 0
+	engine := newDeletionEngine(1, new(errorTracker), logrus.StandardLogger())
+	engine.now = func() time.Time { return now }
+	engine.delete = func(context.Context, arn.ARN, logrus.FieldLogger) error {
+		attempts++
+		if fail {
+			return errors.New("DependencyViolation")
+		}
+		return nil
+	}
+
+	cases := []struct {
+		name             string
+		advance          time.Duration
+		fail             bool
+		expectedAttempts int
+		expectedDeleted  bool
+	}{
+		{name: "first attempt fails", fail: true, expectedAttempts: 1},
+		{name: "backing off", advance: retryBaseDelay - time.Second, fail: true, expectedAttempts: 1},
+		{name: "second attempt fails", advance: time.Second, fail: true, expectedAttempts: 2},
+		{name: "backing off twice as long", advance: retryBaseDelay, fail: true, expectedAttempts: 2},
+		{name: "third attempt succeeds", advance: retryBaseDelay, expectedAttempts: 3, expectedDeleted: true},
+	}
+	for _, tc := range cases {
+		now = now.Add(tc.advance)
+		fail = tc.fail
+		deleted, err := engine.deleteResources(context.Background(), []string{failing})
+		assert.NoError(t, err, tc.name)
+		assert.Equal(t, tc.expectedAttempts, attempts, tc.name)
+		assert.Equal(t, tc.expectedDeleted, deleted.Has(failing), tc.name)
+	}
+	assert.Equal(t, &deletionStats{Attempts: 3, Deleted: 1, Retries: 2}, engine.stats["ec2:subnet"])
+	assert.Empty(t, engine.retries)
+}
+
+func TestRetryDelay(t *testing.T) {
+	cases := []struct {
+		failures int
+		expected time.Duration
+	}{
+		{failures: 1, expected: retryBaseDelay},
+		{failures: 2, expected: 2 * retryBaseDelay},
+		{failures: 3, expected: 4 * retryBaseDelay},
+		{failures: 100, expected: retryMaxDelay},
+	}
+	for _, tc := range cases {
+		t.Run(fmt.Sprint(tc.failures), func(t *testing.T) {
+			assert.Equal(t, tc.expected, retryDelay(tc.failures))
+		})
+	}
+}
+
+func TestDeletionEngineThrottling(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "text/xml")
+		if requests == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			fmt.Fprint(w, `<Response><Errors><Error><Code>RequestLimitExceeded</Code><Message>Request limit exceeded.</Message></Error></Errors></Response>`)
+			return
+		}
+		fmt.Fprint(w, `<DeleteSnapshotResponse><return>true</return></DeleteSnapshotResponse>`)
+	}))
+	defer server.Close()
+
+	awsSession, err := session.NewSession(aws.NewConfig().
+		WithRegion("us-east-1").
+		WithEndpoint(server.URL).
+		WithCredentials(credentials.NewStaticCredentials("id", "secret", "")).
+		WithMaxRetries(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	engine := newDeletionEngine(1, new(errorTracker), logrus.StandardLogger())
+	deleteSession := engine.throttledSession(awsSession, 100)
+	engine.delete = func(ctx context.Context, parsedARN arn.ARN, logger logrus.FieldLogger) error {
+		return deleteARN(ctx, deleteSession, parsedARN, nil, logger)
+	}
+
+	deleted, err := engine.deleteResources(context.Background(), []string{"arn:aws:ec2:us-east-1:123456789012:snapshot/snap-1"})
+	assert.NoError(t, err)
+	assert.True(t, deleted.Has("arn:aws:ec2:us-east-1:123456789012:snapshot/snap-1"))
+	assert.Equal(t, 2, requests)
+	stats := engine.stats["ec2:snapshot"]
+	assert.Equal(t, 1, stats.Deleted)
+	assert.Equal(t, 1, stats.Attempts)
+	assert.Equal(t, 1, stats.Throttled)
+}
+
+func TestThrottledSessionBelowOneRequestPerSecond(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/xml")
+		fmt.Fprint(w, `<DeleteSnapshotResponse><return>true</return></DeleteSnapshotResponse>`)
+	}))
+	defer server.Close()
+
+	awsSession, err := session.NewSession(aws.NewConfig().
+		WithRegion("us-east-1").
+		WithEndpoint(server.URL).
+		WithCredentials(credentials.NewStaticCredentials("id", "secret", "")))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	engine := newDeletionEngine(1, new(errorTracker), logrus.StandardLogger())
+	deleteSession := engine.throttledSession(awsSession, 0.5)
+	parsedARN, err := arn.Parse("arn:aws:ec2:us-east-1:123456789012:snapshot/snap-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.NoError(t, deleteARN(context.Background(), deleteSession, parsedARN, nil, logrus.StandardLogger()))
+}
@@ -0,0 +1,122 @@
+package aws
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/installer/pkg/destroy/providers"
+)
+
+// fakeAWS serves the subset of the tagging and IAM APIs used to discover
+// the resources of a cluster.
+func fakeAWS(t *testing.T) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Amz-Target") == "ResourceGroupsTaggingAPI_20170126.GetResources" {
+			w.Header().Set("Content-Type", "application/x-amz-json-1.1")
+			fmt.Fprint(w, `{"ResourceTagMappingList": [
+  {"ResourceARN": "arn:aws:ec2:us-east-1:123456789012:instance/i-1"},
+  {"ResourceARN": "arn:aws:ec2:us-east-1:123456789012:instance/i-0"},
+  {"ResourceARN": "arn:aws:ec2:us-east-1:123456789012:vpc/vpc-1"},
+  {"ResourceARN": "arn:aws:s3:::test-image-registry"},
+  {"ResourceARN": "arn:aws:route53:::hostedzone/Z1"}
+]}`)
+			return
+		}
+
+		if err := r.ParseForm(); err != nil {
+			t.Fatal(err)
+		}
+		w.Header().Set("Content-Type", "text/xml")
+		switch action := r.PostForm.Get("Action"); action {
+		case "ListRoles":
+			fmt.Fprint(w, `<ListRolesResponse><ListRolesResult><IsTruncated>false</IsTruncated><Roles>
+<member><Arn>arn:aws:iam::123456789012:role/test-master-role</Arn><RoleName>test-master-role</RoleName></member>
+<member><Arn>arn:aws:iam::123456789012:role/other-role</Arn><RoleName>other-role</RoleName></member>
+</Roles></ListRolesResult></ListRolesResponse>`)
+		case "GetRole":
+			name := r.PostForm.Get("RoleName")
+			tags := ""
+			if name == "test-master-role" {
+				tags = `<member><Key>kubernetes.io/cluster/test</Key><Value>owned</Value></member>`
+			}
+			fmt.Fprintf(w, `<GetRoleResponse><GetRoleResult><Role><Arn>arn:aws:iam::123456789012:role/%s</Arn><RoleName>%s</RoleName><Tags>%s</Tags></Role></GetRoleResult></GetRoleResponse>`, name, name, tags)
+		case "ListUsers":
+			fmt.Fprint(w, `<ListUsersResponse><ListUsersResult><IsTruncated>false</IsTruncated><Users></Users></ListUsersResult></ListUsersResponse>`)
+		case "GetInstanceProfile":
+			name := r.PostForm.Get("InstanceProfileName")
+			if name != "test-master-profile" {
+				w.WriteHeader(http.StatusNotFound)
+				fmt.Fprintf(w, `<ErrorResponse><Error><Type>Sender</Type><Code>NoSuchEntity</Code><Message>Instance Profile %s cannot be found.</Message></Error></ErrorResponse>`, name)
+				return
+			}
+			fmt.Fprintf(w, `<GetInstanceProfileResponse><GetInstanceProfileResult><InstanceProfile><Arn>arn:aws:iam::123456789012:instance-profile/%s</Arn></InstanceProfile></GetInstanceProfileResult></GetInstanceProfileResponse>`, name)
+		default:
+			t.Errorf("unexpected action %q", action)
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}
+}
+
+func TestInventory(t *testing.T) {
+	server := httptest.NewServer(fakeAWS(t))
+	defer server.Close()
+
+	awsSession, err := session.NewSession(aws.NewConfig().
+		WithRegion("us-east-1").
+		WithEndpoint(server.URL).
+		WithCredentials(credentials.NewStaticCredentials("id", "secret", "")).
+		WithMaxRetries(0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	buildHandlers := awsSession.Handlers.Build.Len()
+
+	uninstaller := &ClusterUninstaller{
+		Filters:   []Filter{{"kubernetes.io/cluster/test": "owned"}},
+		Logger:    logrus.StandardLogger(),
+		Region:    "us-east-1",
+		ClusterID: "test",
+		Session:   awsSession,
+	}
+	inventory, err := uninstaller.Inventory()
+	assert.NoError(t, err)
+	assert.Equal(t, providers.Inventory{
+		"ec2:instance": {
+			"arn:aws:ec2:us-east-1:123456789012:instance/i-0",
+			"arn:aws:ec2:us-east-1:123456789012:instance/i-1",
+		},
+		"ec2:vpc":              {"arn:aws:ec2:us-east-1:123456789012:vpc/vpc-1"},
+		"iam:instance-profile": {"arn:aws:iam::123456789012:instance-profile/test-master-profile"},
+		"iam:role":             {"arn:aws:iam::123456789012:role/test-master-role"},
+		"route53:hostedzone":   {"arn:aws:route53:::hostedzone/Z1"},
+		"s3":                   {"arn:aws:s3:::test-image-registry"},
+	}, inventory)
+	assert.Equal(t, 7, inventory.Len())
+	assert.Equal(t, buildHandlers, awsSession.Handlers.Build.Len(), "the user agent handler must not be added to the session")
+}
+
+func TestResourceType(t *testing.T) {
+	cases := []struct {
+		arn      string
+		expected string
+	}{
+		{arn: "arn:aws:ec2:us-east-1:123456789012:instance/i-0", expected: "ec2:instance"},
+		{arn: "arn:aws:elasticloadbalancing:us-east-1:123456789012:loadbalancer/net/test-int/0", expected: "elasticloadbalancing:loadbalancer"},
+		{arn: "arn:aws:iam::123456789012:role/test-master-role", expected: "iam:role"},
+		{arn: "arn:aws:s3:::test-image-registry", expected: "s3"},
+		{arn: "not-an-arn", expected: "unknown"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.arn, func(t *testing.T) {
+			assert.Equal(t, tc.expected, resourceType(tc.arn))
+		})
+	}
+}
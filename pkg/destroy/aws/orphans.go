@@ -0,0 +1,318 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi"
+	"github.com/aws/aws-sdk-go/service/route53"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	awssession "github.com/openshift/installer/pkg/asset/installconfig/aws"
+	"github.com/openshift/installer/pkg/destroy/providers"
+	"github.com/openshift/installer/pkg/types"
+	awstypes "github.com/openshift/installer/pkg/types/aws"
+)
+
+// clusterTagPrefix is the prefix of the tag keys marking the resources of a
+// cluster, followed by the infra ID of the cluster.
+const clusterTagPrefix = "kubernetes.io/cluster/"
+
+// capaClusterTagPrefix is the prefix of the tag keys marking the resources
+// of a cluster created with the cluster API provider, followed by the infra
+// ID of the cluster.
+const capaClusterTagPrefix = "sigs.k8s.io/cluster-api-provider-aws/cluster/"
+
+// clusterIDTag is the tag key that older installers used to mark the
+// resources of a cluster with the cluster ID.
+const clusterIDTag = "openshiftClusterID"
+
+// FindOrphans finds the clusters that own resources in the region, from the
+// kubernetes.io/cluster/<infraID>=owned tags of the resources. Clusters whose
+// metadata cannot be recovered are skipped.
+func FindOrphans(ctx context.Context, logger logrus.FieldLogger, opts providers.OrphanOptions) ([]providers.Orphan, error) {
+	awsSession, err := awssession.GetSessionWithOptions(awssession.WithRegion(opts.Region))
+	if err != nil {
+		return nil, err
+	}
+	orphans, err := findOrphans(ctx, logger, opts.Region, awsSession, nil)
+	if err != nil {
+		return nil, err
+	}
+	return completeOrphans(logger, orphans), nil
+}
+
+// DiscoverMetadata recovers the metadata of the cluster from the
+// kubernetes.io/cluster/<infraID>=owned tags of its resources in the region.
+func DiscoverMetadata(ctx context.Context, logger logrus.FieldLogger, opts providers.DiscoverOptions) (*types.ClusterMetadata, error) {
+	if opts.Region == "" {
+		return nil, errors.New("a region is required to discover the cluster metadata on AWS")
+	}
+
+	awsSession, err := awssession.GetSessionWithOptions(awssession.WithRegion(opts.Region))
+	if err != nil {
+		return nil, err
+	}
+	return discoverMetadata(ctx, logger, opts.Region, opts.InfraID, awsSession)
+}
+
+func discoverMetadata(ctx context.Context, logger logrus.FieldLogger, region string, infraID string, awsSession *session.Session) (*types.ClusterMetadata, error) {
+	tagFilters := []*resourcegroupstaggingapi.TagFilter{{
+		Key:    aws.String(clusterTagPrefix + infraID),
+		Values: []*string{aws.String("owned")},
+	}}
+	orphans, err := findOrphans(ctx, logger, region, awsSession, tagFilters)
+	if err != nil {
+		return nil, err
+	}
+	for _, orphan := range orphans {
+		if orphan.Metadata.InfraID == infraID {
+			logger.Debugf("Found %d resources owned by %s", orphan.Resources.Len(), infraID)
+			if err := checkMetadata(orphan.Metadata); err != nil {
+				return nil, err
+			}
+			return orphan.Metadata, nil
+		}
+	}
+	return nil, errors.Errorf("no resources tagged %s%s=owned found in %s", clusterTagPrefix, infraID, region)
+}
+
+// findOrphans returns the clusters owning the resources in the region that
+// match the tag filters, or all the tagged resources in the region if there
+// are no filters.
+func findOrphans(ctx context.Context, logger logrus.FieldLogger, region string, awsSession *session.Session, tagFilters []*resourcegroupstaggingapi.TagFilter) ([]providers.Orphan, error) {
+	o := &ClusterUninstaller{Region: region, Logger: logger, Session: awsSession}
+	awsSession, err := o.session()
+	if err != nil {
+		return nil, err
+	}
+
+	orphans := map[string]*providers.Orphan{}
+	orphan := func(infraID string) *providers.Orphan {
+		if _, ok := orphans[infraID]; !ok {
+			orphans[infraID] = &providers.Orphan{
+				Metadata: &types.ClusterMetadata{
+					InfraID: infraID,
+					ClusterPlatformMetadata: types.ClusterPlatformMetadata{
+						AWS: &awstypes.Metadata{
+							Region:     region,
+							Identifier: []map[string]string{{clusterTagPrefix + infraID: "owned"}},
+						},
+					},
+				},
+				Resources: providers.Inventory{},
+			}
+		}
+		return orphans[infraID]
+	}
+
+	// hostedZones maps the ARNs of owned hosted zones to their owners.
+	hostedZones := map[string][]string{}
+	for _, tagClient := range o.tagClients(awsSession) {
+		err := tagClient.GetResourcesPagesWithContext(
+			ctx,
+			&resourcegroupstaggingapi.GetResourcesInput{ResourcesPerPage: aws.Int64(100), TagFilters: tagFilters},
+			func(results *resourcegroupstaggingapi.GetResourcesOutput, lastPage bool) bool {
+				for _, resource := range results.ResourceTagMappingList {
+					tags := make(map[string]string, len(resource.Tags))
+					for _, tag := range resource.Tags {
+						tags[aws.StringValue(tag.Key)] = aws.StringValue(tag.Value)
+					}
+					arnString := aws.StringValue(resource.ResourceARN)
+					for _, infraID := range ownerInfraIDs(tags) {
+						found := orphan(infraID)
+						found.Resources.Add(resourceType(arnString), arnString)
+						if found.Marker == "" {
+							found.Marker = openshiftMarker(infraID, tags)
+						}
+						if clusterID, ok := tags[clusterIDTag]; ok && found.Metadata.ClusterID == "" {
+							found.Metadata.ClusterID = clusterID
+							found.Metadata.AWS.Identifier = append(found.Metadata.AWS.Identifier, map[string]string{clusterIDTag: clusterID})
+						}
+						if resourceType(arnString) == "route53:hostedzone" {
+							hostedZones[arnString] = append(hostedZones[arnString], infraID)
+						}
+					}
+				}
+				return !lastPage
+			},
+		)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to list tagged resources")
+		}
+	}
+
+	created := func(tags []*ec2.Tag, t *time.Time) {
+		if t == nil {
+			return
+		}
+		for _, infraID := range ownerInfraIDs(ec2TagMap(tags)) {
+			if found, ok := orphans[infraID]; ok && (found.Created.IsZero() || t.Before(found.Created)) {
+				found.Created = *t
+			}
+		}
+	}
+	if err := findEC2CreationTimes(ctx, ec2.New(awsSession), created); err != nil {
+		return nil, err
+	}
+
+	route53Client := route53.New(awsSession)
+	for arnString, infraIDs := range hostedZones {
+		parsed, err := arn.Parse(arnString)
+		if err != nil {
+			continue
+		}
+		id := strings.TrimPrefix(parsed.Resource, "hostedzone/")
+		zone, err := route53Client.GetHostedZoneWithContext(ctx, &route53.GetHostedZoneInput{Id: aws.String(id)})
+		if err != nil {
+			logger.WithError(err).Debugf("could not get hosted zone %s to find the cluster domain", id)
+			continue
+		}
+		if zone.HostedZone.Config == nil || !aws.BoolValue(zone.HostedZone.Config.PrivateZone) {
+			continue
+		}
+		// The private zone of the cluster is its cluster domain,
+		// <clusterName>.<baseDomain>.
+		clusterDomain := strings.TrimSuffix(aws.StringValue(zone.HostedZone.Name), ".")
+		for _, infraID := range infraIDs {
+			orphans[infraID].Metadata.ClusterName = strings.SplitN(clusterDomain, ".", 2)[0]
+			orphans[infraID].Metadata.AWS.ClusterDomain = clusterDomain
+			orphans[infraID].APIServer = fmt.Sprintf("api.%s:6443", clusterDomain)
+		}
+	}
+
+	infraIDs := make([]string, 0, len(orphans))
+	for infraID := range orphans {
+		infraIDs = append(infraIDs, infraID)
+	}
+	sort.Strings(infraIDs)
+	result := make([]providers.Orphan, 0, len(orphans))
+	for _, infraID := range infraIDs {
+		result = append(result, *orphans[infraID])
+	}
+	return result, nil
+}
+
+// completeOrphans returns the orphans whose metadata could be recovered.
+func completeOrphans(logger logrus.FieldLogger, orphans []providers.Orphan) []providers.Orphan {
+	var complete []providers.Orphan
+	for _, orphan := range orphans {
+		if err := checkMetadata(orphan.Metadata); err != nil {
+			logger.Warnf("Skipping %s: %v", orphan.Metadata.InfraID, err)
+			continue
+		}
+		complete = append(complete, orphan)
+	}
+	return complete
+}
+
+// checkMetadata returns an error if the recovered metadata of the cluster is
+// not enough to destroy it. Without the cluster domain, the records of the
+// cluster in the public hosted zone would be left behind. The cluster ID is
+// not required: only older installers tagged resources with it, and the
+// resources of other clusters are found by their infra ID alone.
+func checkMetadata(metadata *types.ClusterMetadata) error {
+	if metadata.AWS.ClusterDomain == "" {
+		return errors.Errorf("no private hosted zone owned by %s was found to recover its cluster domain", metadata.InfraID)
+	}
+	return nil
+}
+
+// findEC2CreationTimes calls created with the tags and the creation times of
+// the instances, volumes and NAT gateways owned by clusters.
+func findEC2CreationTimes(ctx context.Context, client *ec2.EC2, created func(tags []*ec2.Tag, t *time.Time)) error {
+	filters := []*ec2.Filter{{
+		Name:   aws.String("tag-key"),
+		Values: []*string{aws.String(clusterTagPrefix + "*")},
+	}}
+
+	err := client.DescribeInstancesPagesWithContext(
+		ctx,
+		&ec2.DescribeInstancesInput{Filters: filters},
+		func(results *ec2.DescribeInstancesOutput, lastPage bool) bool {
+			for _, reservation := range results.Reservations {
+				for _, instance := range reservation.Instances {
+					created(instance.Tags, instance.LaunchTime)
+				}
+			}
+			return !lastPage
+		},
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to describe instances")
+	}
+
+	err = client.DescribeVolumesPagesWithContext(
+		ctx,
+		&ec2.DescribeVolumesInput{Filters: filters},
+		func(results *ec2.DescribeVolumesOutput, lastPage bool) bool {
+			for _, volume := range results.Volumes {
+				created(volume.Tags, volume.CreateTime)
+			}
+			return !lastPage
+		},
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to describe volumes")
+	}
+
+	err = client.DescribeNatGatewaysPagesWithContext(
+		ctx,
+		&ec2.DescribeNatGatewaysInput{Filter: filters},
+		func(results *ec2.DescribeNatGatewaysOutput, lastPage bool) bool {
+			for _, gateway := range results.NatGateways {
+				created(gateway.Tags, gateway.CreateTime)
+			}
+			return !lastPage
+		},
+	)
+	return errors.Wrap(err, "failed to describe NAT gateways")
+}
+
+// ownerInfraIDs returns the infra IDs of the clusters owning a resource with
+// the given tags.
+func ownerInfraIDs(tags map[string]string) []string {
+	var infraIDs []string
+	for key, value := range tags {
+		if strings.HasPrefix(key, clusterTagPrefix) && value == "owned" {
+			infraIDs = append(infraIDs, strings.TrimPrefix(key, clusterTagPrefix))
+		}
+	}
+	return infraIDs
+}
+
+// openshiftMarker returns the tag of a resource of the cluster that
+// identifies the cluster as created by the OpenShift installer, or an empty
+// string. Other Kubernetes distributions, like EKS and kops, also tag their
+// resources kubernetes.io/cluster/<name>=owned, so that tag alone is not
+// enough to destroy a cluster.
+func openshiftMarker(infraID string, tags map[string]string) string {
+	if value, ok := tags[clusterIDTag]; ok {
+		return clusterIDTag + "=" + value
+	}
+	if key := capaClusterTagPrefix + infraID; tags[key] == "owned" {
+		return key + "=owned"
+	}
+	// The internal API load balancer and private hosted zone, and the
+	// control-plane instances, volumes and network interfaces.
+	if name := tags["Name"]; name == infraID+"-int" || strings.HasPrefix(name, infraID+"-master-") {
+		return "Name=" + name
+	}
+	return ""
+}
+
+func ec2TagMap(tags []*ec2.Tag) map[string]string {
+	m := make(map[string]string, len(tags))
+	for _, tag := range tags {
+		m[aws.StringValue(tag.Key)] = aws.StringValue(tag.Value)
+	}
+	return m
+}
@@ -0,0 +1,157 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/installer/pkg/destroy/providers"
+	"github.com/openshift/installer/pkg/types"
+	awstypes "github.com/openshift/installer/pkg/types/aws"
+)
+
+// fakeOrphansAWS serves the subset of the tagging, EC2 and Route 53 APIs used
+// to find orphaned clusters. Cluster "a" owns an instance, a volume and a
+// private hosted zone, and its volume is tagged with its cluster ID; cluster
+// "b" only owns a VPC, and was not created by the installer; the bucket is
+// shared.
+func fakeOrphansAWS(t *testing.T) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Amz-Target") == "ResourceGroupsTaggingAPI_20170126.GetResources" {
+			w.Header().Set("Content-Type", "application/x-amz-json-1.1")
+			fmt.Fprint(w, `{"ResourceTagMappingList": [
+  {"ResourceARN": "arn:aws:ec2:us-east-1:123456789012:instance/i-1", "Tags": [{"Key": "kubernetes.io/cluster/a", "Value": "owned"}, {"Key": "Name", "Value": "a-master-0"}]},
+  {"ResourceARN": "arn:aws:ec2:us-east-1:123456789012:volume/vol-1", "Tags": [{"Key": "kubernetes.io/cluster/a", "Value": "owned"}, {"Key": "openshiftClusterID", "Value": "uuid-a"}]},
+  {"ResourceARN": "arn:aws:route53:::hostedzone/Z1", "Tags": [{"Key": "kubernetes.io/cluster/a", "Value": "owned"}]},
+  {"ResourceARN": "arn:aws:ec2:us-east-1:123456789012:vpc/vpc-1", "Tags": [{"Key": "kubernetes.io/cluster/b", "Value": "owned"}]},
+  {"ResourceARN": "arn:aws:s3:::shared", "Tags": [{"Key": "kubernetes.io/cluster/c", "Value": "shared"}]}
+]}`)
+			return
+		}
+
+		if r.URL.Path == "/2013-04-01/hostedzone/Z1" {
+			w.Header().Set("Content-Type", "text/xml")
+			fmt.Fprint(w, `<GetHostedZoneResponse xmlns="https://route53.amazonaws.com/doc/2013-04-01/"><HostedZone><Id>/hostedzone/Z1</Id><Name>a.example.com.</Name><Config><PrivateZone>true</PrivateZone></Config></HostedZone></GetHostedZoneResponse>`)
+			return
+		}
+
+		if err := r.ParseForm(); err != nil {
+			t.Fatal(err)
+		}
+		w.Header().Set("Content-Type", "text/xml")
+		tags := `<tagSet><item><key>kubernetes.io/cluster/a</key><value>owned</value></item></tagSet>`
+		switch action := r.PostForm.Get("Action"); action {
+		case "DescribeInstances":
+			fmt.Fprintf(w, `<DescribeInstancesResponse><reservationSet><item><instancesSet><item><instanceId>i-1</instanceId><launchTime>2021-10-01T12:00:00Z</launchTime>%s</item></instancesSet></item></reservationSet></DescribeInstancesResponse>`, tags)
+		case "DescribeVolumes":
+			fmt.Fprintf(w, `<DescribeVolumesResponse><volumeSet><item><volumeId>vol-1</volumeId><createTime>2021-10-01T11:00:00Z</createTime>%s</item></volumeSet></DescribeVolumesResponse>`, tags)
+		case "DescribeNatGateways":
+			fmt.Fprint(w, `<DescribeNatGatewaysResponse><natGatewaySet></natGatewaySet></DescribeNatGatewaysResponse>`)
+		default:
+			t.Errorf("unexpected action %q", action)
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}
+}
+
+func TestFindOrphans(t *testing.T) {
+	server := httptest.NewServer(fakeOrphansAWS(t))
+	defer server.Close()
+
+	awsSession, err := session.NewSession(aws.NewConfig().
+		WithRegion("us-east-1").
+		WithEndpoint(server.URL).
+		WithCredentials(credentials.NewStaticCredentials("id", "secret", "")).
+		WithMaxRetries(0))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	orphans, err := findOrphans(context.Background(), logrus.StandardLogger(), "us-east-1", awsSession, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []providers.Orphan{
+		{
+			Metadata: &types.ClusterMetadata{
+				ClusterName: "a",
+				ClusterID:   "uuid-a",
+				InfraID:     "a",
+				ClusterPlatformMetadata: types.ClusterPlatformMetadata{
+					AWS: &awstypes.Metadata{
+						Region:        "us-east-1",
+						Identifier:    []map[string]string{{"kubernetes.io/cluster/a": "owned"}, {"openshiftClusterID": "uuid-a"}},
+						ClusterDomain: "a.example.com",
+					},
+				},
+			},
+			Marker:    "Name=a-master-0",
+			APIServer: "api.a.example.com:6443",
+			Created:   time.Date(2021, 10, 1, 11, 0, 0, 0, time.UTC),
+			Resources: providers.Inventory{
+				"ec2:instance":       {"arn:aws:ec2:us-east-1:123456789012:instance/i-1"},
+				"ec2:volume":         {"arn:aws:ec2:us-east-1:123456789012:volume/vol-1"},
+				"route53:hostedzone": {"arn:aws:route53:::hostedzone/Z1"},
+			},
+		},
+		{
+			Metadata: &types.ClusterMetadata{
+				InfraID: "b",
+				ClusterPlatformMetadata: types.ClusterPlatformMetadata{
+					AWS: &awstypes.Metadata{
+						Region:     "us-east-1",
+						Identifier: []map[string]string{{"kubernetes.io/cluster/b": "owned"}},
+					},
+				},
+			},
+			Resources: providers.Inventory{
+				"ec2:vpc": {"arn:aws:ec2:us-east-1:123456789012:vpc/vpc-1"},
+			},
+		},
+	}, orphans)
+
+	complete := completeOrphans(logrus.StandardLogger(), orphans)
+	assert.Equal(t, orphans[:1], complete, "clusters without a cluster domain should be skipped")
+}
+
+func TestDiscoverMetadata(t *testing.T) {
+	server := httptest.NewServer(fakeOrphansAWS(t))
+	defer server.Close()
+
+	awsSession, err := session.NewSession(aws.NewConfig().
+		WithRegion("us-east-1").
+		WithEndpoint(server.URL).
+		WithCredentials(credentials.NewStaticCredentials("id", "secret", "")).
+		WithMaxRetries(0))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	metadata, err := discoverMetadata(context.Background(), logrus.StandardLogger(), "us-east-1", "a", awsSession)
+	assert.NoError(t, err)
+	assert.Equal(t, &types.ClusterMetadata{
+		ClusterName: "a",
+		ClusterID:   "uuid-a",
+		InfraID:     "a",
+		ClusterPlatformMetadata: types.ClusterPlatformMetadata{
+			AWS: &awstypes.Metadata{
+				Region:        "us-east-1",
+				Identifier:    []map[string]string{{"kubernetes.io/cluster/a": "owned"}, {"openshiftClusterID": "uuid-a"}},
+				ClusterDomain: "a.example.com",
+			},
+		},
+	}, metadata)
+
+	_, err = discoverMetadata(context.Background(), logrus.StandardLogger(), "us-east-1", "b", awsSession)
+	assert.EqualError(t, err, "no private hosted zone owned by b was found to recover its cluster domain")
+
+	_, err = discoverMetadata(context.Background(), logrus.StandardLogger(), "us-east-1", "missing", awsSession)
+	assert.EqualError(t, err, "no resources tagged kubernetes.io/cluster/missing=owned found in us-east-1")
+}
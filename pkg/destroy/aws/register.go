@@ -4,4 +4,6 @@ import "github.com/openshift/installer/pkg/destroy/providers"
 
 func init() {
 	providers.Registry["aws"] = New
+	providers.OrphanFinders["aws"] = FindOrphans
+	providers.MetadataDiscoverers["aws"] = DiscoverMetadata
 }
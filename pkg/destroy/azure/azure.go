@@ -8,7 +8,9 @@ import (
 	"strings"
 	"time"
 
+	"github.com/Azure/azure-sdk-for-go/profiles/2018-03-01/compute/mgmt/compute"
 	azurestackdns "github.com/Azure/azure-sdk-for-go/profiles/2018-03-01/dns/mgmt/dns"
+	"github.com/Azure/azure-sdk-for-go/profiles/2018-03-01/network/mgmt/network"
 	"github.com/Azure/azure-sdk-for-go/services/graphrbac/1.6/graphrbac"
 	"github.com/Azure/azure-sdk-for-go/services/preview/dns/mgmt/2018-03-01-preview/dns"
 	"github.com/Azure/azure-sdk-for-go/services/privatedns/mgmt/2018-09-01/privatedns"
@@ -44,18 +46,28 @@ type ClusterUninstaller struct {
 	Logger logrus.FieldLogger
 
 	resourceGroupsClient    resources.GroupsClient
+	resourcesClient         resources.Client
 	zonesClient             dns.ZonesClient
 	recordsClient           dns.RecordSetsClient
 	privateRecordSetsClient privatedns.RecordSetsClient
 	privateZonesClient      privatedns.PrivateZonesClient
 	serviceprincipalsClient graphrbac.ServicePrincipalsClient
 	applicationsClient      graphrbac.ApplicationsClient
+
+	virtualMachinesClient     compute.VirtualMachinesClient
+	virtualMachineSizesClient compute.VirtualMachineSizesClient
+	disksClient               compute.DisksClient
+	loadBalancersClient       network.LoadBalancersClient
+	publicIPAddressesClient   network.PublicIPAddressesClient
 }
 
 func (o *ClusterUninstaller) configureClients() {
 	o.resourceGroupsClient = resources.NewGroupsClientWithBaseURI(o.Environment.ResourceManagerEndpoint, o.SubscriptionID)
 	o.resourceGroupsClient.Authorizer = o.Authorizer
 
+	o.resourcesClient = resources.NewClientWithBaseURI(o.Environment.ResourceManagerEndpoint, o.SubscriptionID)
+	o.resourcesClient.Authorizer = o.Authorizer
+
 	o.zonesClient = dns.NewZonesClientWithBaseURI(o.Environment.ResourceManagerEndpoint, o.SubscriptionID)
 	o.zonesClient.Authorizer = o.Authorizer
 
@@ -73,6 +85,21 @@ func (o *ClusterUninstaller) configureClients() {
 
 	o.applicationsClient = graphrbac.NewApplicationsClientWithBaseURI(o.Environment.GraphEndpoint, o.TenantID)
 	o.applicationsClient.Authorizer = o.GraphAuthorizer
+
+	o.virtualMachinesClient = compute.NewVirtualMachinesClientWithBaseURI(o.Environment.ResourceManagerEndpoint, o.SubscriptionID)
+	o.virtualMachinesClient.Authorizer = o.Authorizer
+
+	o.virtualMachineSizesClient = compute.NewVirtualMachineSizesClientWithBaseURI(o.Environment.ResourceManagerEndpoint, o.SubscriptionID)
+	o.virtualMachineSizesClient.Authorizer = o.Authorizer
+
+	o.disksClient = compute.NewDisksClientWithBaseURI(o.Environment.ResourceManagerEndpoint, o.SubscriptionID)
+	o.disksClient.Authorizer = o.Authorizer
+
+	o.loadBalancersClient = network.NewLoadBalancersClientWithBaseURI(o.Environment.ResourceManagerEndpoint, o.SubscriptionID)
+	o.loadBalancersClient.Authorizer = o.Authorizer
+
+	o.publicIPAddressesClient = network.NewPublicIPAddressesClientWithBaseURI(o.Environment.ResourceManagerEndpoint, o.SubscriptionID)
+	o.publicIPAddressesClient.Authorizer = o.Authorizer
 }
 
 // New returns an Azure destroyer from ClusterMetadata.
@@ -150,6 +177,11 @@ func (o *ClusterUninstaller) Run() (*types.ClusterQuota, error) {
 		waitCtx, cancel = context.WithTimeout(context.Background(), diff)
 	}
 
+	footprint, err := o.resourceGroupFootprint(waitCtx)
+	if err != nil {
+		o.Logger.WithError(err).Debug("the quota footprint of the resource group is incomplete")
+	}
+
 	wait.UntilWithContext(
 		waitCtx,
 		func(ctx context.Context) {
@@ -205,7 +237,10 @@ func (o *ClusterUninstaller) Run() (*types.ClusterQuota, error) {
 		o.Logger.Debug(err)
 	}
 
-	return nil, utilerrors.NewAggregate(errs)
+	if err := utilerrors.NewAggregate(errs); err != nil {
+		return nil, err
+	}
+	return &types.ClusterQuota{Azure: footprint}, nil
 }
 
 func deleteAzureStackPublicRecords(ctx context.Context, o *ClusterUninstaller) error {
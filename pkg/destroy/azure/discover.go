@@ -0,0 +1,70 @@
+package azure
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2018-05-01/resources"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	azuresession "github.com/openshift/installer/pkg/asset/installconfig/azure"
+	"github.com/openshift/installer/pkg/destroy/providers"
+	"github.com/openshift/installer/pkg/types"
+	"github.com/openshift/installer/pkg/types/azure"
+)
+
+// DiscoverMetadata recovers the metadata of the cluster from its resource
+// group, which must be tagged kubernetes.io_cluster.<infraID>=owned. The
+// region of the cluster is the location of the resource group.
+func DiscoverMetadata(ctx context.Context, logger logrus.FieldLogger, opts providers.DiscoverOptions) (*types.ClusterMetadata, error) {
+	if opts.CloudName == "" {
+		opts.CloudName = string(azure.PublicCloud)
+	}
+	session, err := azuresession.GetSession(azure.CloudEnvironment(opts.CloudName), opts.ARMEndpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	client := resources.NewGroupsClientWithBaseURI(session.Environment.ResourceManagerEndpoint, session.Credentials.SubscriptionID)
+	client.Authorizer = session.Authorizer
+	return discoverMetadata(ctx, logger, client, opts)
+}
+
+// discoverMetadata recovers the metadata of the cluster. opts.CloudName must
+// be set.
+func discoverMetadata(ctx context.Context, logger logrus.FieldLogger, client resources.GroupsClient, opts providers.DiscoverOptions) (*types.ClusterMetadata, error) {
+	groupName := opts.ResourceGroup
+	if groupName == "" {
+		groupName = opts.InfraID + "-rg"
+	}
+
+	group, err := client.Get(ctx, groupName)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get resource group %s", groupName)
+	}
+
+	tag := fmt.Sprintf("kubernetes.io_cluster.%s", opts.InfraID)
+	if value, ok := group.Tags[tag]; !ok || to.String(value) != "owned" {
+		return nil, errors.Errorf("resource group %s is not tagged %s=owned", groupName, tag)
+	}
+
+	region := to.String(group.Location)
+	if opts.Region != "" && opts.Region != region {
+		logger.Warnf("Resource group %s is in %s, not %s", groupName, region, opts.Region)
+	}
+
+	return &types.ClusterMetadata{
+		InfraID: opts.InfraID,
+		ClusterPlatformMetadata: types.ClusterPlatformMetadata{
+			Azure: &azure.Metadata{
+				ARMEndpoint:                 opts.ARMEndpoint,
+				CloudName:                   azure.CloudEnvironment(opts.CloudName),
+				Region:                      region,
+				ResourceGroupName:           groupName,
+				BaseDomainResourceGroupName: opts.BaseDomainResourceGroup,
+			},
+		},
+	}, nil
+}
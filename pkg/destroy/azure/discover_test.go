@@ -0,0 +1,70 @@
+package azure
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2018-05-01/resources"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/installer/pkg/destroy/providers"
+	"github.com/openshift/installer/pkg/types"
+	"github.com/openshift/installer/pkg/types/azure"
+)
+
+func TestDiscoverMetadata(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/subscriptions/sub/resourcegroups/a-rg":
+			fmt.Fprint(w, `{"id": "/subscriptions/sub/resourceGroups/a-rg", "name": "a-rg", "location": "usgovvirginia", "tags": {"kubernetes.io_cluster.a": "owned"}}`)
+		case "/subscriptions/sub/resourcegroups/b-rg":
+			fmt.Fprint(w, `{"id": "/subscriptions/sub/resourceGroups/b-rg", "name": "b-rg", "location": "usgovvirginia", "tags": {"kubernetes.io_cluster.b": "shared"}}`)
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	client := resources.NewGroupsClientWithBaseURI(server.URL, "sub")
+
+	cases := []struct {
+		name     string
+		opts     providers.DiscoverOptions
+		expected *types.ClusterMetadata
+		err      string
+	}{{
+		name: "owned",
+		opts: providers.DiscoverOptions{InfraID: "a", CloudName: string(azure.USGovernmentCloud), BaseDomainResourceGroup: "dns"},
+		expected: &types.ClusterMetadata{
+			InfraID: "a",
+			ClusterPlatformMetadata: types.ClusterPlatformMetadata{
+				Azure: &azure.Metadata{
+					CloudName:                   azure.USGovernmentCloud,
+					Region:                      "usgovvirginia",
+					ResourceGroupName:           "a-rg",
+					BaseDomainResourceGroupName: "dns",
+				},
+			},
+		},
+	}, {
+		name: "not owned",
+		opts: providers.DiscoverOptions{InfraID: "b", CloudName: string(azure.PublicCloud)},
+		err:  `^resource group b-rg is not tagged kubernetes.io_cluster.b=owned$`,
+	}}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			metadata, err := discoverMetadata(context.Background(), logrus.StandardLogger(), client, tc.opts)
+			if tc.err != "" {
+				assert.Regexp(t, tc.err, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, metadata)
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package azure
+
+import (
+	"context"
+	"strings"
+
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/pkg/errors"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	"github.com/openshift/installer/pkg/destroy/quota"
+	"github.com/openshift/installer/pkg/types"
+)
+
+// resourceGroupFootprint returns the quota used by the virtual machines, disks,
+// load balancers and public IP addresses in the cluster resource group. The
+// resources are deleted along with the resource group, so they have to be
+// looked up before it is deleted. Resources that could not be listed are left
+// out of the returned quota.
+func (o *ClusterUninstaller) resourceGroupFootprint(ctx context.Context) (*types.PlatformQuota, error) {
+	footprint := quota.NewFootprint()
+	var errs []error
+
+	vCPUs := map[string]map[string]int64{}
+	for page, err := o.virtualMachinesClient.List(ctx, o.ResourceGroupName); page.NotDone(); err = page.NextWithContext(ctx) {
+		if err != nil {
+			errs = append(errs, errors.Wrap(err, "failed to list virtual machines"))
+			break
+		}
+		for _, vm := range page.Values() {
+			var size string
+			if vm.VirtualMachineProperties != nil && vm.HardwareProfile != nil {
+				size = string(vm.HardwareProfile.VMSize)
+			}
+			location := to.String(vm.Location)
+			if _, ok := vCPUs[location]; !ok {
+				sizes, err := o.virtualMachineSizes(ctx, location)
+				if err != nil {
+					errs = append(errs, err)
+				}
+				vCPUs[location] = sizes
+			}
+			footprint.Record(to.String(vm.ID), quota.Instance(size, vCPUs[location][strings.ToLower(size)]))
+		}
+	}
+
+	for page, err := o.disksClient.ListByResourceGroup(ctx, o.ResourceGroupName); page.NotDone(); err = page.NextWithContext(ctx) {
+		if err != nil {
+			errs = append(errs, errors.Wrap(err, "failed to list disks"))
+			break
+		}
+		for _, disk := range page.Values() {
+			var sizeGB int64
+			if disk.DiskProperties != nil {
+				sizeGB = int64(to.Int32(disk.DiskSizeGB))
+			}
+			footprint.Record(to.String(disk.ID), quota.Volume(sizeGB))
+		}
+	}
+
+	for page, err := o.loadBalancersClient.List(ctx, o.ResourceGroupName); page.NotDone(); err = page.NextWithContext(ctx) {
+		if err != nil {
+			errs = append(errs, errors.Wrap(err, "failed to list load balancers"))
+			break
+		}
+		for _, lb := range page.Values() {
+			footprint.Record(to.String(lb.ID), quota.LoadBalancer())
+		}
+	}
+
+	for page, err := o.publicIPAddressesClient.List(ctx, o.ResourceGroupName); page.NotDone(); err = page.NextWithContext(ctx) {
+		if err != nil {
+			errs = append(errs, errors.Wrap(err, "failed to list public IP addresses"))
+			break
+		}
+		for _, ip := range page.Values() {
+			footprint.Record(to.String(ip.ID), quota.PublicIP())
+		}
+	}
+
+	return footprint.Quota(), utilerrors.NewAggregate(errs)
+}
+
+// virtualMachineSizes returns the number of vCPUs of the virtual machine
+// sizes available in the location, by lower-case size name.
+func (o *ClusterUninstaller) virtualMachineSizes(ctx context.Context, location string) (map[string]int64, error) {
+	result, err := o.virtualMachineSizesClient.List(ctx, location)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list virtual machine sizes in %s", location)
+	}
+	sizes := map[string]int64{}
+	if result.Value != nil {
+		for _, size := range *result.Value {
+			sizes[strings.ToLower(to.String(size.Name))] = int64(to.Int32(size.NumberOfCores))
+		}
+	}
+	return sizes, nil
+}
@@ -0,0 +1,56 @@
+package azure
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/graphrbac/1.6/graphrbac"
+	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2018-05-01/resources"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/pkg/errors"
+
+	"github.com/openshift/installer/pkg/destroy/providers"
+)
+
+// inventoryTimeout is how long listing the resources of a cluster may take.
+const inventoryTimeout = 10 * time.Minute
+
+// Inventory returns the resources that would be deleted, grouped by type,
+// without deleting any of them. Public DNS records whose deletion follows
+// from the private zone of the cluster are not included.
+func (o *ClusterUninstaller) Inventory() (providers.Inventory, error) {
+	o.configureClients()
+	ctx, cancel := context.WithTimeout(context.Background(), inventoryTimeout)
+	defer cancel()
+	return inventory(ctx, o.resourceGroupsClient, o.resourcesClient, o.serviceprincipalsClient, o.ResourceGroupName, o.InfraID)
+}
+
+// inventory returns the cluster resource group with the resources in it,
+// which are deleted along with the resource group, and the application
+// registrations of the cluster, by the IDs of their service principals.
+func inventory(ctx context.Context, groupsClient resources.GroupsClient, resourcesClient resources.Client, spClient graphrbac.ServicePrincipalsClient, groupName string, infraID string) (providers.Inventory, error) {
+	inventory := providers.Inventory{}
+
+	group, err := groupsClient.Get(ctx, groupName)
+	switch {
+	case isNotFoundError(err):
+	case err != nil:
+		return nil, errors.Wrapf(err, "failed to get resource group %s", groupName)
+	default:
+		inventory.Add("microsoft.resources/resourcegroups", to.String(group.ID))
+		if _, err := addGroupResources(ctx, resourcesClient, groupName, inventory); err != nil {
+			return nil, err
+		}
+	}
+
+	tag := fmt.Sprintf("%s%s=owned", clusterTagPrefix, infraID)
+	servicePrincipals, err := getServicePrincipalsByTag(ctx, spClient, tag, infraID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to gather list of Service Principals by tag")
+	}
+	for _, sp := range servicePrincipals {
+		inventory.Add("microsoft.graph/applications", to.String(sp.AppID))
+	}
+	return inventory, nil
+}
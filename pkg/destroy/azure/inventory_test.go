@@ -0,0 +1,78 @@
+package azure
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/graphrbac/1.6/graphrbac"
+	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2018-05-01/resources"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/installer/pkg/destroy/providers"
+)
+
+// fakeInventoryAzure serves the resource group a-rg with a virtual machine,
+// and the service principals of clusters a and b.
+func fakeInventoryAzure(t *testing.T) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/subscriptions/sub/resourcegroups/a-rg":
+			fmt.Fprint(w, `{"id": "/subscriptions/sub/resourceGroups/a-rg", "name": "a-rg", "location": "eastus"}`)
+		case "/subscriptions/sub/resourcegroups/missing-rg":
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"error": {"code": "ResourceGroupNotFound", "message": "Resource group 'missing-rg' could not be found."}}`)
+		case "/subscriptions/sub/resourceGroups/a-rg/resources":
+			fmt.Fprint(w, `{"value": [
+  {"id": "/subscriptions/sub/resourceGroups/a-rg/providers/Microsoft.Compute/virtualMachines/a-master-0", "type": "Microsoft.Compute/virtualMachines"}
+]}`)
+		case "/tenant/servicePrincipals":
+			fmt.Fprint(w, `{"value": [
+  {"objectType": "ServicePrincipal", "appId": "app-a", "tags": ["kubernetes.io_cluster.a=owned"]},
+  {"objectType": "ServicePrincipal", "appId": "app-ab", "tags": ["kubernetes.io_cluster.ab=owned"]}
+]}`)
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+}
+
+func TestInventory(t *testing.T) {
+	server := httptest.NewServer(fakeInventoryAzure(t))
+	defer server.Close()
+
+	groupsClient := resources.NewGroupsClientWithBaseURI(server.URL, "sub")
+	resourcesClient := resources.NewClientWithBaseURI(server.URL, "sub")
+	spClient := graphrbac.NewServicePrincipalsClientWithBaseURI(server.URL, "tenant")
+
+	cases := []struct {
+		name      string
+		groupName string
+		expected  providers.Inventory
+	}{{
+		name:      "resource group",
+		groupName: "a-rg",
+		expected: providers.Inventory{
+			"microsoft.resources/resourcegroups": {"/subscriptions/sub/resourceGroups/a-rg"},
+			"microsoft.compute/virtualmachines":  {"/subscriptions/sub/resourceGroups/a-rg/providers/Microsoft.Compute/virtualMachines/a-master-0"},
+			"microsoft.graph/applications":       {"app-a"},
+		},
+	}, {
+		name:      "deleted resource group",
+		groupName: "missing-rg",
+		expected: providers.Inventory{
+			"microsoft.graph/applications": {"app-a"},
+		},
+	}}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := inventory(context.Background(), groupsClient, resourcesClient, spClient, tc.groupName, "a")
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package azure
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2018-05-01/resources"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	azuresession "github.com/openshift/installer/pkg/asset/installconfig/azure"
+	"github.com/openshift/installer/pkg/destroy/providers"
+	"github.com/openshift/installer/pkg/types"
+	"github.com/openshift/installer/pkg/types/azure"
+)
+
+// clusterTagPrefix is the prefix of the tag keys marking the resource groups
+// of a cluster, followed by the infra ID of the cluster.
+const clusterTagPrefix = "kubernetes.io_cluster."
+
+// FindOrphans finds the clusters that own resource groups in the region, from
+// the kubernetes.io_cluster.<infraID>=owned tags of the resource groups.
+func FindOrphans(ctx context.Context, logger logrus.FieldLogger, opts providers.OrphanOptions) ([]providers.Orphan, error) {
+	cloudName := azure.CloudEnvironment(opts.CloudName)
+	if cloudName == "" {
+		cloudName = azure.PublicCloud
+	}
+	session, err := azuresession.GetSession(cloudName, opts.ARMEndpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	groupsClient := resources.NewGroupsClientWithBaseURI(session.Environment.ResourceManagerEndpoint, session.Credentials.SubscriptionID)
+	groupsClient.Authorizer = session.Authorizer
+	resourcesClient := resources.NewClientWithBaseURI(session.Environment.ResourceManagerEndpoint, session.Credentials.SubscriptionID)
+	resourcesClient.Authorizer = session.Authorizer
+	return findOrphans(ctx, logger, groupsClient, resourcesClient, opts.Region, cloudName, opts.ARMEndpoint)
+}
+
+// findOrphans returns the clusters owning the resource groups in the region,
+// or in every region if the region is empty. The resource groups are only
+// created, and tagged, by the installer, so the tag also identifies the
+// clusters as OpenShift clusters.
+func findOrphans(ctx context.Context, logger logrus.FieldLogger, groupsClient resources.GroupsClient, resourcesClient resources.Client, region string, cloudName azure.CloudEnvironment, armEndpoint string) ([]providers.Orphan, error) {
+	orphans := map[string]*providers.Orphan{}
+
+	var groups []resources.Group
+	list, err := groupsClient.ListComplete(ctx, "", nil)
+	for ; err == nil && list.NotDone(); err = list.NextWithContext(ctx) {
+		groups = append(groups, list.Value())
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list resource groups")
+	}
+
+	for _, group := range groups {
+		groupName, location := to.String(group.Name), to.String(group.Location)
+		if region != "" && !strings.EqualFold(location, region) {
+			continue
+		}
+		for key, value := range group.Tags {
+			if !strings.HasPrefix(key, clusterTagPrefix) || to.String(value) != "owned" {
+				continue
+			}
+			infraID := strings.TrimPrefix(key, clusterTagPrefix)
+			if _, ok := orphans[infraID]; ok {
+				logger.Warnf("Skipping resource group %s: %s also owns another resource group", groupName, infraID)
+				continue
+			}
+			orphan := &providers.Orphan{
+				Metadata: &types.ClusterMetadata{
+					InfraID: infraID,
+					ClusterPlatformMetadata: types.ClusterPlatformMetadata{
+						Azure: &azure.Metadata{
+							ARMEndpoint:       armEndpoint,
+							CloudName:         cloudName,
+							Region:            location,
+							ResourceGroupName: groupName,
+						},
+					},
+				},
+				Marker:    key + "=owned",
+				Resources: providers.Inventory{"microsoft.resources/resourcegroups": {to.String(group.ID)}},
+			}
+			created, err := addGroupResources(ctx, resourcesClient, groupName, orphan.Resources)
+			if err != nil {
+				return nil, err
+			}
+			orphan.Created = created
+			orphans[infraID] = orphan
+		}
+	}
+
+	infraIDs := make([]string, 0, len(orphans))
+	for infraID := range orphans {
+		infraIDs = append(infraIDs, infraID)
+	}
+	sort.Strings(infraIDs)
+	result := make([]providers.Orphan, 0, len(orphans))
+	for _, infraID := range infraIDs {
+		result = append(result, *orphans[infraID])
+	}
+	return result, nil
+}
+
+// addGroupResources adds the resources of the resource group to the
+// inventory, and returns the creation time of the oldest one.
+func addGroupResources(ctx context.Context, client resources.Client, groupName string, inventory providers.Inventory) (time.Time, error) {
+	var oldest time.Time
+	list, err := client.ListByResourceGroupComplete(ctx, groupName, "", "createdTime", nil)
+	for ; err == nil && list.NotDone(); err = list.NextWithContext(ctx) {
+		resource := list.Value()
+		inventory.Add(strings.ToLower(to.String(resource.Type)), to.String(resource.ID))
+		if resource.CreatedTime == nil {
+			continue
+		}
+		if created := resource.CreatedTime.ToTime(); oldest.IsZero() || created.Before(oldest) {
+			oldest = created.In(time.UTC)
+		}
+	}
+	return oldest, errors.Wrapf(err, "failed to list the resources of resource group %s", groupName)
+}
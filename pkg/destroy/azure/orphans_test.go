@@ -0,0 +1,74 @@
+package azure
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2018-05-01/resources"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/installer/pkg/destroy/providers"
+	"github.com/openshift/installer/pkg/types"
+	"github.com/openshift/installer/pkg/types/azure"
+)
+
+// fakeOrphansAzure serves the resource group and resource lists. Cluster "a"
+// owns a resource group in eastus with a virtual machine and a disk; cluster
+// "b" owns a resource group in westus; the other resource group is not owned
+// by a cluster.
+func fakeOrphansAzure(t *testing.T) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/subscriptions/sub/resourcegroups":
+			fmt.Fprint(w, `{"value": [
+  {"id": "/subscriptions/sub/resourceGroups/a-rg", "name": "a-rg", "location": "eastus", "tags": {"kubernetes.io_cluster.a": "owned"}},
+  {"id": "/subscriptions/sub/resourceGroups/b-rg", "name": "b-rg", "location": "westus", "tags": {"kubernetes.io_cluster.b": "owned"}},
+  {"id": "/subscriptions/sub/resourceGroups/other", "name": "other", "location": "eastus", "tags": {"kubernetes.io_cluster.c": "shared"}}
+]}`)
+		case "/subscriptions/sub/resourceGroups/a-rg/resources":
+			assert.Equal(t, "createdTime", r.URL.Query().Get("$expand"))
+			fmt.Fprint(w, `{"value": [
+  {"id": "/subscriptions/sub/resourceGroups/a-rg/providers/Microsoft.Compute/virtualMachines/a-master-0", "type": "Microsoft.Compute/virtualMachines", "createdTime": "2021-10-01T12:00:00Z"},
+  {"id": "/subscriptions/sub/resourceGroups/a-rg/providers/Microsoft.Compute/disks/a-master-0_OSDisk", "type": "Microsoft.Compute/disks", "createdTime": "2021-10-01T11:00:00Z"}
+]}`)
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+}
+
+func TestFindOrphans(t *testing.T) {
+	server := httptest.NewServer(fakeOrphansAzure(t))
+	defer server.Close()
+
+	groupsClient := resources.NewGroupsClientWithBaseURI(server.URL, "sub")
+	resourcesClient := resources.NewClientWithBaseURI(server.URL, "sub")
+	orphans, err := findOrphans(context.Background(), logrus.StandardLogger(), groupsClient, resourcesClient, "eastus", azure.USGovernmentCloud, "")
+	assert.NoError(t, err)
+	assert.Equal(t, []providers.Orphan{{
+		Metadata: &types.ClusterMetadata{
+			InfraID: "a",
+			ClusterPlatformMetadata: types.ClusterPlatformMetadata{
+				Azure: &azure.Metadata{
+					CloudName:         azure.USGovernmentCloud,
+					Region:            "eastus",
+					ResourceGroupName: "a-rg",
+				},
+			},
+		},
+		Marker:  "kubernetes.io_cluster.a=owned",
+		Created: time.Date(2021, 10, 1, 11, 0, 0, 0, time.UTC),
+		Resources: providers.Inventory{
+			"microsoft.resources/resourcegroups": {"/subscriptions/sub/resourceGroups/a-rg"},
+			"microsoft.compute/virtualmachines":  {"/subscriptions/sub/resourceGroups/a-rg/providers/Microsoft.Compute/virtualMachines/a-master-0"},
+			"microsoft.compute/disks":            {"/subscriptions/sub/resourceGroups/a-rg/providers/Microsoft.Compute/disks/a-master-0_OSDisk"},
+		},
+	}}, orphans)
+}
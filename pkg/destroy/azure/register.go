@@ -6,4 +6,6 @@ import (
 
 func init() {
 	providers.Registry["azure"] = New
+	providers.OrphanFinders["azure"] = FindOrphans
+	providers.MetadataDiscoverers["azure"] = DiscoverMetadata
 }
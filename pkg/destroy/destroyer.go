@@ -1,28 +1,151 @@
 package destroy
 
 import (
+	"context"
+	"net"
+	"time"
+
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/sets"
 
 	"github.com/openshift/installer/pkg/asset/cluster"
 	"github.com/openshift/installer/pkg/destroy/providers"
+	"github.com/openshift/installer/pkg/types"
 )
 
 // New returns a Destroyer based on `metadata.json` in `rootDir`.
 func New(logger logrus.FieldLogger, rootDir string) (providers.Destroyer, error) {
-	metadata, err := cluster.LoadMetadata(rootDir)
+	destroyer, _, err := newDestroyer(logger, rootDir)
+	return destroyer, err
+}
+
+// Inventory returns the resources that the Destroyer based on
+// `metadata.json` in `rootDir` would delete, without deleting them.
+func Inventory(logger logrus.FieldLogger, rootDir string) (providers.Inventory, error) {
+	destroyer, platform, err := newDestroyer(logger, rootDir)
+	if err != nil {
+		return nil, err
+	}
+
+	inventorier, ok := destroyer.(providers.Inventorier)
+	if !ok {
+		return nil, errors.Errorf("listing the resources to destroy is not supported on %s", platform)
+	}
+	return inventorier.Inventory()
+}
+
+// NewForMetadata returns a Destroyer for the cluster described by metadata,
+// e.g. for an orphaned cluster that has no `metadata.json`.
+func NewForMetadata(logger logrus.FieldLogger, metadata *types.ClusterMetadata) (providers.Destroyer, error) {
+	destroyer, _, err := newDestroyerForMetadata(logger, metadata)
+	return destroyer, err
+}
+
+// OrphanFilter selects the orphaned clusters to destroy.
+type OrphanFilter struct {
+	// OlderThan is how long ago the clusters must have been created.
+	OlderThan time.Duration
+	// Exclude are the infra IDs of clusters that must not be destroyed.
+	Exclude []string
+	// IncludeUnknownAPIServer selects the clusters whose API server address
+	// cannot be found, so that whether they are live is unknown.
+	IncludeUnknownAPIServer bool
+}
+
+// apiServerTimeout is how long to wait for the API server of an orphaned
+// cluster to accept a connection.
+const apiServerTimeout = 10 * time.Second
+
+// FindOrphans returns the clusters on the platform that were created by the
+// OpenShift installer more than filter.OlderThan ago and have no live owner.
+// Such clusters are assumed to have been left behind by installer runs that
+// never destroyed them. Clusters whose age cannot be determined are skipped,
+// since they may still be being created, and so are clusters whose API server
+// still accepts connections or cannot be found, unless the filter includes
+// them, or that are excluded by the filter.
+func FindOrphans(ctx context.Context, logger logrus.FieldLogger, platform string, opts providers.OrphanOptions, filter OrphanFilter) ([]providers.Orphan, error) {
+	finder, ok := providers.OrphanFinders[platform]
+	if !ok {
+		return nil, errors.Errorf("finding orphaned clusters is not supported for %q", platform)
+	}
+	orphans, err := finder(ctx, logger, opts)
 	if err != nil {
 		return nil, err
 	}
+	live := func(address string) bool {
+		return apiServerLive(ctx, address)
+	}
+	return filterOrphans(logger, orphans, time.Now().Add(-filter.OlderThan), sets.NewString(filter.Exclude...), filter.IncludeUnknownAPIServer, live), nil
+}
+
+// DiscoverMetadata recovers the metadata of the cluster identified by opts
+// from the tags (or labels) of its resources on the platform, so it can be
+// destroyed after its metadata.json is lost.
+func DiscoverMetadata(ctx context.Context, logger logrus.FieldLogger, platform string, opts providers.DiscoverOptions) (*types.ClusterMetadata, error) {
+	discover, ok := providers.MetadataDiscoverers[platform]
+	if !ok {
+		return nil, errors.Errorf("discovering the cluster metadata is not supported for %q", platform)
+	}
+	return discover(ctx, logger, opts)
+}
+
+// filterOrphans returns the orphans created by the installer before the
+// cutoff, except the excluded ones and the ones whose API server is live, or
+// unknown unless includeUnknownAPIServer is set.
+func filterOrphans(logger logrus.FieldLogger, orphans []providers.Orphan, cutoff time.Time, exclude sets.String, includeUnknownAPIServer bool, live func(address string) bool) []providers.Orphan {
+	var old []providers.Orphan
+	for _, orphan := range orphans {
+		switch {
+		case exclude.Has(orphan.Metadata.InfraID):
+			logger.Infof("Skipping %s: excluded", orphan.Metadata.InfraID)
+		case orphan.Marker == "":
+			logger.Warnf("Skipping %s: none of its %d resources identifies it as created by the OpenShift installer", orphan.Metadata.InfraID, orphan.Resources.Len())
+		case orphan.Created.IsZero():
+			logger.Warnf("Skipping %s: none of its %d resources records when it was created", orphan.Metadata.InfraID, orphan.Resources.Len())
+		case orphan.Created.After(cutoff):
+			logger.Debugf("Skipping %s: created at %s", orphan.Metadata.InfraID, orphan.Created.Format(time.RFC3339))
+		case orphan.APIServer == "" && !includeUnknownAPIServer:
+			logger.Warnf("Skipping %s: the address of its API server is unknown, so it may be live", orphan.Metadata.InfraID)
+		case orphan.APIServer != "" && live(orphan.APIServer):
+			logger.Warnf("Skipping %s: its API server %s accepts connections", orphan.Metadata.InfraID, orphan.APIServer)
+		default:
+			old = append(old, orphan)
+		}
+	}
+	return old
+}
+
+// apiServerLive returns whether the API server at the address accepts
+// connections.
+func apiServerLive(ctx context.Context, address string) bool {
+	dialer := &net.Dialer{Timeout: apiServerTimeout}
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return false
+	}
+	conn.Close()
+	return true
+}
+
+func newDestroyer(logger logrus.FieldLogger, rootDir string) (providers.Destroyer, string, error) {
+	metadata, err := cluster.LoadMetadata(rootDir)
+	if err != nil {
+		return nil, "", err
+	}
+	return newDestroyerForMetadata(logger, metadata)
+}
 
+func newDestroyerForMetadata(logger logrus.FieldLogger, metadata *types.ClusterMetadata) (providers.Destroyer, string, error) {
 	platform := metadata.Platform()
 	if platform == "" {
-		return nil, errors.New("no platform configured in metadata")
+		return nil, "", errors.New("no platform configured in metadata")
 	}
 
 	creator, ok := providers.Registry[platform]
 	if !ok {
-		return nil, errors.Errorf("no destroyers registered for %q", platform)
+		return nil, platform, errors.Errorf("no destroyers registered for %q", platform)
 	}
-	return creator(logger, metadata)
+	destroyer, err := creator(logger, metadata)
+	return destroyer, platform, err
 }
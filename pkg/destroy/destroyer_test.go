@@ -0,0 +1,185 @@
+package destroy
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/openshift/installer/pkg/destroy/providers"
+	"github.com/openshift/installer/pkg/types"
+	"github.com/openshift/installer/pkg/types/ovirt"
+)
+
+func TestFilterOrphans(t *testing.T) {
+	cutoff := time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+	orphan := func(infraID string, created time.Time) providers.Orphan {
+		return providers.Orphan{
+			Metadata:  &types.ClusterMetadata{InfraID: infraID},
+			Marker:    "Name=" + infraID + "-int",
+			APIServer: "api." + infraID + ".example.com:6443",
+			Created:   created,
+			Resources: providers.Inventory{"ec2:instance": {"i-" + infraID}},
+		}
+	}
+	unmarked := orphan("unmarked", cutoff.Add(-time.Hour))
+	unmarked.Marker = ""
+	unknownAPIServer := orphan("unknown-api-server", cutoff.Add(-time.Hour))
+	unknownAPIServer.APIServer = ""
+
+	cases := []struct {
+		name                    string
+		orphans                 []providers.Orphan
+		includeUnknownAPIServer bool
+		expected                []string
+	}{
+		{
+			name: "none",
+		},
+		{
+			name: "older than the cutoff",
+			orphans: []providers.Orphan{
+				orphan("old", cutoff.Add(-time.Hour)),
+				orphan("at-cutoff", cutoff),
+			},
+			expected: []string{"old", "at-cutoff"},
+		},
+		{
+			name: "not created by the installer",
+			orphans: []providers.Orphan{
+				orphan("old", cutoff.Add(-time.Hour)),
+				unmarked,
+			},
+			expected: []string{"old"},
+		},
+		{
+			name: "excluded",
+			orphans: []providers.Orphan{
+				orphan("old", cutoff.Add(-time.Hour)),
+				orphan("excluded", cutoff.Add(-time.Hour)),
+			},
+			expected: []string{"old"},
+		},
+		{
+			name: "live API server",
+			orphans: []providers.Orphan{
+				orphan("old", cutoff.Add(-time.Hour)),
+				orphan("live", cutoff.Add(-time.Hour)),
+			},
+			expected: []string{"old"},
+		},
+		{
+			name: "unknown API server",
+			orphans: []providers.Orphan{
+				orphan("old", cutoff.Add(-time.Hour)),
+				unknownAPIServer,
+			},
+			expected: []string{"old"},
+		},
+		{
+			name: "unknown API server included",
+			orphans: []providers.Orphan{
+				orphan("old", cutoff.Add(-time.Hour)),
+				unknownAPIServer,
+			},
+			includeUnknownAPIServer: true,
+			expected:                []string{"old", "unknown-api-server"},
+		},
+		{
+			name: "newer than the cutoff",
+			orphans: []providers.Orphan{
+				orphan("old", cutoff.Add(-time.Hour)),
+				orphan("new", cutoff.Add(time.Minute)),
+			},
+			expected: []string{"old"},
+		},
+		{
+			name: "unknown creation time",
+			orphans: []providers.Orphan{
+				orphan("unknown", time.Time{}),
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var infraIDs []string
+			live := func(address string) bool {
+				return address == "api.live.example.com:6443"
+			}
+			for _, orphan := range filterOrphans(logrus.StandardLogger(), tc.orphans, cutoff, sets.NewString("excluded"), tc.includeUnknownAPIServer, live) {
+				infraIDs = append(infraIDs, orphan.Metadata.InfraID)
+			}
+			assert.Equal(t, tc.expected, infraIDs)
+		})
+	}
+}
+
+type fakeDestroyer struct{}
+
+func (d *fakeDestroyer) Run() (*types.ClusterQuota, error) {
+	return nil, nil
+}
+
+type fakeInventorier struct {
+	fakeDestroyer
+}
+
+func (d *fakeInventorier) Inventory() (providers.Inventory, error) {
+	return providers.Inventory{"vm": {"vm-1"}}, nil
+}
+
+func TestInventory(t *testing.T) {
+	cases := []struct {
+		name              string
+		destroyer         providers.Destroyer
+		expectedInventory providers.Inventory
+		expectedError     string
+	}{
+		{
+			name:              "supported platform",
+			destroyer:         &fakeInventorier{},
+			expectedInventory: providers.Inventory{"vm": {"vm-1"}},
+		},
+		{
+			name:          "unsupported platform",
+			destroyer:     &fakeDestroyer{},
+			expectedError: "listing the resources to destroy is not supported on ovirt",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			metadata := &types.ClusterMetadata{InfraID: "test-abcde"}
+			metadata.Ovirt = &ovirt.Metadata{}
+			raw, err := json.Marshal(metadata)
+			require.NoError(t, err)
+			require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "metadata.json"), raw, 0600))
+
+			registered, ok := providers.Registry["ovirt"]
+			providers.Registry["ovirt"] = func(logrus.FieldLogger, *types.ClusterMetadata) (providers.Destroyer, error) {
+				return tc.destroyer, nil
+			}
+			defer func() {
+				if ok {
+					providers.Registry["ovirt"] = registered
+				} else {
+					delete(providers.Registry, "ovirt")
+				}
+			}()
+
+			inventory, err := Inventory(logrus.StandardLogger(), dir)
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedInventory, inventory)
+		})
+	}
+}
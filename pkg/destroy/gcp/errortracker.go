@@ -8,18 +8,28 @@ import (
 
 const (
 	suppressDuration = time.Minute * 5
+
+	// stuckFailures is the number of errors after which a resource is
+	// reported as stuck.
+	stuckFailures = 3
 )
 
 // errorTracker holds a history of errors
 type errorTracker struct {
-	history map[string]time.Time
+	history  map[string]time.Time
+	failures map[string]int
+	last     map[string]error
 }
 
 // suppressWarning logs errors WARN once every duration and the rest to DEBUG
 func (o *errorTracker) suppressWarning(identifier string, err error, logger logrus.FieldLogger) {
 	if o.history == nil {
 		o.history = map[string]time.Time{}
+		o.failures = map[string]int{}
+		o.last = map[string]error{}
 	}
+	o.failures[identifier]++
+	o.last[identifier] = err
 	if firstSeen, ok := o.history[identifier]; ok {
 		if time.Since(firstSeen) > suppressDuration {
 			logger.Warn(err)
@@ -32,3 +42,12 @@ func (o *errorTracker) suppressWarning(identifier string, err error, logger logr
 		logger.Debug(err)
 	}
 }
+
+// stuckError returns the last error for the identifier if there were at least
+// stuckFailures errors for it, and nil otherwise.
+func (o *errorTracker) stuckError(identifier string) error {
+	if o.failures[identifier] < stuckFailures {
+		return nil
+	}
+	return o.last[identifier]
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"sort"
 	"strings"
 	"time"
 
@@ -55,6 +56,9 @@ type ClusterUninstaller struct {
 	// from metadata or by inferring it from existing cluster resources.
 	cloudControllerUID string
 
+	// progress reports the pending items periodically.
+	progress providers.ProgressReporter
+
 	errorTracker
 	requestIDTracker
 	pendingItemTracker
@@ -76,22 +80,20 @@ func New(logger logrus.FieldLogger, metadata *types.ClusterMetadata) (providers.
 
 // Run is the entrypoint to start the uninstall process
 func (o *ClusterUninstaller) Run() (*types.ClusterQuota, error) {
+	return o.RunContext(o.Context)
+}
+
+// RunContext runs the uninstall process until the context is done. The
+// resources that could not be destroyed by then are returned in a
+// *providers.LeftoversError.
+func (o *ClusterUninstaller) RunContext(runCtx context.Context) (*types.ClusterQuota, error) {
+	o.Context = runCtx
 	ctx, cancel := o.contextWithTimeout()
 	defer cancel()
 
-	ssn, err := gcpconfig.GetSession(ctx)
+	err := o.configureServices(ctx)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to get session")
-	}
-
-	options := []option.ClientOption{
-		option.WithCredentials(ssn.Credentials),
-		option.WithUserAgent(fmt.Sprintf("OpenShift/4.x Destroyer/%s", version.Raw)),
-	}
-
-	o.computeSvc, err = compute.NewService(ctx, options...)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to create compute service")
+		return nil, err
 	}
 
 	o.cpusByMachineType = map[string]int64{}
@@ -107,36 +109,116 @@ func (o *ClusterUninstaller) Run() (*types.ClusterQuota, error) {
 		return nil, errors.Wrap(err, "failed to cache machine types")
 	}
 
+	err = wait.PollImmediateUntil(
+		time.Second*10,
+		o.destroyCluster,
+		o.Context.Done(),
+	)
+	if err != nil {
+		if err := o.Context.Err(); err != nil {
+			return nil, &providers.LeftoversError{Err: err, Leftovers: o.leftovers()}
+		}
+		return nil, errors.Wrap(err, "failed to destroy cluster")
+	}
+
+	quota := gcptypes.Quota(o.pendingItemTracker.removedQuota)
+	return &types.ClusterQuota{GCP: &quota}, nil
+}
+
+// Inventory returns the resources that would be deleted, grouped by type,
+// without deleting any of them. Bucket objects, which are deleted along with
+// their buckets, and DNS records are not included.
+func (o *ClusterUninstaller) Inventory() (providers.Inventory, error) {
+	ctx, cancel := o.contextWithTimeout()
+	defer cancel()
+
+	err := o.configureServices(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := o.discoverCloudControllerResources(); err != nil {
+		return nil, err
+	}
+	listers := []struct {
+		typeName string
+		list     func() ([]cloudResource, error)
+	}{
+		{typeName: "instance", list: o.listInstances},
+		{typeName: "disk", list: o.listDisks},
+		{typeName: "serviceaccount", list: o.listServiceAccounts},
+		{typeName: "image", list: o.listImages},
+		{typeName: "bucket", list: o.listBuckets},
+		{typeName: "route", list: o.listRoutes},
+		{typeName: "firewall", list: o.listFirewalls},
+		{typeName: "address", list: o.listAddresses},
+		{typeName: "targetpool", list: o.listTargetPools},
+		{typeName: "instancegroup", list: o.listInstanceGroups},
+		{typeName: "forwardingrule", list: o.listForwardingRules},
+		{typeName: "backendservice", list: o.listBackendServices},
+		{typeName: "healthcheck", list: o.listHealthChecks},
+		{typeName: "httphealthcheck", list: o.listHTTPHealthChecks},
+		{typeName: "router", list: o.listRouters},
+		{typeName: "subnetwork", list: o.listSubnetworks},
+		{typeName: "network", list: o.listNetworks},
+	}
+	for _, lister := range listers {
+		found, err := lister.list()
+		if err != nil {
+			return nil, err
+		}
+		o.insertPendingItems(lister.typeName, found)
+	}
+
+	privateZone, _, err := o.listDNSZones()
+	if err != nil {
+		return nil, err
+	}
+	if privateZone != nil {
+		o.insertPendingItems("dnszone", []cloudResource{{key: privateZone.name, name: privateZone.name, typeName: "dnszone"}})
+	}
+
+	return o.leftovers(), nil
+}
+
+// configureServices creates the clients of the GCP services.
+func (o *ClusterUninstaller) configureServices(ctx context.Context) error {
+	ssn, err := gcpconfig.GetSession(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to get session")
+	}
+
+	options := []option.ClientOption{
+		option.WithCredentials(ssn.Credentials),
+		option.WithUserAgent(fmt.Sprintf("OpenShift/4.x Destroyer/%s", version.Raw)),
+	}
+
+	o.computeSvc, err = compute.NewService(ctx, options...)
+	if err != nil {
+		return errors.Wrap(err, "failed to create compute service")
+	}
+
 	o.iamSvc, err = iam.NewService(ctx, options...)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to create iam service")
+		return errors.Wrap(err, "failed to create iam service")
 	}
 
 	o.dnsSvc, err = dns.NewService(ctx, options...)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to create dns service")
+		return errors.Wrap(err, "failed to create dns service")
 	}
 
 	o.storageSvc, err = storage.NewService(ctx, options...)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to create storage service")
+		return errors.Wrap(err, "failed to create storage service")
 	}
 
 	o.rmSvc, err = resourcemanager.NewService(ctx, options...)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to create resourcemanager service")
-	}
-
-	err = wait.PollImmediateInfinite(
-		time.Second*10,
-		o.destroyCluster,
-	)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to destroy cluster")
+		return errors.Wrap(err, "failed to create resourcemanager service")
 	}
 
-	quota := gcptypes.Quota(o.pendingItemTracker.removedQuota)
-	return &types.ClusterQuota{GCP: &quota}, nil
+	return nil
 }
 
 func (o *ClusterUninstaller) destroyCluster() (bool, error) {
@@ -179,9 +261,41 @@ func (o *ClusterUninstaller) destroyCluster() (bool, error) {
 			}
 		}
 	}
+	if !done {
+		o.reportProgress()
+	}
 	return done, nil
 }
 
+// leftovers returns the pending items, by type.
+func (o *ClusterUninstaller) leftovers() providers.Inventory {
+	inventory := providers.Inventory{}
+	for itemType, items := range o.pendingItems {
+		for _, item := range items {
+			inventory.Add(itemType, item.name)
+		}
+	}
+	for _, ids := range inventory {
+		sort.Strings(ids)
+	}
+	return inventory
+}
+
+// reportProgress reports the pending items, and those that are stuck with
+// repeated errors.
+func (o *ClusterUninstaller) reportProgress() {
+	stuck := map[string]error{}
+	for itemType, items := range o.pendingItems {
+		for _, item := range items {
+			if err := o.errorTracker.stuckError(item.key); err != nil {
+				stuck[fmt.Sprintf("%s %s", itemType, item.name)] = err
+			}
+		}
+	}
+	o.progress.Logger = o.Logger
+	o.progress.Report(o.leftovers(), stuck)
+}
+
 // getZoneName extracts a zone name from a zone URL
 func (o *ClusterUninstaller) getZoneName(zoneURL string) string {
 	return getNameFromURL("zones", zoneURL)
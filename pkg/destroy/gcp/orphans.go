@@ -0,0 +1,170 @@
+package gcp
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/api/compute/v1"
+	"google.golang.org/api/option"
+
+	gcpconfig "github.com/openshift/installer/pkg/asset/installconfig/gcp"
+	"github.com/openshift/installer/pkg/destroy/providers"
+	"github.com/openshift/installer/pkg/types"
+	gcptypes "github.com/openshift/installer/pkg/types/gcp"
+	"github.com/openshift/installer/pkg/version"
+)
+
+// clusterLabelPrefix is the prefix of the label keys marking the resources of
+// a cluster, followed by the infra ID of the cluster.
+const clusterLabelPrefix = "kubernetes-io-cluster-"
+
+// FindOrphans finds the clusters that own instances or disks in the region of
+// the project, from the kubernetes-io-cluster-<infraID>=owned labels of the
+// resources.
+func FindOrphans(ctx context.Context, logger logrus.FieldLogger, opts providers.OrphanOptions) ([]providers.Orphan, error) {
+	if opts.Project == "" {
+		return nil, errors.New("a project is required to find orphaned clusters on GCP")
+	}
+
+	computeSvc, err := newComputeService(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return findOrphans(ctx, computeSvc, opts.Region, opts.Project, "")
+}
+
+// DiscoverMetadata recovers the metadata of the cluster from the
+// kubernetes-io-cluster-<infraID>=owned labels of its instances and disks in
+// the region of the project.
+func DiscoverMetadata(ctx context.Context, logger logrus.FieldLogger, opts providers.DiscoverOptions) (*types.ClusterMetadata, error) {
+	if opts.Project == "" || opts.Region == "" {
+		return nil, errors.New("a project and a region are required to discover the cluster metadata on GCP")
+	}
+
+	computeSvc, err := newComputeService(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return discoverMetadata(ctx, logger, computeSvc, opts.Region, opts.Project, opts.InfraID)
+}
+
+func discoverMetadata(ctx context.Context, logger logrus.FieldLogger, computeSvc *compute.Service, region, project, infraID string) (*types.ClusterMetadata, error) {
+	filter := fmt.Sprintf("labels.%s%s = \"owned\"", clusterLabelPrefix, infraID)
+	orphans, err := findOrphans(ctx, computeSvc, region, project, filter)
+	if err != nil {
+		return nil, err
+	}
+	for _, orphan := range orphans {
+		if orphan.Metadata.InfraID == infraID {
+			logger.Debugf("Found %d resources owned by %s", orphan.Resources.Len(), infraID)
+			return orphan.Metadata, nil
+		}
+	}
+	return nil, errors.Errorf("no resources labeled %s%s=owned found in %s of project %s", clusterLabelPrefix, infraID, region, project)
+}
+
+func newComputeService(ctx context.Context) (*compute.Service, error) {
+	ssn, err := gcpconfig.GetSession(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get session")
+	}
+	computeSvc, err := compute.NewService(ctx,
+		option.WithCredentials(ssn.Credentials),
+		option.WithUserAgent(fmt.Sprintf("OpenShift/4.x Destroyer/%s", version.Raw)),
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create compute service")
+	}
+	return computeSvc, nil
+}
+
+// findOrphans returns the clusters owning the instances and disks in the
+// region of the project that match the filter, or all the labeled instances
+// and disks if the filter is empty.
+func findOrphans(ctx context.Context, computeSvc *compute.Service, region, project, filter string) ([]providers.Orphan, error) {
+	orphans := map[string]*providers.Orphan{}
+	found := func(resourceType, zone, name, creationTimestamp string, labels map[string]string) {
+		if !strings.HasPrefix(getNameFromURL("zones", zone), region+"-") {
+			return
+		}
+		created, err := time.Parse(time.RFC3339, creationTimestamp)
+		for key, value := range labels {
+			if !strings.HasPrefix(key, clusterLabelPrefix) || value != "owned" {
+				continue
+			}
+			infraID := strings.TrimPrefix(key, clusterLabelPrefix)
+			orphan, ok := orphans[infraID]
+			if !ok {
+				orphan = &providers.Orphan{
+					Metadata: &types.ClusterMetadata{
+						InfraID: infraID,
+						ClusterPlatformMetadata: types.ClusterPlatformMetadata{
+							GCP: &gcptypes.Metadata{Region: region, ProjectID: project},
+						},
+					},
+					Resources: providers.Inventory{},
+				}
+				orphans[infraID] = orphan
+			}
+			orphan.Resources.Add(resourceType, name)
+			// Other Kubernetes distributions, like GKE, also label their
+			// resources, but the control-plane machines of the cluster are
+			// only created by the installer.
+			if orphan.Marker == "" && strings.HasPrefix(name, infraID+"-master-") {
+				orphan.Marker = "name=" + name
+			}
+			if err == nil && (orphan.Created.IsZero() || created.Before(orphan.Created)) {
+				orphan.Created = created
+			}
+		}
+	}
+
+	instancesReq := computeSvc.Instances.AggregatedList(project).
+		Fields("items/*/instances(name,zone,labels,creationTimestamp),nextPageToken")
+	disksReq := computeSvc.Disks.AggregatedList(project).
+		Fields("items/*/disks(name,zone,labels,creationTimestamp),nextPageToken")
+	if filter != "" {
+		instancesReq = instancesReq.Filter(filter)
+		disksReq = disksReq.Filter(filter)
+	}
+
+	err := instancesReq.Pages(ctx, func(list *compute.InstanceAggregatedList) error {
+		for _, scopedList := range list.Items {
+			for _, item := range scopedList.Instances {
+				found("compute:instance", item.Zone, item.Name, item.CreationTimestamp, item.Labels)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list instances")
+	}
+
+	err = disksReq.Pages(ctx, func(list *compute.DiskAggregatedList) error {
+		for _, scopedList := range list.Items {
+			for _, item := range scopedList.Disks {
+				found("compute:disk", item.Zone, item.Name, item.CreationTimestamp, item.Labels)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list disks")
+	}
+
+	infraIDs := make([]string, 0, len(orphans))
+	for infraID := range orphans {
+		infraIDs = append(infraIDs, infraID)
+	}
+	sort.Strings(infraIDs)
+	result := make([]providers.Orphan, 0, len(orphans))
+	for _, infraID := range infraIDs {
+		result = append(result, *orphans[infraID])
+	}
+	return result, nil
+}
@@ -0,0 +1,77 @@
+package gcp
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/api/compute/v1"
+	"google.golang.org/api/option"
+
+	"github.com/openshift/installer/pkg/destroy/providers"
+	"github.com/openshift/installer/pkg/types"
+	gcptypes "github.com/openshift/installer/pkg/types/gcp"
+)
+
+// fakeOrphansGCP serves the aggregated instance and disk lists. Cluster "a"
+// owns an instance and a disk in us-east1; cluster "b" owns an instance in
+// europe-west1.
+func fakeOrphansGCP(t *testing.T) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/aggregated/instances"):
+			fmt.Fprint(w, `{"items": {
+  "zones/us-east1-b": {"instances": [{"name": "a-master-0", "zone": "https://www.googleapis.com/compute/v1/projects/p/zones/us-east1-b", "creationTimestamp": "2021-10-01T05:00:00.000-07:00", "labels": {"kubernetes-io-cluster-a": "owned"}}]},
+  "zones/europe-west1-b": {"instances": [{"name": "b-master-0", "zone": "https://www.googleapis.com/compute/v1/projects/p/zones/europe-west1-b", "creationTimestamp": "2021-10-01T05:00:00.000-07:00", "labels": {"kubernetes-io-cluster-b": "owned"}}]}
+}}`)
+		case strings.HasSuffix(r.URL.Path, "/aggregated/disks"):
+			fmt.Fprint(w, `{"items": {
+  "zones/us-east1-b": {"disks": [{"name": "a-master-0", "zone": "https://www.googleapis.com/compute/v1/projects/p/zones/us-east1-b", "creationTimestamp": "2021-10-01T04:59:00.000-07:00", "labels": {"kubernetes-io-cluster-a": "owned"}}]}
+}}`)
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+}
+
+func TestDiscoverMetadata(t *testing.T) {
+	server := httptest.NewServer(fakeOrphansGCP(t))
+	defer server.Close()
+
+	computeSvc, err := compute.NewService(context.Background(), option.WithEndpoint(server.URL+"/"), option.WithoutAuthentication())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	orphans, err := findOrphans(context.Background(), computeSvc, "us-east1", "p", "")
+	assert.NoError(t, err)
+	assert.Equal(t, []providers.Orphan{{
+		Metadata: &types.ClusterMetadata{
+			InfraID: "a",
+			ClusterPlatformMetadata: types.ClusterPlatformMetadata{
+				GCP: &gcptypes.Metadata{Region: "us-east1", ProjectID: "p"},
+			},
+		},
+		Marker:  "name=a-master-0",
+		Created: time.Date(2021, 10, 1, 4, 59, 0, 0, time.FixedZone("", -7*60*60)),
+		Resources: providers.Inventory{
+			"compute:instance": {"a-master-0"},
+			"compute:disk":     {"a-master-0"},
+		},
+	}}, orphans)
+
+	metadata, err := discoverMetadata(context.Background(), logrus.StandardLogger(), computeSvc, "us-east1", "p", "a")
+	assert.NoError(t, err)
+	assert.Equal(t, orphans[0].Metadata, metadata)
+
+	_, err = discoverMetadata(context.Background(), logrus.StandardLogger(), computeSvc, "us-east1", "p", "b")
+	assert.EqualError(t, err, "no resources labeled kubernetes-io-cluster-b=owned found in us-east1 of project p")
+}
@@ -4,4 +4,6 @@ import "github.com/openshift/installer/pkg/destroy/providers"
 
 func init() {
 	providers.Registry["gcp"] = New
+	providers.OrphanFinders["gcp"] = FindOrphans
+	providers.MetadataDiscoverers["gcp"] = DiscoverMetadata
 }
@@ -1,5 +1,7 @@
 package ibmcloud
 
+import "github.com/openshift/installer/pkg/types"
+
 // cloudResource hold various fields for any given cloud resource
 type cloudResource struct {
 	key      string
@@ -7,6 +9,7 @@ type cloudResource struct {
 	status   string
 	typeName string
 	id       string
+	quota    types.PlatformQuota
 }
 
 type cloudResources map[string]cloudResource
@@ -6,6 +6,8 @@ import (
 
 	"github.com/IBM/vpc-go-sdk/vpcv1"
 	"github.com/pkg/errors"
+
+	"github.com/openshift/installer/pkg/destroy/quota"
 )
 
 const floatingIPTypeName = "floating ip"
@@ -32,6 +34,7 @@ func (o *ClusterUninstaller) listFloatingIPs() (cloudResources, error) {
 				status:   *floatingIPs.Status,
 				typeName: floatingIPTypeName,
 				id:       *floatingIPs.ID,
+				quota:    quota.PublicIP(),
 			})
 		}
 	}
@@ -55,6 +58,7 @@ func (o *ClusterUninstaller) deleteFloatingIP(item cloudResource) error {
 	if err != nil && details != nil && details.StatusCode == http.StatusNotFound {
 		// The resource is gone
 		o.deletePendingItems(item.typeName, []cloudResource{item})
+		o.footprint.Record(item.key, item.quota)
 		o.Logger.Infof("Deleted floating IP %q", item.name)
 		return nil
 	}
@@ -80,6 +84,7 @@ func (o *ClusterUninstaller) destroyFloatingIPs() error {
 		if _, ok := found[item.key]; !ok {
 			// This item has finished deletion.
 			o.deletePendingItems(item.typeName, []cloudResource{item})
+			o.footprint.Record(item.key, item.quota)
 			o.Logger.Infof("Deleted floating IP %q", item.name)
 			continue
 		}
@@ -22,6 +22,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/wait"
 
 	"github.com/openshift/installer/pkg/destroy/providers"
+	"github.com/openshift/installer/pkg/destroy/quota"
 	"github.com/openshift/installer/pkg/types"
 	"github.com/openshift/installer/pkg/version"
 )
@@ -54,6 +55,9 @@ type ClusterUninstaller struct {
 	resourceGroupID string
 	cosInstanceID   string
 
+	// footprint records the quota released by the deleted resources.
+	footprint *quota.Footprint
+
 	errorTracker
 	pendingItemTracker
 }
@@ -73,6 +77,7 @@ func New(logger logrus.FieldLogger, metadata *types.ClusterMetadata) (providers.
 		UserProvidedSubnets: metadata.ClusterPlatformMetadata.IBMCloud.Subnets,
 		UserProvidedVPC:     metadata.ClusterPlatformMetadata.IBMCloud.VPC,
 		pendingItemTracker:  newPendingItemTracker(),
+		footprint:           quota.NewFootprint(),
 	}, nil
 }
 
@@ -88,7 +93,7 @@ func (o *ClusterUninstaller) Run() (*types.ClusterQuota, error) {
 		return nil, errors.Wrap(err, "failed to destroy cluster")
 	}
 
-	return nil, nil
+	return &types.ClusterQuota{IBMCloud: o.footprint.Quota()}, nil
 }
 
 func (o *ClusterUninstaller) destroyCluster() error {
@@ -8,6 +8,8 @@ import (
 	"github.com/IBM/go-sdk-core/v5/core"
 	"github.com/IBM/vpc-go-sdk/vpcv1"
 	"github.com/pkg/errors"
+
+	"github.com/openshift/installer/pkg/destroy/quota"
 )
 
 const (
@@ -30,12 +32,21 @@ func (o *ClusterUninstaller) listInstances() (cloudResources, error) {
 	result := []cloudResource{}
 	for _, instance := range resources.Instances {
 		if strings.Contains(*instance.Name, o.InfraID) {
+			var profile string
+			if instance.Profile != nil {
+				profile = core.StringNilMapper(instance.Profile.Name)
+			}
+			var vCPUs int64
+			if instance.Vcpu != nil && instance.Vcpu.Count != nil {
+				vCPUs = *instance.Vcpu.Count
+			}
 			result = append(result, cloudResource{
 				key:      *instance.ID,
 				name:     *instance.Name,
 				status:   *instance.Status,
 				typeName: "instance",
 				id:       *instance.ID,
+				quota:    quota.Instance(profile, vCPUs),
 			})
 		}
 	}
@@ -115,6 +126,7 @@ func (o *ClusterUninstaller) deleteInstance(item cloudResource) error {
 	if err != nil && details != nil && details.StatusCode == http.StatusNotFound {
 		// The resource is gone
 		o.deletePendingItems(item.typeName, []cloudResource{item})
+		o.footprint.Record(item.key, item.quota)
 		o.Logger.Infof("Deleted instance %q", item.name)
 		return nil
 	}
@@ -139,6 +151,7 @@ func (o *ClusterUninstaller) destroyInstances() error {
 		if _, ok := found[item.key]; !ok {
 			// This item has finished deletion.
 			o.deletePendingItems(item.typeName, []cloudResource{item})
+			o.footprint.Record(item.key, item.quota)
 			o.Logger.Infof("Deleted instance %q", item.name)
 			continue
 		}
@@ -6,6 +6,8 @@ import (
 
 	"github.com/IBM/vpc-go-sdk/vpcv1"
 	"github.com/pkg/errors"
+
+	"github.com/openshift/installer/pkg/destroy/quota"
 )
 
 const loadBalancerTypeName = "load balancer"
@@ -31,6 +33,7 @@ func (o *ClusterUninstaller) listLoadBalancers() (cloudResources, error) {
 				status:   *loadbalancer.ProvisioningStatus,
 				typeName: loadBalancerTypeName,
 				id:       *loadbalancer.ID,
+				quota:    quota.LoadBalancer(),
 			})
 		}
 	}
@@ -54,6 +57,7 @@ func (o *ClusterUninstaller) deleteLoadBalancer(item cloudResource) error {
 	if err != nil && details != nil && details.StatusCode == http.StatusNotFound {
 		// The resource is gone.
 		o.deletePendingItems(item.typeName, []cloudResource{item})
+		o.footprint.Record(item.key, item.quota)
 		o.Logger.Infof("Deleted load balancer %q", item.name)
 		return nil
 	}
@@ -78,6 +82,7 @@ func (o *ClusterUninstaller) destroyLoadBalancers() error {
 		if _, ok := found[item.key]; !ok {
 			// This item has finished deletion.
 			o.deletePendingItems(item.typeName, []cloudResource{item})
+			o.footprint.Record(item.key, item.quota)
 			o.Logger.Infof("Deleted load balancer %q", item.name)
 			continue
 		}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/openshift/installer/pkg/destroy/providers"
+	"github.com/openshift/installer/pkg/destroy/quota"
 	"github.com/openshift/installer/pkg/types"
 	openstackdefaults "github.com/openshift/installer/pkg/types/openstack/defaults"
 	"github.com/openshift/installer/pkg/types/openstack/validation/networkextensions"
@@ -14,6 +15,7 @@ import (
 	"github.com/gophercloud/gophercloud/openstack/blockstorage/v3/snapshots"
 	"github.com/gophercloud/gophercloud/openstack/blockstorage/v3/volumes"
 	"github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/servergroups"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/flavors"
 	"github.com/gophercloud/gophercloud/openstack/compute/v2/servers"
 	"github.com/gophercloud/gophercloud/openstack/imageservice/v2/images"
 	"github.com/gophercloud/gophercloud/openstack/loadbalancer/v2/apiversions"
@@ -65,6 +67,17 @@ type ObjectWithTags struct {
 // completion, and the error is for unrecoverable errors.
 type deleteFunc func(opts *clientconfig.ClientOpts, filter Filter, logger logrus.FieldLogger) (bool, error)
 
+// recordingDeleteFunc is a deleteFunc that also records the quota released by
+// the resources it deletes.
+type recordingDeleteFunc func(opts *clientconfig.ClientOpts, filter Filter, logger logrus.FieldLogger, footprint *quota.Footprint) (bool, error)
+
+// recordTo returns the deleteFunc recording to the footprint.
+func (f recordingDeleteFunc) recordTo(footprint *quota.Footprint) deleteFunc {
+	return func(opts *clientconfig.ClientOpts, filter Filter, logger logrus.FieldLogger) (bool, error) {
+		return f(opts, filter, logger, footprint)
+	}
+}
+
 // ClusterUninstaller holds the various options for the cluster we want to delete.
 type ClusterUninstaller struct {
 	// Cloud is the cloud name as set in clouds.yml
@@ -96,13 +109,15 @@ func (o *ClusterUninstaller) Run() (*types.ClusterQuota, error) {
 		return nil, err
 	}
 
+	footprint := quota.NewFootprint()
+
 	// deleteFuncs contains the functions that will be launched as
 	// goroutines.
 	deleteFuncs := map[string]deleteFunc{
-		"deleteServers":         deleteServers,
+		"deleteServers":         recordingDeleteFunc(deleteServers).recordTo(footprint),
 		"deleteServerGroups":    deleteServerGroups,
 		"deleteTrunks":          deleteTrunks,
-		"deleteLoadBalancers":   deleteLoadBalancers,
+		"deleteLoadBalancers":   recordingDeleteFunc(deleteLoadBalancers).recordTo(footprint),
 		"deletePorts":           deletePorts,
 		"deleteSecurityGroups":  deleteSecurityGroups,
 		"clearRouterInterfaces": clearRouterInterfaces,
@@ -110,9 +125,9 @@ func (o *ClusterUninstaller) Run() (*types.ClusterQuota, error) {
 		"deleteSubnetPools":     deleteSubnetPools,
 		"deleteNetworks":        deleteNetworks,
 		"deleteContainers":      deleteContainers,
-		"deleteVolumes":         deleteVolumes,
+		"deleteVolumes":         recordingDeleteFunc(deleteVolumes).recordTo(footprint),
 		"deleteShares":          deleteShares,
-		"deleteFloatingIPs":     deleteFloatingIPs,
+		"deleteFloatingIPs":     recordingDeleteFunc(deleteFloatingIPs).recordTo(footprint),
 		"deleteImages":          deleteImages,
 	}
 	returnChannel := make(chan string)
@@ -142,7 +157,7 @@ func (o *ClusterUninstaller) Run() (*types.ClusterQuota, error) {
 		return nil, err
 	}
 
-	return nil, nil
+	return &types.ClusterQuota{OpenStack: footprint.Quota()}, nil
 }
 
 func deleteRunner(deleteFuncName string, dFunction deleteFunc, opts *clientconfig.ClientOpts, filter Filter, logger logrus.FieldLogger, channel chan string) {
@@ -209,7 +224,7 @@ func filterTags(filters Filter) []string {
 	return tags
 }
 
-func deleteServers(opts *clientconfig.ClientOpts, filter Filter, logger logrus.FieldLogger) (bool, error) {
+func deleteServers(opts *clientconfig.ClientOpts, filter Filter, logger logrus.FieldLogger, footprint *quota.Footprint) (bool, error) {
 	logger.Debug("Deleting openstack servers")
 	defer logger.Debugf("Exiting deleting openstack servers")
 
@@ -232,12 +247,17 @@ func deleteServers(opts *clientconfig.ClientOpts, filter Filter, logger logrus.F
 	}
 
 	serverObjects := []ObjectWithTags{}
+	serverFlavors := map[string]string{}
 	for _, server := range allServers {
 		serverObjects = append(
 			serverObjects, ObjectWithTags{
 				ID:   server.ID,
 				Tags: server.Metadata})
+		if id, ok := server.Flavor["id"].(string); ok {
+			serverFlavors[server.ID] = id
+		}
 	}
+	flavorUsage := map[string]types.PlatformQuota{}
 
 	filteredServers := filterObjects(serverObjects, filter)
 	numberToDelete := len(filteredServers)
@@ -255,11 +275,31 @@ func deleteServers(opts *clientconfig.ClientOpts, filter Filter, logger logrus.F
 			}
 			logger.Debugf("Cannot find server %q. It's probably already been deleted.", server.ID)
 		}
+		flavorID := serverFlavors[server.ID]
+		if _, ok := flavorUsage[flavorID]; !ok {
+			flavorUsage[flavorID] = flavorQuota(conn, flavorID, logger)
+		}
+		footprint.Record(server.ID, flavorUsage[flavorID])
 		numberDeleted++
 	}
 	return numberDeleted == numberToDelete, nil
 }
 
+// flavorQuota returns the usage of a server of the flavor with the given ID.
+// The footprint is best-effort, so a flavor that cannot be found is recorded
+// without its name and vCPUs.
+func flavorQuota(conn *gophercloud.ServiceClient, flavorID string, logger logrus.FieldLogger) types.PlatformQuota {
+	if flavorID == "" {
+		return quota.Instance("", 0)
+	}
+	flavor, err := flavors.Get(conn, flavorID).Extract()
+	if err != nil {
+		logger.Debugf("Cannot get flavor %q to record its quota: %v", flavorID, err)
+		return quota.Instance(flavorID, 0)
+	}
+	return quota.Instance(flavor.Name, int64(flavor.VCPUs))
+}
+
 func deleteServerGroups(opts *clientconfig.ClientOpts, filter Filter, logger logrus.FieldLogger) (bool, error) {
 	logger.Debug("Deleting openstack server groups")
 	defer logger.Debugf("Exiting deleting openstack server groups")
@@ -1190,7 +1230,7 @@ func deleteAssociatedTrunk(conn *gophercloud.ServiceClient, logger logrus.FieldL
 	return
 }
 
-func deleteLoadBalancers(opts *clientconfig.ClientOpts, filter Filter, logger logrus.FieldLogger) (bool, error) {
+func deleteLoadBalancers(opts *clientconfig.ClientOpts, filter Filter, logger logrus.FieldLogger, footprint *quota.Footprint) (bool, error) {
 	logger.Debug("Deleting openstack load balancers")
 	defer logger.Debugf("Exiting deleting openstack load balancers")
 
@@ -1281,6 +1321,7 @@ func deleteLoadBalancers(opts *clientconfig.ClientOpts, filter Filter, logger lo
 			}
 			logger.Debugf("Cannot find load balancer %q. It's probably already been deleted.", loadbalancer.ID)
 		}
+		footprint.Record(loadbalancer.ID, quota.LoadBalancer())
 		numberDeleted++
 	}
 
@@ -1332,7 +1373,7 @@ func deleteSubnetPools(opts *clientconfig.ClientOpts, filter Filter, logger logr
 	return numberDeleted == numberToDelete, nil
 }
 
-func deleteVolumes(opts *clientconfig.ClientOpts, filter Filter, logger logrus.FieldLogger) (bool, error) {
+func deleteVolumes(opts *clientconfig.ClientOpts, filter Filter, logger logrus.FieldLogger, footprint *quota.Footprint) (bool, error) {
 	logger.Debug("Deleting OpenStack volumes")
 	defer logger.Debugf("Exiting deleting OpenStack volumes")
 
@@ -1365,7 +1406,9 @@ func deleteVolumes(opts *clientconfig.ClientOpts, filter Filter, logger logrus.F
 	}
 
 	volumeIDs := []string{}
+	volumeSizes := map[string]int{}
 	for _, volume := range allVolumes {
+		volumeSizes[volume.ID] = volume.Size
 		// First, we need to delete all volumes that have names with the cluster ID as a prefix.
 		// They are created by the in-tree Cinder provisioner.
 		if strings.HasPrefix(volume.Name, clusterID) {
@@ -1403,6 +1446,7 @@ func deleteVolumes(opts *clientconfig.ClientOpts, filter Filter, logger logrus.F
 			}
 			logger.Debugf("Cannot find volume %q. It's probably already been deleted.", volumeID)
 		}
+		footprint.Record(volumeID, quota.Volume(int64(volumeSizes[volumeID])))
 		numberDeleted++
 	}
 
@@ -1560,7 +1604,7 @@ func deleteShareSnapshots(conn *gophercloud.ServiceClient, shareID string, logge
 	return numberDeleted == numberToDelete, nil
 }
 
-func deleteFloatingIPs(opts *clientconfig.ClientOpts, filter Filter, logger logrus.FieldLogger) (bool, error) {
+func deleteFloatingIPs(opts *clientconfig.ClientOpts, filter Filter, logger logrus.FieldLogger, footprint *quota.Footprint) (bool, error) {
 	logger.Debug("Deleting openstack floating ips")
 	defer logger.Debugf("Exiting deleting openstack floating ips")
 
@@ -1601,6 +1645,7 @@ func deleteFloatingIPs(opts *clientconfig.ClientOpts, filter Filter, logger logr
 			}
 			logger.Debugf("Cannot find floating ip %q. It's probably already been deleted.", floatingIP.ID)
 		}
+		footprint.Record(floatingIP.ID, quota.PublicIP())
 		numberDeleted++
 	}
 	return numberDeleted == numberToDelete, nil
@@ -0,0 +1,41 @@
+package providers
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/openshift/installer/pkg/types"
+)
+
+// DiscoverOptions identifies the cluster whose metadata is recovered from the
+// tags (or labels) of its resources.
+type DiscoverOptions struct {
+	// InfraID is the infra ID of the cluster.
+	InfraID string
+	// Region is the region of the cluster.
+	Region string
+	// Project is the project of the cluster, on platforms that have
+	// projects.
+	Project string
+	// ResourceGroup is the resource group of the cluster, on platforms
+	// that have resource groups. Defaults to <infraID>-rg.
+	ResourceGroup string
+	// BaseDomainResourceGroup is the resource group of the base domain,
+	// on platforms that have resource groups.
+	BaseDomainResourceGroup string
+	// CloudName is the name of the cloud of the cluster, on Azure. Defaults
+	// to the public cloud.
+	CloudName string
+	// ARMEndpoint is the Resource Manager endpoint of the cloud, on Azure
+	// Stack Hub.
+	ARMEndpoint string
+}
+
+// DiscoverMetadataFunc recovers the metadata of a cluster on a platform from
+// its resources.
+type DiscoverMetadataFunc func(ctx context.Context, logger logrus.FieldLogger, opts DiscoverOptions) (*types.ClusterMetadata, error)
+
+// MetadataDiscoverers maps ClusterMetadata.Platform() to per-platform
+// metadata discoverers.
+var MetadataDiscoverers = make(map[string]DiscoverMetadataFunc)
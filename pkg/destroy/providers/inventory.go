@@ -0,0 +1,40 @@
+package providers
+
+import (
+	"sort"
+)
+
+// Inventory maps resource types to the identifiers (e.g. ARNs or IDs) of the
+// resources of that type.
+type Inventory map[string][]string
+
+// Inventorier is implemented by destroyers that can list the resources they
+// would delete without deleting any of them.
+type Inventorier interface {
+	Inventory() (Inventory, error)
+}
+
+// Add adds the resource with the given identifier to the inventory, under
+// the given resource type.
+func (i Inventory) Add(resourceType, id string) {
+	i[resourceType] = append(i[resourceType], id)
+}
+
+// Types returns the sorted resource types of the inventory.
+func (i Inventory) Types() []string {
+	types := make([]string, 0, len(i))
+	for t := range i {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+	return types
+}
+
+// Len returns the total number of resources in the inventory.
+func (i Inventory) Len() int {
+	n := 0
+	for _, ids := range i {
+		n += len(ids)
+	}
+	return n
+}
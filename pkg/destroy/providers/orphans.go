@@ -0,0 +1,53 @@
+package providers
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/openshift/installer/pkg/types"
+)
+
+// OrphanOptions scopes the search for orphaned clusters.
+type OrphanOptions struct {
+	// Region is the region to search.
+	Region string
+	// Project is the project to search, on platforms that have projects.
+	Project string
+	// CloudName is the name of the cloud to search, on Azure. Defaults to
+	// the public cloud.
+	CloudName string
+	// ARMEndpoint is the Resource Manager endpoint of the cloud, on Azure
+	// Stack Hub.
+	ARMEndpoint string
+}
+
+// Orphan is a cluster whose resources are still in the cloud, found from the
+// ownership tags (or labels) of the resources rather than from its
+// metadata.json.
+type Orphan struct {
+	// Metadata is the metadata of the cluster, as far as it can be
+	// recovered from its resources, and is enough for the destroyer of
+	// the platform to delete them.
+	Metadata *types.ClusterMetadata `json:"metadata"`
+	// Marker is the tag (or label) of one of the resources of the cluster
+	// that identifies it as created by the OpenShift installer, or empty if
+	// none of them has one.
+	Marker string `json:"marker,omitempty"`
+	// APIServer is the address of the API server of the cluster, if it is
+	// known.
+	APIServer string `json:"apiServer,omitempty"`
+	// Created is the creation time of the oldest resource of the cluster
+	// that records one, or zero if none of them does.
+	Created time.Time `json:"created"`
+	// Resources are the owned resources that were found.
+	Resources Inventory `json:"resources"`
+}
+
+// FindOrphansFunc finds the clusters that own resources on a platform.
+type FindOrphansFunc func(ctx context.Context, logger logrus.FieldLogger, opts OrphanOptions) ([]Orphan, error)
+
+// OrphanFinders maps ClusterMetadata.Platform() to per-platform orphan
+// finders.
+var OrphanFinders = make(map[string]FindOrphansFunc)
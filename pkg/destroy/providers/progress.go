@@ -0,0 +1,85 @@
+package providers
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// defaultProgressInterval is the interval between progress summaries when
+// ProgressReporter.Interval is not set.
+const defaultProgressInterval = time.Minute
+
+// ProgressReporter logs summaries of the resources that remain to be
+// deleted, at most once per interval.
+type ProgressReporter struct {
+	Logger logrus.FieldLogger
+	// Interval is the minimum time between summaries. If zero,
+	// defaultProgressInterval is used.
+	Interval time.Duration
+
+	now  func() time.Time
+	last time.Time
+}
+
+// Report logs the number of remaining resources of each type, and the
+// resources that are stuck with their last errors, unless a summary was
+// logged less than an interval ago.
+func (p *ProgressReporter) Report(remaining Inventory, stuck map[string]error) {
+	now := time.Now
+	if p.now != nil {
+		now = p.now
+	}
+	interval := p.Interval
+	if interval == 0 {
+		interval = defaultProgressInterval
+	}
+	if !p.last.IsZero() && now().Sub(p.last) < interval {
+		return
+	}
+	p.last = now()
+
+	if remaining.Len() == 0 {
+		return
+	}
+	counts := make([]string, 0, len(remaining))
+	for _, resourceType := range remaining.Types() {
+		counts = append(counts, fmt.Sprintf("%s (%d)", resourceType, len(remaining[resourceType])))
+	}
+	p.Logger.Infof("Waiting for %d resources to be destroyed: %s", remaining.Len(), strings.Join(counts, ", "))
+
+	ids := make([]string, 0, len(stuck))
+	for id := range stuck {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		p.Logger.Warnf("%s keeps failing to be destroyed: %v", id, stuck[id])
+	}
+}
+
+// LeftoversError is returned by destroyers that gave up before destroying
+// every resource, e.g. because their deadline was hit.
+type LeftoversError struct {
+	// Err is the reason the destroyer gave up.
+	Err error
+	// Leftovers are the resources that were not destroyed.
+	Leftovers Inventory
+}
+
+func (e *LeftoversError) Error() string {
+	return fmt.Sprintf("%d resources were not destroyed: %v", e.Leftovers.Len(), e.Err)
+}
+
+// Cause returns the reason the destroyer gave up.
+func (e *LeftoversError) Cause() error {
+	return e.Err
+}
+
+// Unwrap returns the reason the destroyer gave up.
+func (e *LeftoversError) Unwrap() error {
+	return e.Err
+}
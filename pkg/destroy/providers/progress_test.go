@@ -0,0 +1,76 @@
+package providers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	logrustest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProgressReporter(t *testing.T) {
+	logger, hook := logrustest.NewNullLogger()
+	now := time.Date(2021, 10, 1, 12, 0, 0, 0, time.UTC)
+	progress := &ProgressReporter{Logger: logger, now: func() time.Time { return now }}
+
+	remaining := Inventory{
+		"ec2:vpc":      {"vpc-1"},
+		"ec2:instance": {"i-1", "i-2"},
+	}
+	stuck := map[string]error{"vpc-1": errors.New("DependencyViolation")}
+
+	cases := []struct {
+		name      string
+		advance   time.Duration
+		remaining Inventory
+		expected  []string
+	}{
+		{
+			name:      "first report",
+			remaining: remaining,
+			expected: []string{
+				"Waiting for 3 resources to be destroyed: ec2:instance (2), ec2:vpc (1)",
+				"vpc-1 keeps failing to be destroyed: DependencyViolation",
+			},
+		},
+		{
+			name:      "within the interval",
+			advance:   defaultProgressInterval / 2,
+			remaining: remaining,
+		},
+		{
+			name:      "after the interval",
+			advance:   defaultProgressInterval / 2,
+			remaining: Inventory{"ec2:vpc": {"vpc-1"}},
+			expected: []string{
+				"Waiting for 1 resources to be destroyed: ec2:vpc (1)",
+				"vpc-1 keeps failing to be destroyed: DependencyViolation",
+			},
+		},
+		{
+			name:      "nothing left",
+			advance:   defaultProgressInterval,
+			remaining: Inventory{},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			hook.Reset()
+			now = now.Add(tc.advance)
+			progress.Report(tc.remaining, stuck)
+
+			var messages []string
+			for _, entry := range hook.AllEntries() {
+				messages = append(messages, entry.Message)
+			}
+			assert.Equal(t, tc.expected, messages)
+		})
+	}
+}
+
+func TestLeftoversError(t *testing.T) {
+	err := &LeftoversError{Err: errors.New("context deadline exceeded"), Leftovers: Inventory{"ec2:vpc": {"vpc-1"}}}
+	assert.EqualError(t, err, "1 resources were not destroyed: context deadline exceeded")
+	assert.EqualError(t, errors.Cause(errors.Wrap(err, "failed")), "context deadline exceeded")
+}
@@ -1,6 +1,8 @@
 package providers
 
 import (
+	"context"
+
 	"github.com/sirupsen/logrus"
 
 	"github.com/openshift/installer/pkg/types"
@@ -12,5 +14,12 @@ type Destroyer interface {
 	Run() (*types.ClusterQuota, error)
 }
 
+// ContextDestroyer is implemented by destroyers that give up when the
+// context is done, returning a *LeftoversError that lists the resources
+// that were not destroyed.
+type ContextDestroyer interface {
+	RunContext(ctx context.Context) (*types.ClusterQuota, error)
+}
+
 // NewFunc is an interface for creating platform-specific destroyers.
 type NewFunc func(logger logrus.FieldLogger, metadata *types.ClusterMetadata) (Destroyer, error)
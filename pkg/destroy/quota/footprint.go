@@ -0,0 +1,76 @@
+package quota
+
+import (
+	"sync"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/openshift/installer/pkg/types"
+)
+
+// Footprint records the quota released by deleting the resources of a
+// cluster. The usage of a resource is recorded once, so resources whose
+// deletion is retried are not counted twice. A Footprint is safe for
+// concurrent use, and recording to a nil Footprint does nothing.
+type Footprint struct {
+	mu       sync.Mutex
+	recorded sets.String
+	quota    types.PlatformQuota
+}
+
+// NewFootprint returns an empty footprint.
+func NewFootprint() *Footprint {
+	return &Footprint{recorded: sets.NewString()}
+}
+
+// Record records the usage of the deleted resource with the given ID.
+func (f *Footprint) Record(id string, usage types.PlatformQuota) {
+	if f == nil {
+		return
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.recorded.Has(id) {
+		return
+	}
+	f.recorded.Insert(id)
+	f.quota.Add(usage)
+}
+
+// Quota returns the quota recorded so far.
+func (f *Footprint) Quota() *types.PlatformQuota {
+	if f == nil {
+		return nil
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	quota := &types.PlatformQuota{}
+	quota.Add(f.quota)
+	return quota
+}
+
+// Instance returns the usage of an instance of the given type.
+func Instance(instanceType string, vCPUs int64) types.PlatformQuota {
+	if instanceType == "" {
+		instanceType = "unknown"
+	}
+	return types.PlatformQuota{
+		Instances: map[string]int64{instanceType: 1},
+		VCPUs:     vCPUs,
+	}
+}
+
+// Volume returns the usage of a block storage volume of the given size.
+func Volume(sizeGB int64) types.PlatformQuota {
+	return types.PlatformQuota{Volumes: 1, VolumeGB: sizeGB}
+}
+
+// LoadBalancer returns the usage of a load balancer.
+func LoadBalancer() types.PlatformQuota {
+	return types.PlatformQuota{LoadBalancers: 1}
+}
+
+// PublicIP returns the usage of an elastic, floating or public IP address.
+func PublicIP() types.PlatformQuota {
+	return types.PlatformQuota{PublicIPs: 1}
+}
@@ -0,0 +1,85 @@
+package quota
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/installer/pkg/types"
+)
+
+func TestFootprint(t *testing.T) {
+	cases := []struct {
+		name     string
+		records  map[string][]types.PlatformQuota
+		expected *types.PlatformQuota
+	}{
+		{
+			name:     "empty",
+			expected: &types.PlatformQuota{},
+		},
+		{
+			name: "all resources",
+			records: map[string][]types.PlatformQuota{
+				"i-1":   {Instance("m5.xlarge", 4)},
+				"i-2":   {Instance("m5.xlarge", 4)},
+				"i-3":   {Instance("m5.2xlarge", 8)},
+				"vol-1": {Volume(120)},
+				"vol-2": {Volume(100)},
+				"lb-1":  {LoadBalancer()},
+				"eip-1": {PublicIP()},
+			},
+			expected: &types.PlatformQuota{
+				Instances:     map[string]int64{"m5.xlarge": 2, "m5.2xlarge": 1},
+				VCPUs:         16,
+				Volumes:       2,
+				VolumeGB:      220,
+				LoadBalancers: 1,
+				PublicIPs:     1,
+			},
+		},
+		{
+			name: "retried deletion",
+			records: map[string][]types.PlatformQuota{
+				"i-1": {Instance("m5.xlarge", 4), Instance("m5.xlarge", 4)},
+			},
+			expected: &types.PlatformQuota{
+				Instances: map[string]int64{"m5.xlarge": 1},
+				VCPUs:     4,
+			},
+		},
+		{
+			name: "unknown instance type",
+			records: map[string][]types.PlatformQuota{
+				"i-1": {Instance("", 0)},
+			},
+			expected: &types.PlatformQuota{
+				Instances: map[string]int64{"unknown": 1},
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			footprint := NewFootprint()
+			var wg sync.WaitGroup
+			for id, usages := range tc.records {
+				for _, usage := range usages {
+					wg.Add(1)
+					go func(id string, usage types.PlatformQuota) {
+						defer wg.Done()
+						footprint.Record(id, usage)
+					}(id, usage)
+				}
+			}
+			wg.Wait()
+			assert.Equal(t, tc.expected, footprint.Quota())
+		})
+	}
+}
+
+func TestNilFootprint(t *testing.T) {
+	var footprint *Footprint
+	footprint.Record("i-1", Instance("m5.xlarge", 4))
+	assert.Nil(t, footprint.Quota())
+}
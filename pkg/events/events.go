@@ -0,0 +1,103 @@
+// Package events emits machine-readable progress events for the installer.
+//
+// An event is attached to a regular logrus entry under the Key field, so that
+// it is logged like any other message. The Hook additionally writes each event
+// as a single line of JSON for consumption by other tools.
+package events
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Key is the logrus field under which the event is attached to an entry.
+const Key = "event"
+
+// Type is the type of an event.
+type Type string
+
+const (
+	// AssetGenerated is emitted when an asset has been generated.
+	AssetGenerated Type = "asset-generated"
+	// StageStarted is emitted when a terraform stage starts.
+	StageStarted Type = "stage-started"
+	// StageFinished is emitted when a terraform stage finishes, whether or
+	// not it was successful.
+	StageFinished Type = "stage-finished"
+	// BootstrapComplete is emitted when the bootstrap process has completed.
+	BootstrapComplete Type = "bootstrap-complete"
+	// OperatorCondition is emitted for each cluster operator condition that
+	// is reported after a failure.
+	OperatorCondition Type = "operator-condition"
+	// InstallComplete is emitted when the cluster installation has completed.
+	InstallComplete Type = "install-complete"
+)
+
+// Event is a machine-readable progress event.
+type Event struct {
+	Time    time.Time              `json:"time"`
+	Type    Type                   `json:"type"`
+	Message string                 `json:"message,omitempty"`
+	Fields  map[string]interface{} `json:"fields,omitempty"`
+}
+
+// New returns a logrus entry that carries an event of the given type with
+// the given fields. The time and message of the event are taken from the
+// entry when it is logged.
+func New(t Type, fields map[string]interface{}) *logrus.Entry {
+	return logrus.WithField(Key, &Event{Type: t, Fields: fields})
+}
+
+// Strip returns the entry without the attached event, if any. It is used by
+// hooks which should not render the event alongside the message.
+func Strip(entry *logrus.Entry) *logrus.Entry {
+	if _, ok := entry.Data[Key]; !ok {
+		return entry
+	}
+	stripped := *entry
+	stripped.Data = make(logrus.Fields, len(entry.Data)-1)
+	for k, v := range entry.Data {
+		if k != Key {
+			stripped.Data[k] = v
+		}
+	}
+	return &stripped
+}
+
+// Hook is a logrus hook that writes events as JSON lines.
+type Hook struct {
+	out io.Writer
+}
+
+var _ logrus.Hook = (*Hook)(nil)
+
+// NewHook returns a hook that writes all events to out.
+func NewHook(out io.Writer) *Hook {
+	return &Hook{out: out}
+}
+
+// Levels implements logrus.Hook.Levels. Events are written regardless of
+// the level of the entry carrying them.
+func (h *Hook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+// Fire implements logrus.Hook.Fire.
+func (h *Hook) Fire(entry *logrus.Entry) error {
+	ev, ok := entry.Data[Key].(*Event)
+	if !ok {
+		return nil
+	}
+	out := *ev
+	out.Time = entry.Time
+	out.Message = entry.Message
+	data, err := json.Marshal(out)
+	if err != nil {
+		return err
+	}
+	_, err = h.out.Write(append(data, '\n'))
+	return err
+}
@@ -0,0 +1,38 @@
+package events
+
+import (
+	"bytes"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHook(t *testing.T) {
+	buf := &bytes.Buffer{}
+	logger := logrus.New()
+	logger.SetOutput(ioutil.Discard)
+	logger.SetLevel(logrus.TraceLevel)
+	logger.AddHook(NewHook(buf))
+
+	now := time.Date(2021, 1, 2, 3, 4, 5, 0, time.UTC)
+	logger.WithTime(now).Info("not an event")
+	logger.WithTime(now).WithField(Key, &Event{
+		Type:   StageFinished,
+		Fields: map[string]interface{}{"stage": "cluster"},
+	}).Debug("Finished stage cluster")
+
+	assert.Equal(t, `{"time":"2021-01-02T03:04:05Z","type":"stage-finished","message":"Finished stage cluster","fields":{"stage":"cluster"}}`+"\n", buf.String())
+}
+
+func TestStrip(t *testing.T) {
+	entry := New(InstallComplete, nil).WithField("other", "value")
+	stripped := Strip(entry)
+	assert.Equal(t, logrus.Fields{"other": "value"}, stripped.Data)
+	assert.Contains(t, entry.Data, Key, "original entry should not be modified")
+
+	plain := logrus.WithField("other", "value")
+	assert.Same(t, plain, Strip(plain))
+}
@@ -0,0 +1,101 @@
+package explain
+
+import (
+	"path/filepath"
+	"reflect"
+	"sort"
+
+	"github.com/pkg/errors"
+
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/ignition/bootstrap"
+	"github.com/openshift/installer/pkg/asset/tls"
+)
+
+// bootstrapIgnSource is the source of the certificates embedded in the bootstrap Ignition config.
+const bootstrapIgnSource = "bootstrap.ign"
+
+// Certificates returns the inventory of the certificates of the assets on which the targets depend,
+// followed by the certificates embedded in the bootstrap Ignition config. The assets are loaded from
+// the state file or the asset directory, and the assets that were not generated yet are skipped.
+func Certificates(assetStore asset.Store, targets []asset.WritableAsset) ([]*tls.CertificateInfo, error) {
+	var certAssets []asset.Asset
+	consumers := map[reflect.Type][]string{}
+	visited := map[reflect.Type]bool{}
+	var walk func(a asset.Asset)
+	walk = func(a asset.Asset) {
+		if visited[reflect.TypeOf(a)] {
+			return
+		}
+		visited[reflect.TypeOf(a)] = true
+		if _, ok := a.(tls.CertInterface); ok {
+			certAssets = append(certAssets, a)
+		}
+		for _, d := range a.Dependencies() {
+			if _, ok := d.(tls.CertInterface); ok {
+				consumers[reflect.TypeOf(d)] = append(consumers[reflect.TypeOf(d)], a.Name())
+			}
+			walk(d)
+		}
+	}
+	for _, t := range targets {
+		walk(t)
+	}
+
+	var infos []*tls.CertificateInfo
+	for _, a := range certAssets {
+		loaded, err := assetStore.Load(a)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to load %s", a.Name())
+		}
+		if loaded == nil {
+			continue
+		}
+		certs, err := tls.PemToCertificates(loaded.(tls.CertInterface).Cert())
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse the certificate of %s", a.Name())
+		}
+		consumedBy := consumers[reflect.TypeOf(a)]
+		sort.Strings(consumedBy)
+		for _, cert := range certs {
+			info := tls.DescribeCertificate(cert)
+			info.Source = a.Name()
+			info.File = certFilename(loaded)
+			info.ConsumedBy = consumedBy
+			infos = append(infos, info)
+		}
+	}
+	sort.SliceStable(infos, func(i, j int) bool {
+		return infos[i].Source < infos[j].Source
+	})
+
+	loaded, err := assetStore.Load(&bootstrap.Bootstrap{})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load the bootstrap Ignition config")
+	}
+	if loaded != nil {
+		for _, file := range bootstrap.CertificateFiles(loaded.(*bootstrap.Bootstrap).Config) {
+			for _, cert := range file.Certificates {
+				info := tls.DescribeCertificate(cert)
+				info.Source = bootstrapIgnSource
+				info.File = file.Path
+				infos = append(infos, info)
+			}
+		}
+	}
+	return infos, nil
+}
+
+// certFilename returns the name of the certificate file of the asset, if any.
+func certFilename(a asset.Asset) string {
+	writable, ok := a.(asset.WritableAsset)
+	if !ok {
+		return ""
+	}
+	for _, f := range writable.Files() {
+		if filepath.Ext(f.Filename) == ".crt" {
+			return f.Filename
+		}
+	}
+	return ""
+}
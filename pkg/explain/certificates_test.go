@@ -0,0 +1,72 @@
+package explain
+
+import (
+	"reflect"
+	"testing"
+
+	igntypes "github.com/coreos/ignition/v2/config/v3_2/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/ignition"
+	"github.com/openshift/installer/pkg/asset/ignition/bootstrap"
+	"github.com/openshift/installer/pkg/asset/installconfig"
+	"github.com/openshift/installer/pkg/asset/tls"
+	"github.com/openshift/installer/pkg/types"
+)
+
+// fakeStore is a store of the assets that were already generated.
+type fakeStore struct {
+	asset.Store
+	assets map[reflect.Type]asset.Asset
+}
+
+func (s *fakeStore) Load(a asset.Asset) (asset.Asset, error) {
+	return s.assets[reflect.TypeOf(a)], nil
+}
+
+// consumer is an asset that consumes the root CA.
+type consumer struct{}
+
+func (*consumer) Dependencies() []asset.Asset {
+	return []asset.Asset{&tls.RootCA{}, &tls.AdminKubeConfigSignerCertKey{}}
+}
+func (*consumer) Generate(asset.Parents) error         { return nil }
+func (*consumer) Name() string                         { return "Consumer" }
+func (*consumer) Files() []*asset.File                 { return nil }
+func (*consumer) Load(asset.FileFetcher) (bool, error) { return false, nil }
+
+func TestCertificates(t *testing.T) {
+	parents := asset.Parents{}
+	parents.Add(&installconfig.InstallConfig{Config: &types.InstallConfig{KeyAlgorithm: types.KeyAlgorithmECDSAP256}})
+	rootCA := &tls.RootCA{}
+	require.NoError(t, rootCA.Generate(parents))
+
+	bootstrapIgn := &bootstrap.Bootstrap{}
+	bootstrapIgn.Config = &igntypes.Config{}
+	bootstrapIgn.Config.Storage.Files = []igntypes.File{
+		ignition.FileFromBytes("/opt/openshift/tls/root-ca.crt", "root", 0644, rootCA.Cert()),
+		ignition.FileFromBytes("/opt/openshift/tls/root-ca.key", "root", 0600, rootCA.Key()),
+	}
+
+	store := &fakeStore{assets: map[reflect.Type]asset.Asset{
+		reflect.TypeOf(rootCA):       rootCA,
+		reflect.TypeOf(bootstrapIgn): bootstrapIgn,
+	}}
+	infos, err := Certificates(store, []asset.WritableAsset{&consumer{}})
+	require.NoError(t, err)
+	require.Len(t, infos, 2)
+
+	assert.Equal(t, "Root CA", infos[0].Source)
+	assert.Equal(t, "tls/root-ca.crt", infos[0].File)
+	assert.Equal(t, "CN=root-ca,OU=openshift", infos[0].Subject)
+	assert.Equal(t, "CN=root-ca,OU=openshift", infos[0].Issuer)
+	assert.Equal(t, "ECDSA P-256", infos[0].KeyType)
+	assert.True(t, infos[0].IsCA)
+	assert.Equal(t, []string{"Consumer"}, infos[0].ConsumedBy)
+
+	assert.Equal(t, "bootstrap.ign", infos[1].Source)
+	assert.Equal(t, "/opt/openshift/tls/root-ca.crt", infos[1].File)
+	assert.Equal(t, infos[0].NotAfter, infos[1].NotAfter)
+}
@@ -0,0 +1,322 @@
+package explain
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	igntypes "github.com/coreos/ignition/v2/config/v3_2/types"
+	"github.com/pkg/errors"
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/vincent-petithory/dataurl"
+)
+
+// IgnitionEntry is a file, directory, link, systemd unit, systemd drop-in, user, merged or
+// replacing config, or certificate authority of an Ignition config.
+type IgnitionEntry struct {
+	Kind string
+	Name string
+	// Attributes are the properties of the entry other than its contents, e.g. "mode=0644".
+	Attributes []string
+	// Contents are the decoded contents of the entry.
+	Contents string
+}
+
+// key identifies the entry in its Ignition config.
+func (e *IgnitionEntry) key() string {
+	return e.Kind + " " + e.Name
+}
+
+// String returns the entry, with its attributes but without its contents.
+func (e *IgnitionEntry) String() string {
+	if len(e.Attributes) == 0 {
+		return e.key()
+	}
+	return fmt.Sprintf("%s (%s)", e.key(), strings.Join(e.Attributes, " "))
+}
+
+// ParseIgnition parses the Ignition config.
+func ParseIgnition(data []byte) (*igntypes.Config, error) {
+	config := &igntypes.Config{}
+	if err := json.Unmarshal(data, config); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal the Ignition config")
+	}
+	return config, nil
+}
+
+// IgnitionEntries returns the entries of the Ignition config, sorted by kind and name, with their
+// data URLs decoded.
+func IgnitionEntries(config *igntypes.Config) ([]*IgnitionEntry, error) {
+	var entries []*IgnitionEntry
+
+	for _, merge := range config.Ignition.Config.Merge {
+		entry, err := resourceEntry("merge", merge)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	if config.Ignition.Config.Replace.Source != nil {
+		entry, err := resourceEntry("replace", config.Ignition.Config.Replace)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	for _, ca := range config.Ignition.Security.TLS.CertificateAuthorities {
+		entry, err := resourceEntry("ca", ca)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+
+	for _, f := range config.Storage.Files {
+		entry := &IgnitionEntry{Kind: "file", Name: f.Path, Attributes: nodeAttributes(f.Node)}
+		if f.Mode != nil {
+			entry.Attributes = append(entry.Attributes, fmt.Sprintf("mode=%04o", *f.Mode))
+		}
+		contents := []igntypes.Resource{f.Contents}
+		if len(f.Append) > 0 {
+			entry.Attributes = append(entry.Attributes, fmt.Sprintf("append=%d", len(f.Append)))
+			contents = append(contents, f.Append...)
+		}
+		var data []byte
+		for _, r := range contents {
+			if r.Source == nil {
+				continue
+			}
+			decoded, source, err := decodeResource(r)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to decode the contents of %s", f.Path)
+			}
+			if source != "" {
+				entry.Attributes = append(entry.Attributes, "source="+source)
+			}
+			data = append(data, decoded...)
+		}
+		entry.Contents = printable(data)
+		entries = append(entries, entry)
+	}
+	for _, d := range config.Storage.Directories {
+		entry := &IgnitionEntry{Kind: "directory", Name: d.Path, Attributes: nodeAttributes(d.Node)}
+		if d.Mode != nil {
+			entry.Attributes = append(entry.Attributes, fmt.Sprintf("mode=%04o", *d.Mode))
+		}
+		entries = append(entries, entry)
+	}
+	for _, l := range config.Storage.Links {
+		entry := &IgnitionEntry{Kind: "link", Name: l.Path, Attributes: nodeAttributes(l.Node)}
+		entry.Attributes = append(entry.Attributes, "target="+l.Target)
+		if l.Hard != nil && *l.Hard {
+			entry.Attributes = append(entry.Attributes, "hard=true")
+		}
+		entries = append(entries, entry)
+	}
+
+	for _, u := range config.Systemd.Units {
+		entry := &IgnitionEntry{Kind: "unit", Name: u.Name}
+		if u.Enabled != nil {
+			entry.Attributes = append(entry.Attributes, fmt.Sprintf("enabled=%t", *u.Enabled))
+		}
+		if u.Mask != nil && *u.Mask {
+			entry.Attributes = append(entry.Attributes, "mask=true")
+		}
+		if u.Contents != nil {
+			entry.Contents = *u.Contents
+		}
+		entries = append(entries, entry)
+		for _, d := range u.Dropins {
+			entry := &IgnitionEntry{Kind: "dropin", Name: u.Name + "/" + d.Name}
+			if d.Contents != nil {
+				entry.Contents = *d.Contents
+			}
+			entries = append(entries, entry)
+		}
+	}
+
+	for _, u := range config.Passwd.Users {
+		entry := &IgnitionEntry{Kind: "user", Name: u.Name}
+		if len(u.Groups) > 0 {
+			groups := make([]string, 0, len(u.Groups))
+			for _, g := range u.Groups {
+				groups = append(groups, string(g))
+			}
+			entry.Attributes = append(entry.Attributes, "groups="+strings.Join(groups, ","))
+		}
+		if u.PasswordHash != nil && *u.PasswordHash != "" {
+			entry.Attributes = append(entry.Attributes, "passwordHash=set")
+		}
+		keys := make([]string, 0, len(u.SSHAuthorizedKeys))
+		for _, k := range u.SSHAuthorizedKeys {
+			keys = append(keys, string(k))
+		}
+		entry.Contents = strings.Join(keys, "\n")
+		entries = append(entries, entry)
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].Kind != entries[j].Kind {
+			return entries[i].Kind < entries[j].Kind
+		}
+		return entries[i].Name < entries[j].Name
+	})
+	return entries, nil
+}
+
+// resourceEntry returns the entry of the resource, named after its source when the source is not
+// a data URL.
+func resourceEntry(kind string, r igntypes.Resource) (*IgnitionEntry, error) {
+	data, source, err := decodeResource(r)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to decode the %s resource", kind)
+	}
+	if source == "" {
+		source = "data"
+	}
+	return &IgnitionEntry{Kind: kind, Name: source, Contents: printable(data)}, nil
+}
+
+// decodeResource returns the decoded data of the resource when its source is a data URL, or
+// otherwise its source.
+func decodeResource(r igntypes.Resource) ([]byte, string, error) {
+	if r.Source == nil {
+		return nil, "", nil
+	}
+	if !strings.HasPrefix(*r.Source, "data:") {
+		return nil, *r.Source, nil
+	}
+	decoded, err := dataurl.DecodeString(*r.Source)
+	if err != nil {
+		return nil, "", err
+	}
+	data := decoded.Data
+	if r.Compression != nil && *r.Compression == "gzip" {
+		reader, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, "", errors.Wrap(err, "failed to decompress the data")
+		}
+		defer reader.Close()
+		if data, err = ioutil.ReadAll(reader); err != nil {
+			return nil, "", errors.Wrap(err, "failed to decompress the data")
+		}
+	}
+	return data, "", nil
+}
+
+// printable returns the data as a string, or a placeholder for binary data.
+func printable(data []byte) string {
+	if !utf8.Valid(data) {
+		return fmt.Sprintf("<%d bytes of binary data>", len(data))
+	}
+	return string(data)
+}
+
+// nodeAttributes returns the attributes of the owner of the node.
+func nodeAttributes(n igntypes.Node) []string {
+	var attributes []string
+	if n.User.Name != nil {
+		attributes = append(attributes, "user="+*n.User.Name)
+	} else if n.User.ID != nil {
+		attributes = append(attributes, fmt.Sprintf("user=%d", *n.User.ID))
+	}
+	if n.Group.Name != nil {
+		attributes = append(attributes, "group="+*n.Group.Name)
+	} else if n.Group.ID != nil {
+		attributes = append(attributes, fmt.Sprintf("group=%d", *n.Group.ID))
+	}
+	if n.Overwrite != nil {
+		attributes = append(attributes, fmt.Sprintf("overwrite=%t", *n.Overwrite))
+	}
+	return attributes
+}
+
+// PrintIgnitionEntries writes the entries, with their contents indented below them.
+func PrintIgnitionEntries(out io.Writer, entries []*IgnitionEntry) error {
+	for _, e := range entries {
+		if _, err := fmt.Fprintln(out, e); err != nil {
+			return err
+		}
+		if e.Contents != "" {
+			for _, line := range strings.Split(strings.TrimSuffix(e.Contents, "\n"), "\n") {
+				if _, err := fmt.Fprintf(out, "    %s\n", line); err != nil {
+					return err
+				}
+			}
+		}
+		if _, err := fmt.Fprintln(out); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DiffIgnitionEntries writes the entries that were added, removed or changed between the from
+// and to entries, with a unified diff of the changed entries. It returns the number of
+// differences.
+func DiffIgnitionEntries(out io.Writer, from []*IgnitionEntry, fromName string, to []*IgnitionEntry, toName string) (int, error) {
+	fromEntries := make(map[string]*IgnitionEntry, len(from))
+	for _, e := range from {
+		fromEntries[e.key()] = e
+	}
+	toEntries := make(map[string]*IgnitionEntry, len(to))
+	for _, e := range to {
+		toEntries[e.key()] = e
+	}
+
+	differences := 0
+	for _, e := range from {
+		if _, ok := toEntries[e.key()]; !ok {
+			differences++
+			if _, err := fmt.Fprintf(out, "- %s\n", e); err != nil {
+				return differences, err
+			}
+		}
+	}
+	for _, e := range to {
+		if _, ok := fromEntries[e.key()]; !ok {
+			differences++
+			if _, err := fmt.Fprintf(out, "+ %s\n", e); err != nil {
+				return differences, err
+			}
+		}
+	}
+	for _, e := range to {
+		old, ok := fromEntries[e.key()]
+		if !ok {
+			continue
+		}
+		diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+			A:        difflib.SplitLines(entryText(old)),
+			B:        difflib.SplitLines(entryText(e)),
+			FromFile: fromName,
+			ToFile:   toName,
+			Context:  3,
+		})
+		if err != nil {
+			return differences, errors.Wrapf(err, "failed to diff %s", e.key())
+		}
+		if diff == "" {
+			continue
+		}
+		differences++
+		if _, err := fmt.Fprintf(out, "~ %s\n%s", e.key(), diff); err != nil {
+			return differences, err
+		}
+	}
+	return differences, nil
+}
+
+// entryText returns the entry and its contents, for diffs.
+func entryText(e *IgnitionEntry) string {
+	if e.Contents == "" {
+		return e.String()
+	}
+	return e.String() + "\n" + strings.TrimSuffix(e.Contents, "\n")
+}
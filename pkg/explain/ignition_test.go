@@ -0,0 +1,127 @@
+package explain
+
+import (
+	"bytes"
+	"compress/gzip"
+	"testing"
+
+	igntypes "github.com/coreos/ignition/v2/config/v3_2/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vincent-petithory/dataurl"
+	"k8s.io/utils/pointer"
+
+	"github.com/openshift/installer/pkg/asset/ignition"
+)
+
+func gzipDataURL(t *testing.T, data string) string {
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	_, err := w.Write([]byte(data))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	return dataurl.EncodeBytes(buf.Bytes())
+}
+
+func testIgnitionConfig(t *testing.T) *igntypes.Config {
+	config := &igntypes.Config{}
+	config.Storage.Files = []igntypes.File{
+		ignition.FileFromString("/usr/local/bin/bootkube.sh", "root", 0555, "#!/bin/bash\necho bootkube\n"),
+		{
+			Node: igntypes.Node{Path: "/opt/openshift/manifests/config.yaml"},
+			FileEmbedded1: igntypes.FileEmbedded1{
+				Contents: igntypes.Resource{
+					Source:      pointer.StringPtr(gzipDataURL(t, "kind: Config\n")),
+					Compression: pointer.StringPtr("gzip"),
+				},
+			},
+		},
+	}
+	config.Systemd.Units = []igntypes.Unit{{
+		Name:     "bootkube.service",
+		Enabled:  pointer.BoolPtr(true),
+		Contents: pointer.StringPtr("[Service]\nExecStart=/usr/local/bin/bootkube.sh\n"),
+		Dropins: []igntypes.Dropin{{
+			Name:     "10-env.conf",
+			Contents: pointer.StringPtr("[Service]\nEnvironment=A=1\n"),
+		}},
+	}}
+	config.Passwd.Users = []igntypes.PasswdUser{{
+		Name:              "core",
+		SSHAuthorizedKeys: []igntypes.SSHAuthorizedKey{"ssh-ed25519 AAAA"},
+	}}
+	return config
+}
+
+func TestIgnitionEntries(t *testing.T) {
+	entries, err := IgnitionEntries(testIgnitionConfig(t))
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, PrintIgnitionEntries(&buf, entries))
+	assert.Equal(t, `dropin bootkube.service/10-env.conf
+    [Service]
+    Environment=A=1
+
+file /opt/openshift/manifests/config.yaml
+    kind: Config
+
+file /usr/local/bin/bootkube.sh (user=root overwrite=true mode=0555)
+    #!/bin/bash
+    echo bootkube
+
+unit bootkube.service (enabled=true)
+    [Service]
+    ExecStart=/usr/local/bin/bootkube.sh
+
+user core
+    ssh-ed25519 AAAA
+
+`, buf.String())
+}
+
+func TestIgnitionEntriesPointerConfig(t *testing.T) {
+	config := &igntypes.Config{}
+	config.Ignition.Config.Merge = []igntypes.Resource{{Source: pointer.StringPtr("https://api-int.example.com:22623/config/master")}}
+	config.Ignition.Security.TLS.CertificateAuthorities = []igntypes.Resource{{Source: pointer.StringPtr(dataurl.EncodeBytes([]byte("CA")))}}
+
+	entries, err := IgnitionEntries(config)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, &IgnitionEntry{Kind: "ca", Name: "data", Contents: "CA"}, entries[0])
+	assert.Equal(t, &IgnitionEntry{Kind: "merge", Name: "https://api-int.example.com:22623/config/master"}, entries[1])
+}
+
+func TestDiffIgnitionEntries(t *testing.T) {
+	from, err := IgnitionEntries(testIgnitionConfig(t))
+	require.NoError(t, err)
+
+	config := testIgnitionConfig(t)
+	config.Storage.Files[0] = ignition.FileFromString("/usr/local/bin/bootkube.sh", "root", 0555, "#!/bin/bash\necho bootkube v2\n")
+	config.Storage.Files = append(config.Storage.Files, ignition.FileFromString("/etc/motd", "root", 0644, "hello\n"))
+	config.Systemd.Units[0].Dropins = nil
+	to, err := IgnitionEntries(config)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	differences, err := DiffIgnitionEntries(&buf, from, "old.ign", to, "new.ign")
+	require.NoError(t, err)
+	assert.Equal(t, 3, differences)
+	assert.Equal(t, `- dropin bootkube.service/10-env.conf
++ file /etc/motd (user=root overwrite=true mode=0644)
+~ file /usr/local/bin/bootkube.sh
+--- old.ign
++++ new.ign
+@@ -1,3 +1,3 @@
+ file /usr/local/bin/bootkube.sh (user=root overwrite=true mode=0555)
+ #!/bin/bash
+-echo bootkube
++echo bootkube v2
+`, buf.String())
+
+	buf.Reset()
+	differences, err = DiffIgnitionEntries(&buf, from, "old.ign", from, "new.ign")
+	require.NoError(t, err)
+	assert.Equal(t, 0, differences)
+	assert.Empty(t, buf.String())
+}
@@ -53,6 +53,10 @@ func Test_PrintFields(t *testing.T) {
       ImageContentSources lists sources/repositories for the release-image content.
       ImageContentSource defines a list of sources/repositories that can be used to pull content.
 
+    keyAlgorithm <string>
+      Valid Values: "","RSA2048","RSA3072","RSA4096","ECDSAP256","ECDSAP384"
+      KeyAlgorithm is the algorithm of the private keys generated by the installer for the certificate authorities, the service account signer and the bootstrap SSH key. The keys of the certificates use the algorithm of the certificate authority that signs them. When empty, RSA2048 is used.
+
     kind <string>
       Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds
 
@@ -0,0 +1,107 @@
+package journal
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// units are the units of the bootstrap host whose journals are gathered, as in installer-gather.sh.
+var units = []string{
+	"release-image",
+	"release-image-download",
+	"crio-configure",
+	"bootkube",
+	"kubelet",
+	"crio",
+	"approve-csr",
+	"ironic",
+	"master-bmh-update",
+}
+
+// GatherBootstrap gathers the service records and the journals of the bootstrap host into the
+// log-bundle-<gatherID>.tar.gz file in directory, laid out as the bundle of installer-gather.sh. The
+// container logs and the logs of the control plane hosts are not gathered, as they are not in the journal of
+// the bootstrap host. It returns the path of the bundle.
+func GatherBootstrap(ctx context.Context, client *Client, gatherID string, directory string) (string, error) {
+	records, err := client.ServiceRecords(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	root := fmt.Sprintf("log-bundle-%s", gatherID)
+	files := map[string][]byte{}
+	for service, entries := range records {
+		data, err := json.Marshal(entries)
+		if err != nil {
+			return "", errors.Wrapf(err, "failed to marshal the service records of %s", service)
+		}
+		files[path.Join(root, "bootstrap", "services", service+".json")] = data
+	}
+	for _, unit := range units {
+		data, err := client.Journal(ctx, unit)
+		if err != nil {
+			return "", err
+		}
+		files[path.Join(root, "bootstrap", "journals", unit+".log")] = data
+	}
+
+	file := filepath.Join(directory, root+".tar.gz")
+	if err := writeBundle(file, files); err != nil {
+		return "", errors.Wrap(err, "failed to write the log bundle")
+	}
+	logrus.Debugf("Gathered %d service records and %d journals", len(records), len(units))
+	return file, nil
+}
+
+// writeBundle writes the files, keyed by their path in the bundle, to the gzipped tarball at filename.
+func writeBundle(filename string, files map[string][]byte) (err error) {
+	f, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+	}()
+
+	gz := gzip.NewWriter(f)
+	tw := tar.NewWriter(gz)
+
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	now := time.Now()
+	for _, name := range names {
+		if err := tw.WriteHeader(&tar.Header{
+			Typeflag: tar.TypeReg,
+			Name:     name,
+			Mode:     0644,
+			Size:     int64(len(files[name])),
+			ModTime:  now,
+		}); err != nil {
+			return err
+		}
+		if _, err := tw.Write(files[name]); err != nil {
+			return err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gz.Close()
+}
@@ -0,0 +1,101 @@
+package journal
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openshift/installer/pkg/gather/service"
+)
+
+func TestGatherBootstrap(t *testing.T) {
+	cases := []struct {
+		name             string
+		records          string
+		journals         map[string]string
+		status           int
+		expectedError    string
+		expectedFindings []string
+	}{
+		{
+			name:     "no records",
+			journals: map[string]string{},
+			expectedFindings: []string{
+				"The bootstrap machine did not execute the release-image.service systemd unit",
+			},
+		},
+		{
+			name: "release-image failed",
+			records: `{"SYSLOG_IDENTIFIER":"openshift-service-record","OPENSHIFT_SERVICE":"release-image","MESSAGE":"{\"phase\":\"service start\"}"}
+{"SYSLOG_IDENTIFIER":"openshift-service-record","OPENSHIFT_SERVICE":"release-image","MESSAGE":"{\"phase\":\"service end\",\"result\":\"failure\",\"errorMessage\":\"pull failed\"}"}
+`,
+			journals: map[string]string{
+				"release-image.service": "Pulling quay.io/openshift-release-dev/ocp-release@sha256:1234\n",
+			},
+			expectedFindings: []string{
+				"The bootstrap machine failed to download the release image",
+			},
+		},
+		{
+			name:          "unavailable",
+			status:        http.StatusServiceUnavailable,
+			expectedError: "failed to read the service records: unexpected status 503 Service Unavailable",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if tc.status != 0 {
+					w.WriteHeader(tc.status)
+					return
+				}
+				query := r.URL.Query()
+				if _, ok := query["boot"]; !ok || r.URL.Path != "/entries" {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				switch {
+				case query.Get("SYSLOG_IDENTIFIER") == serviceRecordIdentifier:
+					assert.Equal(t, "application/json", r.Header.Get("Accept"))
+					fmt.Fprint(w, tc.records)
+				case query.Get("_SYSTEMD_UNIT") != "":
+					assert.Equal(t, "text/plain", r.Header.Get("Accept"))
+					fmt.Fprint(w, tc.journals[query.Get("_SYSTEMD_UNIT")])
+				default:
+					w.WriteHeader(http.StatusBadRequest)
+				}
+			}))
+			defer server.Close()
+
+			directory, err := ioutil.TempDir("", "gather")
+			require.NoError(t, err)
+			defer os.RemoveAll(directory)
+
+			bundle, err := GatherBootstrap(context.Background(), newClient(server.URL, server.Client()), "1234", directory)
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, filepath.Join(directory, "log-bundle-1234.tar.gz"), bundle)
+
+			report, err := service.Analyze(bundle)
+			require.NoError(t, err)
+			findings := make([]string, 0, len(report.Findings))
+			for _, f := range report.Findings {
+				findings = append(findings, f.Message)
+			}
+			for _, expected := range tc.expectedFindings {
+				assert.Contains(t, findings, expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,133 @@
+// Package journal contains utilities that gather logs on failures from the systemd-journal-gatewayd of the
+// bootstrap host, for when the bootstrap host cannot be reached using ssh.
+package journal
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// Port is the port on which systemd-journal-gatewayd listens on the bootstrap host.
+	Port = 19531
+
+	// serviceRecordIdentifier is the syslog identifier of the journal entries of the service records, see
+	// bootstrap-service-record.sh.
+	serviceRecordIdentifier = "openshift-service-record"
+)
+
+// Client reads the journal of a host from its systemd-journal-gatewayd.
+type Client struct {
+	baseURL string
+	client  *http.Client
+}
+
+// NewClient creates a new client for the systemd-journal-gatewayd listening on address.
+//
+// The client authenticates with the cert and key, and trusts the server certificates signed by the CA. The host name
+// of the server is not verified, as the certificate of the bootstrap host is not issued for its address.
+func NewClient(address string, ca, cert, key []byte) (*Client, error) {
+	roots := x509.NewCertPool()
+	if !roots.AppendCertsFromPEM(ca) {
+		return nil, errors.New("failed to parse the CA certificate")
+	}
+	clientCert, err := tls.X509KeyPair(cert, key)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse the client certificate")
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{
+		Certificates: []tls.Certificate{clientCert},
+		// The chain is verified in VerifyConnection, without the host name.
+		InsecureSkipVerify: true,
+		VerifyConnection: func(state tls.ConnectionState) error {
+			if len(state.PeerCertificates) == 0 {
+				return errors.New("the server did not present a certificate")
+			}
+			intermediates := x509.NewCertPool()
+			for _, c := range state.PeerCertificates[1:] {
+				intermediates.AddCert(c)
+			}
+			_, err := state.PeerCertificates[0].Verify(x509.VerifyOptions{
+				Roots:         roots,
+				Intermediates: intermediates,
+				KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+			})
+			return err
+		},
+	}
+	return newClient(fmt.Sprintf("https://%s", address), &http.Client{Transport: transport}), nil
+}
+
+func newClient(baseURL string, client *http.Client) *Client {
+	return &Client{baseURL: baseURL, client: client}
+}
+
+// Journal returns the journal of the unit for the current boot, in the short output format of journalctl.
+func (c *Client) Journal(ctx context.Context, unit string) ([]byte, error) {
+	body, err := c.entries(ctx, "text/plain", "_SYSTEMD_UNIT", unit+".service")
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read the journal of %s", unit)
+	}
+	defer body.Close()
+	return ioutil.ReadAll(body)
+}
+
+// ServiceRecords returns the entries recorded by the services of the bootstrap host for the current boot, keyed by
+// the name of the service. The entries of a service are in the order in which they were recorded.
+func (c *Client) ServiceRecords(ctx context.Context) (map[string][]json.RawMessage, error) {
+	body, err := c.entries(ctx, "application/json", "SYSLOG_IDENTIFIER", serviceRecordIdentifier)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read the service records")
+	}
+	defer body.Close()
+
+	records := map[string][]json.RawMessage{}
+	decoder := json.NewDecoder(body)
+	for {
+		entry := struct {
+			Service string `json:"OPENSHIFT_SERVICE"`
+			Message string `json:"MESSAGE"`
+		}{}
+		if err := decoder.Decode(&entry); err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, errors.Wrap(err, "failed to decode the service records")
+		}
+		if entry.Service == "" || !json.Valid([]byte(entry.Message)) {
+			continue
+		}
+		records[entry.Service] = append(records[entry.Service], json.RawMessage(entry.Message))
+	}
+	return records, nil
+}
+
+// entries requests the entries of the current boot with the field matching the value.
+func (c *Client) entries(ctx context.Context, accept string, field string, value string) (io.ReadCloser, error) {
+	query := "boot&" + url.Values{field: []string{value}}.Encode()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/entries?%s", c.baseURL, query), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", accept)
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, errors.Errorf("unexpected status %s", resp.Status)
+	}
+	return resp.Body, nil
+}
@@ -1,16 +1,12 @@
 package service
 
 import (
-	"archive/tar"
-	"compress/gzip"
 	"encoding/json"
 	"io"
 	"os"
 	"regexp"
-	"strings"
 
 	"github.com/pkg/errors"
-	"github.com/sirupsen/logrus"
 )
 
 // regex matching the path of a service entries file. The captured group is the name of the service.
@@ -24,80 +20,36 @@ var serviceEntriesFilePathRegex = regexp.MustCompile(`^[^\/]+(?:\/log-bundle-boo
 // Analysis will be logged.
 // Returns an error if there was a problem reading the bundle.
 func AnalyzeGatherBundle(bundlePath string) error {
+	report, err := Analyze(bundlePath)
+	if err != nil {
+		return err
+	}
+	report.Log()
+	return nil
+}
+
+// Analyze will analyze the bootstrap gather bundle at the specified path and return the report.
+// Returns an error if there was a problem reading the bundle.
+func Analyze(bundlePath string) (*Report, error) {
 	// open the bundle file for reading
 	bundleFile, err := os.Open(bundlePath)
 	if err != nil {
-		return errors.Wrap(err, "could not open the gather bundle")
+		return nil, errors.Wrap(err, "could not open the gather bundle")
 	}
 	defer bundleFile.Close()
 	return analyzeGatherBundle(bundleFile)
 }
 
-func analyzeGatherBundle(bundleFile io.Reader) error {
-	// decompress the bundle
-	uncompressedStream, err := gzip.NewReader(bundleFile)
+func analyzeGatherBundle(bundleFile io.Reader) (*Report, error) {
+	b, err := readBundle(bundleFile)
 	if err != nil {
-		return errors.Wrap(err, "could not decompress the gather bundle")
-	}
-	defer uncompressedStream.Close()
-
-	// read through the tar for relevant files
-	tarReader := tar.NewReader(uncompressedStream)
-	serviceAnalyses := make(map[string]analysis)
-	for {
-		header, err := tarReader.Next()
-		if err == io.EOF {
-			break
-		}
-		if err != nil {
-			return errors.Wrap(err, "encountered an error reading from the gather bundle")
-		}
-		if header.Typeflag != tar.TypeReg {
-			continue
-		}
-
-		serviceEntriesFileSubmatch := serviceEntriesFilePathRegex.FindStringSubmatch(header.Name)
-		if serviceEntriesFileSubmatch == nil {
-			continue
-		}
-		serviceName := serviceEntriesFileSubmatch[1]
-
-		serviceAnalysis, err := analyzeService(tarReader)
-		if err != nil {
-			logrus.Infof("Could not analyze the %s.service: %v", serviceName, err)
-			continue
-		}
-
-		serviceAnalyses[serviceName] = serviceAnalysis
-	}
-
-	analysisChecks := []struct {
-		name  string
-		check func(analysis) bool
-	}{
-		{name: "release-image", check: checkReleaseImageDownload},
-	}
-	for _, check := range analysisChecks {
-		a := serviceAnalyses[check.name]
-		if a.starts == 0 {
-			logrus.Errorf("The bootstrap machine did not execute the %s.service systemd unit", check.name)
-			break
-		}
-		if !check.check(a) {
-			break
-		}
+		return nil, err
 	}
 
-	return nil
-}
-
-func checkReleaseImageDownload(a analysis) bool {
-	if a.successful {
-		return true
-	}
-	logrus.Error("The bootstrap machine failed to download the release image")
-	a.logLastError()
-	return false
+	return &Report{
+		Rules:    ruleNames(),
+		Findings: evaluateRules(b),
+	}, nil
 }
 
 type analysis struct {
@@ -105,6 +57,8 @@ type analysis struct {
 	starts int
 	// successful is true if the last invocation of the service ended in success
 	successful bool
+	// failed is true if the last invocation of the service ended in failure
+	failed bool
 	// failingStage is the stage that failed in the last unsuccessful invocation of the service
 	failingStage string
 	// lastError is the last error recorded in the last failure of the service
@@ -140,6 +94,7 @@ func analyzeService(r io.Reader) (analysis, error) {
 		// the service is only considered successful if the last entry is either the service ending successfully or a
 		// post-command ending successfully.
 		a.successful = entry.Result == Success && (entry.Phase == ServiceEnd || entry.Phase == PostCommandEnd)
+		a.failed = entry.Result == Failure
 
 		// save the last error
 		if entry.Result == Failure {
@@ -154,9 +109,3 @@ func analyzeService(r io.Reader) (analysis, error) {
 	}
 	return a, nil
 }
-
-func (a analysis) logLastError() {
-	for _, l := range strings.Split(a.lastError, "\n") {
-		logrus.Info(l)
-	}
-}
@@ -11,6 +11,11 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
+const releaseImageSuccess = `[
+{"phase":"service start"},
+{"phase":"service end", "result":"success"}
+]`
+
 func TestAnalyzeGatherBundle(t *testing.T) {
 	cases := []struct {
 		name           string
@@ -90,6 +95,60 @@ func TestAnalyzeGatherBundle(t *testing.T) {
 				{Level: logrus.ErrorLevel, Message: "The bootstrap machine did not execute the release-image.service systemd unit"},
 			},
 		},
+		{
+			name: "failed service",
+			files: map[string]string{
+				"log-bundle/bootstrap/services/release-image.json": releaseImageSuccess,
+				"log-bundle/bootstrap/services/bootkube.json": `[
+{"phase":"service start"},
+{"phase":"stage start", "string":"wait-for-etcd"},
+{"phase":"stage end", "string":"wait-for-etcd", "result":"failure", "errorMessage":"etcdctl failed"},
+{"phase":"service end", "result":"failure", "errorMessage":"bootkube failed"}
+]`,
+			},
+			expectedOutput: []logrus.Entry{
+				{Level: logrus.ErrorLevel, Message: "The bootkube.service systemd unit on the bootstrap machine failed in stage wait-for-etcd"},
+				{Level: logrus.InfoLevel, Message: "bootkube failed"},
+			},
+		},
+		{
+			name: "pull secret rejected",
+			files: map[string]string{
+				"log-bundle/bootstrap/services/release-image.json": releaseImageSuccess,
+				"log-bundle/bootstrap/journals/release-image.log": `Jan 01 00:00:00 bootstrap release-image-download.sh[1]: Pulling quay.io/openshift-release-dev/ocp-release@sha256:1234...
+Jan 01 00:00:01 bootstrap release-image-download.sh[1]: Error: reading manifest sha256:1234: unauthorized: authentication required
+Jan 01 00:00:01 bootstrap release-image-download.sh[1]: Error: reading manifest sha256:1234: unauthorized: authentication required
+`,
+			},
+			expectedOutput: []logrus.Entry{
+				{Level: logrus.ErrorLevel, Message: "Pulling images was rejected by the registry; verify that the pull secret grants access to the release image"},
+				{Level: logrus.InfoLevel, Message: "Jan 01 00:00:01 bootstrap release-image-download.sh[1]: Error: reading manifest sha256:1234: unauthorized: authentication required"},
+			},
+		},
+		{
+			name: "findings are ranked",
+			files: map[string]string{
+				"log-bundle/bootstrap/services/release-image.json": releaseImageSuccess,
+				"log-bundle/bootstrap/journals/bootkube.log": `Jan 01 00:00:00 bootstrap bootkube.sh[1]: Starting temporary bootstrap control plane...
+Jan 01 00:00:01 bootstrap bootkube.sh[1]: {"level":"warn","msg":"retrying of unary invoker failed","error":"etcdserver: request timed out"}
+`,
+				"log-bundle/control-plane/10.0.0.1/containers/machine-config-server-abcd.log": "http: TLS handshake error from 10.0.0.2:1234: remote error: tls: bad certificate\n",
+				"log-bundle/control-plane/10.0.0.1/containers/etcd-1234.log":                  "x509: certificate has expired or is not yet valid\n",
+				"log-bundle/control-plane/10.0.0.1/containers/etcd-1234.inspect":              `{"status":{"state":"CONTAINER_EXITED","exitCode":1,"metadata":{"name":"etcd"}}}`,
+				"log-bundle/bootstrap/containers/kube-apiserver-5678.inspect":                 `{"status":{"state":"CONTAINER_RUNNING","exitCode":0,"metadata":{"name":"kube-apiserver"}}}`,
+			},
+			expectedOutput: []logrus.Entry{
+				{Level: logrus.ErrorLevel, Message: "Certificates were rejected as expired or not yet valid, which usually means that the clocks of the hosts are not synchronized"},
+				{Level: logrus.InfoLevel, Message: "x509: certificate has expired or is not yet valid"},
+				{Level: logrus.ErrorLevel, Message: "The etcd cluster lost quorum or could not reach its members"},
+				{Level: logrus.InfoLevel, Message: `Jan 01 00:00:01 bootstrap bootkube.sh[1]: {"level":"warn","msg":"retrying of unary invoker failed","error":"etcdserver: request timed out"}`},
+				{Level: logrus.ErrorLevel, Message: "The etcd container on control plane host 10.0.0.1 exited with code 1"},
+				{Level: logrus.InfoLevel, Message: "x509: certificate has expired or is not yet valid"},
+				{Level: logrus.ErrorLevel, Message: "The temporary control plane on the bootstrap machine did not bring up the Kubernetes API"},
+				{Level: logrus.ErrorLevel, Message: "TLS connections to the machine config server failed, so the control plane hosts may not be able to fetch their Ignition configs"},
+				{Level: logrus.InfoLevel, Message: "http: TLS handshake error from 10.0.0.2:1234: remote error: tls: bad certificate"},
+			},
+		},
 	}
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -114,8 +173,10 @@ func TestAnalyzeGatherBundle(t *testing.T) {
 			gzipWriter.Close()
 			hook := test.NewLocal(logrus.StandardLogger())
 			defer hook.Reset()
-			err := analyzeGatherBundle(&gatherBuilder)
+			report, err := analyzeGatherBundle(&gatherBuilder)
 			assert.NoError(t, err, "unexpected error from analysis")
+			assert.NotNil(t, report.Findings, "findings must serialize as an empty list")
+			report.Log()
 			for i, e := range hook.Entries {
 				hook.Entries[i] = logrus.Entry{
 					Level:   e.Level,
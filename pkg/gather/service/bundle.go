@@ -0,0 +1,213 @@
+package service
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// maxLogSize is the maximum number of bytes kept from the end of each log in the gather bundle.
+const maxLogSize = 4 << 20
+
+var (
+	// regex matching the path of a file in the gather bundle. The captured group is the path of the file relative to
+	// the root of the bundle. In case the log-bundle is from bootstrap-in-place installation, the files are nested in
+	// a log-bundle-bootstrap directory.
+	bundleFilePathRegex = regexp.MustCompile(`^[^\/]+\/(?:log-bundle-bootstrap\/)?(.+)$`)
+
+	// regex matching the path of a journal or a container log, relative to the root of the bundle.
+	// For example, "bootstrap/journals/bootkube.log" or "control-plane/10.0.0.1/containers/etcd-1234.log".
+	logFilePathRegex = regexp.MustCompile(`^(?:bootstrap|control-plane\/[^\/]+)\/(?:journals|containers|pods)\/.+\.log$`)
+
+	// regex matching the path of a container inspect file, relative to the root of the bundle. The captured group
+	// is the host which ran the container.
+	inspectFilePathRegex = regexp.MustCompile(`^(bootstrap|control-plane\/[^\/]+)\/(?:containers|pods)\/.+\.inspect$`)
+)
+
+// bundle is the content of a gather bundle that is relevant to the analysis.
+type bundle struct {
+	// services are the analyses of the service entries files, keyed by the name of the service.
+	services map[string]analysis
+	// logs are the journals and container logs, keyed by the path relative to the root of the bundle.
+	logs map[string]string
+	// containers are the statuses of the containers, keyed by the path of the inspect file relative to the root of
+	// the bundle.
+	containers map[string]containerStatus
+}
+
+// containerStatus is the status of a container, as reported by `crictl inspect`.
+type containerStatus struct {
+	// host is the host which ran the container, either "bootstrap" or "control-plane/<host>".
+	host     string
+	name     string
+	state    string
+	exitCode int
+}
+
+func readBundle(bundleFile io.Reader) (*bundle, error) {
+	// decompress the bundle
+	uncompressedStream, err := gzip.NewReader(bundleFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not decompress the gather bundle")
+	}
+	defer uncompressedStream.Close()
+
+	b := &bundle{
+		services:   map[string]analysis{},
+		logs:       map[string]string{},
+		containers: map[string]containerStatus{},
+	}
+
+	// read through the tar for relevant files
+	tarReader := tar.NewReader(uncompressedStream)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "encountered an error reading from the gather bundle")
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		if serviceEntriesFileSubmatch := serviceEntriesFilePathRegex.FindStringSubmatch(header.Name); serviceEntriesFileSubmatch != nil {
+			serviceName := serviceEntriesFileSubmatch[1]
+
+			serviceAnalysis, err := analyzeService(tarReader)
+			if err != nil {
+				logrus.Infof("Could not analyze the %s.service: %v", serviceName, err)
+				continue
+			}
+
+			b.services[serviceName] = serviceAnalysis
+			continue
+		}
+
+		pathSubmatch := bundleFilePathRegex.FindStringSubmatch(header.Name)
+		if pathSubmatch == nil {
+			continue
+		}
+		path := pathSubmatch[1]
+
+		switch {
+		case logFilePathRegex.MatchString(path):
+			data, err := readTail(tarReader, header.Size, maxLogSize)
+			if err != nil {
+				return nil, errors.Wrapf(err, "could not read %s from the gather bundle", header.Name)
+			}
+			b.logs[path] = string(data)
+		case inspectFilePathRegex.MatchString(path):
+			status, err := readContainerStatus(tarReader)
+			if err != nil {
+				logrus.Debugf("Could not read the container status from %s: %v", header.Name, err)
+				continue
+			}
+			status.host = inspectFilePathRegex.FindStringSubmatch(path)[1]
+			b.containers[path] = status
+		}
+	}
+
+	return b, nil
+}
+
+// readTail returns the last max bytes of the size bytes in r.
+func readTail(r io.Reader, size int64, max int64) ([]byte, error) {
+	if size > max {
+		if _, err := io.CopyN(ioutil.Discard, r, size-max); err != nil {
+			return nil, err
+		}
+	}
+	return ioutil.ReadAll(r)
+}
+
+func readContainerStatus(r io.Reader) (containerStatus, error) {
+	inspect := struct {
+		Status struct {
+			State    string `json:"state"`
+			ExitCode int    `json:"exitCode"`
+			Metadata struct {
+				Name string `json:"name"`
+			} `json:"metadata"`
+		} `json:"status"`
+	}{}
+	if err := json.NewDecoder(r).Decode(&inspect); err != nil {
+		return containerStatus{}, err
+	}
+	return containerStatus{
+		name:     inspect.Status.Metadata.Name,
+		state:    inspect.Status.State,
+		exitCode: inspect.Status.ExitCode,
+	}, nil
+}
+
+// logPaths returns the sorted paths of the logs matching the regex.
+func (b *bundle) logPaths(pathRegex *regexp.Regexp) []string {
+	var paths []string
+	for path := range b.logs {
+		if pathRegex.MatchString(path) {
+			paths = append(paths, path)
+		}
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// matchingLines returns the distinct lines, up to max, in the logs with paths matching pathRegex that match all of
+// the line regexes.
+func (b *bundle) matchingLines(pathRegex *regexp.Regexp, max int, lineRegexes ...*regexp.Regexp) []string {
+	if max <= 0 {
+		return nil
+	}
+	seen := map[string]bool{}
+	var lines []string
+	for _, path := range b.logPaths(pathRegex) {
+		for _, line := range strings.Split(b.logs[path], "\n") {
+			if !matchesAll(line, lineRegexes) {
+				continue
+			}
+			line = strings.TrimSpace(line)
+			if seen[line] {
+				continue
+			}
+			seen[line] = true
+			lines = append(lines, line)
+			if len(lines) == max {
+				return lines
+			}
+		}
+	}
+	return lines
+}
+
+func matchesAll(line string, regexes []*regexp.Regexp) bool {
+	for _, re := range regexes {
+		if !re.MatchString(line) {
+			return false
+		}
+	}
+	return true
+}
+
+// lastLines returns the last n non-empty lines of s.
+func lastLines(s string, n int) []string {
+	var lines []string
+	for _, line := range strings.Split(strings.TrimRight(s, "\n"), "\n") {
+		if strings.TrimSpace(line) != "" {
+			lines = append(lines, line)
+		}
+	}
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	return lines
+}
@@ -1,2 +1,4 @@
-// Package service is used to analyze service json files from an installation that failing to bootstrap.
+// Package service is used to analyze the gather bundle from an installation that failed to bootstrap.
+// The service json files, journals, container logs and container statuses in the bundle are evaluated against a
+// registry of rules, and the findings are reported ordered by how likely they are to be the root cause.
 package service
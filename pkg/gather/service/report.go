@@ -0,0 +1,135 @@
+package service
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Report is the result of the analysis of a gather bundle.
+type Report struct {
+	// Rules are the names of the rules that were evaluated.
+	Rules []string `json:"rules"`
+	// Findings are the problems found by the rules, ordered by how likely they are to be the root cause.
+	Findings []Finding `json:"findings"`
+}
+
+// Log logs the findings of the report. For each finding, the message is logged as an error, followed by the
+// supporting details.
+func (r *Report) Log() {
+	for _, f := range r.Findings {
+		logrus.Error(f.Message)
+		if f.LastError != "" {
+			for _, l := range strings.Split(f.LastError, "\n") {
+				logrus.Info(l)
+			}
+		}
+		for _, l := range f.Details {
+			logrus.Info(l)
+		}
+	}
+}
+
+// WriteJSON writes the report as JSON.
+func (r *Report) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(r)
+}
+
+type junitTestSuites struct {
+	XMLName xml.Name         `xml:"testsuites"`
+	Suites  []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+}
+
+type junitFailure struct {
+	Message  string `xml:"message,attr"`
+	Type     string `xml:"type,attr"`
+	Contents string `xml:",chardata"`
+}
+
+// junitSuiteName is the name of the JUnit test suite of the report.
+const junitSuiteName = "openshift-install analyze"
+
+// WriteJUnit writes the report as JUnit XML. Each rule without findings is a passing test case, and each finding
+// is a failing test case, in the order of the findings.
+func (r *Report) WriteJUnit(w io.Writer) error {
+	suite := junitTestSuite{Name: junitSuiteName}
+
+	findingsByRule := map[string]int{}
+	for _, f := range r.Findings {
+		findingsByRule[f.Rule]++
+	}
+	for _, rule := range r.Rules {
+		if findingsByRule[rule] == 0 {
+			suite.TestCases = append(suite.TestCases, junitTestCase{Name: rule, ClassName: junitSuiteName})
+		}
+	}
+
+	seen := map[string]int{}
+	for _, f := range r.Findings {
+		seen[f.Rule]++
+		name := f.Rule
+		switch {
+		case f.Service != "":
+			name = fmt.Sprintf("%s/%s", f.Rule, f.Service)
+		case seen[f.Rule] > 1:
+			name = fmt.Sprintf("%s #%d", f.Rule, seen[f.Rule])
+		}
+		suite.TestCases = append(suite.TestCases, junitTestCase{
+			Name:      name,
+			ClassName: junitSuiteName,
+			Failure: &junitFailure{
+				Message:  f.Message,
+				Type:     f.Reason,
+				Contents: f.contents(),
+			},
+		})
+		suite.Failures++
+	}
+	suite.Tests = len(suite.TestCases)
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(junitTestSuites{Suites: []junitTestSuite{suite}}); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// contents returns the details of the finding as text.
+func (f Finding) contents() string {
+	var lines []string
+	if f.Service != "" {
+		lines = append(lines, fmt.Sprintf("Service: %s", f.Service))
+	}
+	if f.FailingStage != "" {
+		lines = append(lines, fmt.Sprintf("Failing stage: %s", f.FailingStage))
+	}
+	if f.LastError != "" {
+		lines = append(lines, fmt.Sprintf("Last error:\n%s", f.LastError))
+	}
+	lines = append(lines, f.Details...)
+	return strings.Join(lines, "\n")
+}
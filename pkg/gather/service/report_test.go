@@ -0,0 +1,75 @@
+package service
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testReport = &Report{
+	Rules: []string{"failed-services", "pull-secret", "release-image"},
+	Findings: []Finding{
+		{
+			Rule:      "release-image",
+			Reason:    "ReleaseImageDownloadFailed",
+			Message:   "The bootstrap machine failed to download the release image",
+			Service:   "release-image",
+			LastError: "Line 1\nLine 2",
+		},
+		{
+			Rule:         "failed-services",
+			Reason:       "ServiceFailed",
+			Message:      "The bootkube.service systemd unit on the bootstrap machine failed in stage wait-for-etcd",
+			Service:      "bootkube",
+			FailingStage: "wait-for-etcd",
+		},
+	},
+}
+
+func TestReportWriteJSON(t *testing.T) {
+	buf := &bytes.Buffer{}
+	assert.NoError(t, testReport.WriteJSON(buf))
+	assert.Equal(t, `{
+  "rules": [
+    "failed-services",
+    "pull-secret",
+    "release-image"
+  ],
+  "findings": [
+    {
+      "rule": "release-image",
+      "reason": "ReleaseImageDownloadFailed",
+      "message": "The bootstrap machine failed to download the release image",
+      "service": "release-image",
+      "lastError": "Line 1\nLine 2"
+    },
+    {
+      "rule": "failed-services",
+      "reason": "ServiceFailed",
+      "message": "The bootkube.service systemd unit on the bootstrap machine failed in stage wait-for-etcd",
+      "service": "bootkube",
+      "failingStage": "wait-for-etcd"
+    }
+  ]
+}
+`, buf.String())
+}
+
+func TestReportWriteJUnit(t *testing.T) {
+	buf := &bytes.Buffer{}
+	assert.NoError(t, testReport.WriteJUnit(buf))
+	assert.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites>
+  <testsuite name="openshift-install analyze" tests="3" failures="2">
+    <testcase name="pull-secret" classname="openshift-install analyze"></testcase>
+    <testcase name="release-image/release-image" classname="openshift-install analyze">
+      <failure message="The bootstrap machine failed to download the release image" type="ReleaseImageDownloadFailed">Service: release-image&#xA;Last error:&#xA;Line 1&#xA;Line 2</failure>
+    </testcase>
+    <testcase name="failed-services/bootkube" classname="openshift-install analyze">
+      <failure message="The bootkube.service systemd unit on the bootstrap machine failed in stage wait-for-etcd" type="ServiceFailed">Service: bootkube&#xA;Failing stage: wait-for-etcd</failure>
+    </testcase>
+  </testsuite>
+</testsuites>
+`, buf.String())
+}
@@ -0,0 +1,277 @@
+package service
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// rule is a check that is evaluated against the contents of a gather bundle.
+type rule struct {
+	// name uniquely identifies the rule.
+	name string
+	// rank orders the findings of the rules in the report. Rules detecting problems that commonly cause other
+	// failures have a lower rank, so that the most likely root cause is reported first.
+	rank int
+	// check evaluates the rule against the bundle and returns the findings.
+	check func(b *bundle) []Finding
+}
+
+// Finding is a problem found by a rule.
+type Finding struct {
+	// Rule is the name of the rule that produced the finding.
+	Rule string `json:"rule"`
+	// Reason is a CamelCase string that summarizes the finding in one word. It is stable so that findings can
+	// be categorized, like the reason of diagnostics.Err.
+	Reason string `json:"reason"`
+	// Message describes the finding for end-users.
+	Message string `json:"message"`
+	// Service is the name of the service that the finding relates to, if any.
+	Service string `json:"service,omitempty"`
+	// FailingStage is the stage that failed in the last unsuccessful invocation of the service, if any.
+	FailingStage string `json:"failingStage,omitempty"`
+	// LastError is the last error recorded in the last failure of the service, if any.
+	LastError string `json:"lastError,omitempty"`
+	// Details are the lines from the logs supporting the finding.
+	Details []string `json:"details,omitempty"`
+
+	// rank is the rank of the rule that produced the finding.
+	rank int
+}
+
+// rules is the registry of the rules that are evaluated when analyzing a gather bundle.
+var rules = map[string]rule{}
+
+// registerRule adds the rule to the registry. It panics if a rule with the same name has already been registered.
+func registerRule(r rule) {
+	if _, ok := rules[r.name]; ok {
+		panic(fmt.Sprintf("analysis rule %q registered twice", r.name))
+	}
+	rules[r.name] = r
+}
+
+// ruleNames returns the sorted names of the registered rules.
+func ruleNames() []string {
+	names := make([]string, 0, len(rules))
+	for name := range rules {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// evaluateRules evaluates all of the registered rules against the bundle and returns the findings ordered by rank.
+func evaluateRules(b *bundle) []Finding {
+	findings := []Finding{}
+	for _, name := range ruleNames() {
+		r := rules[name]
+		for _, f := range r.check(b) {
+			f.Rule = r.name
+			f.rank = r.rank
+			findings = append(findings, f)
+		}
+	}
+	sort.SliceStable(findings, func(i, j int) bool {
+		return findings[i].rank < findings[j].rank
+	})
+	return findings
+}
+
+const (
+	// maxDetails is the maximum number of log lines included in a finding.
+	maxDetails = 5
+
+	// controlPlaneHostPrefix is the prefix of the paths in the bundle of the files gathered from control plane hosts.
+	controlPlaneHostPrefix = "control-plane/"
+)
+
+var (
+	allLogsRegex  = regexp.MustCompile(`.`)
+	etcdLogsRegex = regexp.MustCompile(`(?:^bootstrap\/journals\/bootkube\.log|\/containers\/etcd[^\/]*\.log)$`)
+	apiServerLogs = regexp.MustCompile(`^bootstrap\/containers\/kube-apiserver-[^\/]+\.log$`)
+	mcsLogsRegex  = regexp.MustCompile(`\/(?:containers|pods)\/machine-config-server[^\/]*\.log$`)
+
+	imagePullRegex      = regexp.MustCompile(`(?i)(?:pull|manifest|image|registry)`)
+	authFailureRegex    = regexp.MustCompile(`(?i)(?:unauthorized|authentication required|invalid username\/password|access denied)`)
+	clockSkewRegex      = regexp.MustCompile(`(?:x509: certificate has expired or is not yet valid|clock difference against peer)`)
+	etcdQuorumRegex     = regexp.MustCompile(`(?:etcdserver: no leader|lost leader|etcdserver: request timed out|etcdserver: leader changed|could not connect: dial tcp)`)
+	tlsHandshakeRegex   = regexp.MustCompile(`TLS handshake error`)
+	mcsFetchRegex       = regexp.MustCompile(`:22623`)
+	x509Regex           = regexp.MustCompile(`x509: `)
+	bootstrapStartRegex = regexp.MustCompile(`Starting temporary bootstrap control plane`)
+	apiUpRegex          = regexp.MustCompile(`API is up`)
+
+	// controlPlaneContainers are the names of the containers that make up the control plane.
+	controlPlaneContainers = map[string]bool{
+		"etcd":                     true,
+		"etcd-member":              true,
+		"kube-apiserver":           true,
+		"kube-controller-manager":  true,
+		"kube-scheduler":           true,
+		"cluster-version-operator": true,
+	}
+)
+
+func init() {
+	registerRule(rule{name: "release-image", rank: 10, check: checkReleaseImageDownload})
+	registerRule(rule{name: "pull-secret", rank: 20, check: checkPullSecretAuth})
+	registerRule(rule{name: "clock-skew", rank: 30, check: checkClockSkew})
+	registerRule(rule{name: "failed-services", rank: 40, check: checkFailedServices})
+	registerRule(rule{name: "etcd-quorum", rank: 50, check: checkEtcdQuorum})
+	registerRule(rule{name: "control-plane-containers", rank: 60, check: checkControlPlaneContainers})
+	registerRule(rule{name: "api", rank: 70, check: checkAPIUp})
+	registerRule(rule{name: "machine-config-server-tls", rank: 80, check: checkMachineConfigServerTLS})
+}
+
+func checkReleaseImageDownload(b *bundle) []Finding {
+	a := b.services["release-image"]
+	if a.starts == 0 {
+		return []Finding{{
+			Reason:  "ServiceNotStarted",
+			Message: "The bootstrap machine did not execute the release-image.service systemd unit",
+			Service: "release-image",
+		}}
+	}
+	if a.successful {
+		return nil
+	}
+	return []Finding{{
+		Reason:       "ReleaseImageDownloadFailed",
+		Message:      "The bootstrap machine failed to download the release image",
+		Service:      "release-image",
+		FailingStage: a.failingStage,
+		LastError:    a.lastError,
+	}}
+}
+
+func checkPullSecretAuth(b *bundle) []Finding {
+	lines := b.matchingLines(allLogsRegex, maxDetails, authFailureRegex, imagePullRegex)
+	if len(lines) == 0 {
+		return nil
+	}
+	return []Finding{{
+		Reason:  "PullSecretAuthFailure",
+		Message: "Pulling images was rejected by the registry; verify that the pull secret grants access to the release image",
+		Details: lines,
+	}}
+}
+
+func checkClockSkew(b *bundle) []Finding {
+	lines := b.matchingLines(allLogsRegex, maxDetails, clockSkewRegex)
+	if len(lines) == 0 {
+		return nil
+	}
+	return []Finding{{
+		Reason:  "ClockSkew",
+		Message: "Certificates were rejected as expired or not yet valid, which usually means that the clocks of the hosts are not synchronized",
+		Details: lines,
+	}}
+}
+
+func checkFailedServices(b *bundle) []Finding {
+	names := make([]string, 0, len(b.services))
+	for name := range b.services {
+		// the release image is covered by its own rule
+		if name != "release-image" {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	findings := []Finding{}
+	for _, name := range names {
+		a := b.services[name]
+		if !a.failed {
+			continue
+		}
+		message := fmt.Sprintf("The %s.service systemd unit on the bootstrap machine failed", name)
+		if a.failingStage != "" {
+			message = fmt.Sprintf("%s in stage %s", message, a.failingStage)
+		}
+		findings = append(findings, Finding{
+			Reason:       "ServiceFailed",
+			Message:      message,
+			Service:      name,
+			FailingStage: a.failingStage,
+			LastError:    a.lastError,
+		})
+	}
+	return findings
+}
+
+func checkEtcdQuorum(b *bundle) []Finding {
+	lines := b.matchingLines(etcdLogsRegex, maxDetails, etcdQuorumRegex)
+	if len(lines) == 0 {
+		return nil
+	}
+	return []Finding{{
+		Reason:  "EtcdQuorumLost",
+		Message: "The etcd cluster lost quorum or could not reach its members",
+		Details: lines,
+	}}
+}
+
+func checkControlPlaneContainers(b *bundle) []Finding {
+	paths := make([]string, 0, len(b.containers))
+	for path := range b.containers {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	type failure struct {
+		host, name string
+	}
+	reported := map[failure]bool{}
+	findings := []Finding{}
+	for _, path := range paths {
+		c := b.containers[path]
+		if !controlPlaneContainers[c.name] || c.state != "CONTAINER_EXITED" || c.exitCode == 0 {
+			continue
+		}
+		if reported[failure{host: c.host, name: c.name}] {
+			continue
+		}
+		reported[failure{host: c.host, name: c.name}] = true
+		host := "the bootstrap machine"
+		if strings.HasPrefix(c.host, controlPlaneHostPrefix) {
+			host = fmt.Sprintf("control plane host %s", strings.TrimPrefix(c.host, controlPlaneHostPrefix))
+		}
+		findings = append(findings, Finding{
+			Reason:  "ControlPlaneContainerFailed",
+			Message: fmt.Sprintf("The %s container on %s exited with code %d", c.name, host, c.exitCode),
+			Details: lastLines(b.logs[strings.TrimSuffix(path, ".inspect")+".log"], maxDetails),
+		})
+	}
+	return findings
+}
+
+func checkAPIUp(b *bundle) []Finding {
+	bootkube, ok := b.logs["bootstrap/journals/bootkube.log"]
+	if !ok || !bootstrapStartRegex.MatchString(bootkube) || apiUpRegex.MatchString(bootkube) {
+		return nil
+	}
+	var details []string
+	if paths := b.logPaths(apiServerLogs); len(paths) > 0 {
+		details = lastLines(b.logs[paths[len(paths)-1]], maxDetails)
+	}
+	return []Finding{{
+		Reason:  "APINotUp",
+		Message: "The temporary control plane on the bootstrap machine did not bring up the Kubernetes API",
+		Service: "bootkube",
+		Details: details,
+	}}
+}
+
+func checkMachineConfigServerTLS(b *bundle) []Finding {
+	lines := b.matchingLines(mcsLogsRegex, maxDetails, tlsHandshakeRegex)
+	lines = append(lines, b.matchingLines(allLogsRegex, maxDetails-len(lines), mcsFetchRegex, x509Regex)...)
+	if len(lines) == 0 {
+		return nil
+	}
+	return []Finding{{
+		Reason:  "MachineConfigServerTLSError",
+		Message: "TLS connections to the machine config server failed, so the control plane hosts may not be able to fetch their Ignition configs",
+		Details: lines,
+	}}
+}
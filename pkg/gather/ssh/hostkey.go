@@ -0,0 +1,84 @@
+package ssh
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+// HostKeyVerifier verifies the host keys presented by the SSH servers.
+type HostKeyVerifier struct {
+	callback ssh.HostKeyCallback
+	// algorithms are the host key algorithms accepted from the server. The default algorithms are used when empty.
+	algorithms []string
+}
+
+// PinnedHostKey returns a verifier that only accepts the host key, in the authorized_keys format.
+func PinnedHostKey(authorizedKey []byte) (*HostKeyVerifier, error) {
+	key, _, _, _, err := ssh.ParseAuthorizedKey(authorizedKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse the host key")
+	}
+	return &HostKeyVerifier{
+		callback: ssh.FixedHostKey(key),
+		// Only negotiate the pinned key, as the server may also have keys of other types.
+		algorithms: []string{key.Type()},
+	}, nil
+}
+
+// KnownHosts returns a verifier that accepts the host keys in the known_hosts file at path. The keys of the hosts
+// that are not in the file are trusted on first use and added to the file, while the keys of the hosts that are in
+// the file with different keys are rejected.
+func KnownHosts(path string) (*HostKeyVerifier, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, errors.Wrap(err, "failed to create the known hosts directory")
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_RDONLY, 0600)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create the known hosts file")
+	}
+	f.Close()
+
+	known, err := knownhosts.New(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read the known hosts file %q", path)
+	}
+	return &HostKeyVerifier{
+		callback: func(hostname string, remote net.Addr, key ssh.PublicKey) error {
+			err := known(hostname, remote, key)
+			var keyErr *knownhosts.KeyError
+			if !errors.As(err, &keyErr) {
+				return err
+			}
+			if len(keyErr.Want) > 0 {
+				return errors.Errorf("the host key of %s does not match the key in %q; if the host was reinstalled, remove its entry from the file", hostname, path)
+			}
+			return addKnownHost(path, hostname, key)
+		},
+	}, nil
+}
+
+// InsecureIgnoreHostKey returns a verifier that accepts any host key.
+func InsecureIgnoreHostKey() *HostKeyVerifier {
+	return &HostKeyVerifier{callback: ssh.InsecureIgnoreHostKey()}
+}
+
+// addKnownHost adds the key of the host to the known_hosts file at path.
+func addKnownHost(path string, hostname string, key ssh.PublicKey) error {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return errors.Wrap(err, "failed to open the known hosts file")
+	}
+	defer f.Close()
+	if _, err := fmt.Fprintln(f, knownhosts.Line([]string{knownhosts.Normalize(hostname)}, key)); err != nil {
+		return errors.Wrap(err, "failed to add the host key to the known hosts file")
+	}
+	logrus.Infof("Added the %s host key of %s to %q", key.Type(), hostname, path)
+	return nil
+}
@@ -0,0 +1,68 @@
+package ssh
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+)
+
+func newHostKey(t *testing.T) ssh.PublicKey {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	pub, err := ssh.NewPublicKey(&key.PublicKey)
+	require.NoError(t, err)
+	return pub
+}
+
+func TestPinnedHostKey(t *testing.T) {
+	pinned := newHostKey(t)
+	verifier, err := PinnedHostKey(ssh.MarshalAuthorizedKey(pinned))
+	require.NoError(t, err)
+	assert.Equal(t, []string{ssh.KeyAlgoECDSA256}, verifier.algorithms)
+
+	remote := &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 22}
+	assert.NoError(t, verifier.callback("10.0.0.1:22", remote, pinned))
+	assert.Error(t, verifier.callback("10.0.0.1:22", remote, newHostKey(t)))
+
+	_, err = PinnedHostKey([]byte("not a key"))
+	assert.EqualError(t, err, "failed to parse the host key: ssh: no key found")
+}
+
+func TestKnownHosts(t *testing.T) {
+	dir, err := ioutil.TempDir("", "known-hosts")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "ssh", "known_hosts")
+
+	verifier, err := KnownHosts(path)
+	require.NoError(t, err)
+
+	first := newHostKey(t)
+	second := newHostKey(t)
+	remote := &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 22}
+
+	// trusted on first use
+	assert.NoError(t, verifier.callback("10.0.0.1:22", remote, first))
+	data, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "10.0.0.1 ecdsa-sha2-nistp256 ")
+
+	// verified on the following uses, including by new verifiers
+	verifier, err = KnownHosts(path)
+	require.NoError(t, err)
+	assert.NoError(t, verifier.callback("10.0.0.1:22", remote, first))
+	assert.EqualError(t, verifier.callback("10.0.0.1:22", remote, second),
+		`the host key of 10.0.0.1:22 does not match the key in "`+path+`"; if the host was reinstalled, remove its entry from the file`)
+
+	// other hosts are trusted on first use
+	assert.NoError(t, verifier.callback("10.0.0.2:22", &net.TCPAddr{IP: net.ParseIP("10.0.0.2"), Port: 22}, second))
+}
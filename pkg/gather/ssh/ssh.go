@@ -18,9 +18,10 @@ import (
 )
 
 // NewClient creates a new SSH client which can be used to SSH to address using user and the keys.
+// The host key of the server is verified with the verifier.
 //
 // if keys list is empty, it tries to load the keys from the user's environment.
-func NewClient(user, address string, keys []string) (*ssh.Client, error) {
+func NewClient(user, address string, keys []string, verifier *HostKeyVerifier) (*ssh.Client, error) {
 	ag, agentType, err := getAgent(keys)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to initialize the SSH agent")
@@ -34,7 +35,8 @@ func NewClient(user, address string, keys []string) (*ssh.Client, error) {
 			// wants it.
 			ssh.PublicKeysCallback(ag.Signers),
 		},
-		HostKeyCallback: ssh.InsecureIgnoreHostKey(),
+		HostKeyCallback:   verifier.callback,
+		HostKeyAlgorithms: verifier.algorithms,
 	})
 	if err != nil {
 		if strings.Contains(err.Error(), "ssh: handshake failed: ssh: unable to authenticate") {
@@ -28,8 +28,9 @@ func StartTimer(key string) {
 }
 
 // StopTimer records the duration for the current stage sent as the key parameter and stores the information.
-func StopTimer(key string) {
-	timer.StopTimer(key)
+// It returns the recorded duration.
+func StopTimer(key string) time.Duration {
+	return timer.StopTimer(key)
 }
 
 // LogSummary prints the summary of all the times collected so far into the INFO section.
@@ -53,12 +54,13 @@ func (t *Timer) StartTimer(key string) {
 }
 
 // StopTimer records the duration for the current stage sent as the key parameter and stores the information.
+// It returns the recorded duration.
 func (t *Timer) StopTimer(key string) time.Duration {
 	if item, found := t.startTimes[key]; found {
 		duration := time.Since(item).Round(time.Second)
 		t.stageTimes[key] = duration
 	}
-	return time.Since(time.Now())
+	return t.stageTimes[key]
 }
 
 // LogSummary prints the summary of all the times collected so far into the INFO section.
@@ -0,0 +1,76 @@
+package azure
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/Azure/azure-sdk-for-go/profiles/2018-03-01/compute/mgmt/compute"
+	"github.com/Azure/azure-sdk-for-go/profiles/2018-03-01/network/mgmt/network"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/pkg/errors"
+
+	"github.com/openshift/installer/pkg/quota"
+)
+
+// UsageGetter returns the usage and limit of the quotas of the subscription
+// in a region.
+type UsageGetter interface {
+	GetComputeUsages(ctx context.Context, region string) ([]compute.Usage, error)
+	GetNetworkUsages(ctx context.Context, region string) ([]network.Usage, error)
+}
+
+// Load loads the quota information of the subscription in a region. It
+// provides the usage and limit of the regional vCPUs, of the vCPUs of each
+// virtual machine family and of the network resources, e.g.
+// "compute/standardDSv3Family" or "network/PublicIPAddresses".
+func Load(ctx context.Context, client UsageGetter, region string) ([]quota.Quota, error) {
+	computeUsages, err := client.GetComputeUsages(ctx, region)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load compute usages")
+	}
+	networkUsages, err := client.GetNetworkUsages(ctx, region)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load network usages")
+	}
+
+	quotas := make([]quota.Quota, 0, len(computeUsages)+len(networkUsages))
+	for _, usage := range computeUsages {
+		if usage.Name == nil {
+			continue
+		}
+		quotas = append(quotas, newQuota("compute", to.String(usage.Name.Value), region, int64(to.Int32(usage.CurrentValue)), to.Int64(usage.Limit)))
+	}
+	for _, usage := range networkUsages {
+		if usage.Name == nil {
+			continue
+		}
+		quotas = append(quotas, newQuota("network", to.String(usage.Name.Value), region, to.Int64(usage.CurrentValue), to.Int64(usage.Limit)))
+	}
+	return quotas, nil
+}
+
+func newQuota(service, name, region string, inUse, limit int64) quota.Quota {
+	return quota.Quota{
+		Service:   service,
+		Name:      fmt.Sprintf("%s/%s", service, name),
+		Region:    region,
+		InUse:     inUse,
+		Limit:     limit,
+		Unlimited: limit < 0,
+	}
+}
+
+// IsUnauthorized checks if the error is un authorized.
+func IsUnauthorized(err error) bool {
+	if err == nil {
+		return false
+	}
+	var dErr autorest.DetailedError
+	if errors.As(err, &dErr) {
+		code, ok := dErr.StatusCode.(int)
+		return ok && (code == http.StatusUnauthorized || code == http.StatusForbidden)
+	}
+	return false
+}
@@ -0,0 +1,66 @@
+package azure
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/profiles/2018-03-01/compute/mgmt/compute"
+	"github.com/Azure/azure-sdk-for-go/profiles/2018-03-01/network/mgmt/network"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/installer/pkg/asset/installconfig/azure/mock"
+	"github.com/openshift/installer/pkg/quota"
+)
+
+func TestLoad(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	client := mock.NewMockAPI(mockCtrl)
+	client.EXPECT().GetComputeUsages(gomock.Any(), "eastus").Return([]compute.Usage{
+		{Name: &compute.UsageName{Value: to.StringPtr("cores")}, CurrentValue: to.Int32Ptr(10), Limit: to.Int64Ptr(100)},
+		{Name: &compute.UsageName{Value: to.StringPtr("standardDSv3Family")}, CurrentValue: to.Int32Ptr(8), Limit: to.Int64Ptr(50)},
+		{CurrentValue: to.Int32Ptr(1), Limit: to.Int64Ptr(1)},
+	}, nil)
+	client.EXPECT().GetNetworkUsages(gomock.Any(), "eastus").Return([]network.Usage{
+		{Name: &network.UsageName{Value: to.StringPtr("PublicIPAddresses")}, CurrentValue: to.Int64Ptr(3), Limit: to.Int64Ptr(-1)},
+	}, nil)
+
+	quotas, err := Load(context.TODO(), client, "eastus")
+	assert.NoError(t, err)
+	assert.Equal(t, []quota.Quota{
+		{Service: "compute", Name: "compute/cores", Region: "eastus", InUse: 10, Limit: 100},
+		{Service: "compute", Name: "compute/standardDSv3Family", Region: "eastus", InUse: 8, Limit: 50},
+		{Service: "network", Name: "network/PublicIPAddresses", Region: "eastus", InUse: 3, Limit: -1, Unlimited: true},
+	}, quotas)
+}
+
+func TestIsUnauthorized(t *testing.T) {
+	cases := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{name: "nil"},
+		{name: "other error", err: errors.New("boom")},
+		{
+			name:     "forbidden",
+			err:      errors.Wrap(autorest.DetailedError{StatusCode: http.StatusForbidden}, "failed"),
+			expected: true,
+		},
+		{
+			name: "not found",
+			err:  errors.Wrap(autorest.DetailedError{StatusCode: http.StatusNotFound}, "failed"),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, IsUnauthorized(tc.err))
+		})
+	}
+}
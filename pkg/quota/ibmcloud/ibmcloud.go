@@ -0,0 +1,104 @@
+package ibmcloud
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/IBM/vpc-go-sdk/vpcv1"
+	"github.com/pkg/errors"
+
+	"github.com/openshift/installer/pkg/quota"
+)
+
+// UsageGetter lists the VPC infrastructure resources of the account in a
+// region.
+type UsageGetter interface {
+	GetFloatingIPs(ctx context.Context, region string) ([]vpcv1.FloatingIP, error)
+	GetInstances(ctx context.Context, region string) ([]vpcv1.Instance, error)
+	GetLoadBalancers(ctx context.Context, region string) ([]vpcv1.LoadBalancer, error)
+	GetVPCs(ctx context.Context, region string) ([]vpcv1.VPC, error)
+	GetVPCZonesForRegion(ctx context.Context, region string) ([]string, error)
+}
+
+// The VPC infrastructure does not provide an API for its quotas, so the
+// default quotas of an account are used. They are advisory, since the quotas
+// of the account may have been raised.
+// see https://cloud.ibm.com/docs/vpc?topic=vpc-quotas
+const (
+	// vcpuLimit is the number of vCPUs of the instances of a region.
+	vcpuLimit = 200
+	// memoryLimit is the memory, in GiB, of the instances of a region.
+	memoryLimit = 5600
+	// vpcLimit is the number of VPCs of a region.
+	vpcLimit = 10
+	// loadBalancerLimit is the number of load balancers of a region.
+	loadBalancerLimit = 50
+	// floatingIPLimit is the number of floating IPs of a zone.
+	floatingIPLimit = 20
+)
+
+// Load loads the quota information of the VPC infrastructure in a region.
+// It provides the usage and advisory default limit of the "vpc/vcpu", "vpc/memory",
+// "vpc/vpcs" and "vpc/load-balancers" quotas of the region, and of the
+// "vpc/floating-ips" quota of each of its zones.
+func Load(ctx context.Context, client UsageGetter, region string) ([]quota.Quota, error) {
+	instances, err := client.GetInstances(ctx, region)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load instances")
+	}
+	var vcpus, memory int64
+	for _, instance := range instances {
+		if instance.Vcpu != nil && instance.Vcpu.Count != nil {
+			vcpus += *instance.Vcpu.Count
+		}
+		if instance.Memory != nil {
+			memory += *instance.Memory
+		}
+	}
+
+	vpcs, err := client.GetVPCs(ctx, region)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load VPCs")
+	}
+	loadBalancers, err := client.GetLoadBalancers(ctx, region)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load load balancers")
+	}
+
+	zones, err := client.GetVPCZonesForRegion(ctx, region)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load zones")
+	}
+	floatingIPs, err := client.GetFloatingIPs(ctx, region)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load floating IPs")
+	}
+	floatingIPsByZone := map[string]int64{}
+	for _, floatingIP := range floatingIPs {
+		if floatingIP.Zone != nil && floatingIP.Zone.Name != nil {
+			floatingIPsByZone[*floatingIP.Zone.Name]++
+		}
+	}
+
+	quotas := []quota.Quota{
+		newQuota("vcpu", region, vcpus, vcpuLimit),
+		newQuota("memory", region, memory, memoryLimit),
+		newQuota("vpcs", region, int64(len(vpcs)), vpcLimit),
+		newQuota("load-balancers", region, int64(len(loadBalancers)), loadBalancerLimit),
+	}
+	for _, zone := range zones {
+		quotas = append(quotas, newQuota("floating-ips", zone, floatingIPsByZone[zone], floatingIPLimit))
+	}
+	return quotas, nil
+}
+
+func newQuota(name, region string, inUse, limit int64) quota.Quota {
+	return quota.Quota{
+		Service:  "vpc",
+		Name:     fmt.Sprintf("vpc/%s", name),
+		Region:   region,
+		InUse:    inUse,
+		Limit:    limit,
+		Advisory: true,
+	}
+}
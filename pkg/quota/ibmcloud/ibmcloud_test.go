@@ -0,0 +1,69 @@
+package ibmcloud
+
+import (
+	"context"
+	"testing"
+
+	"github.com/IBM/vpc-go-sdk/vpcv1"
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/utils/pointer"
+
+	"github.com/openshift/installer/pkg/asset/installconfig/ibmcloud/mock"
+	"github.com/openshift/installer/pkg/quota"
+)
+
+func TestLoad(t *testing.T) {
+	instance := func(vcpus, memory int64) vpcv1.Instance {
+		return vpcv1.Instance{Vcpu: &vpcv1.InstanceVcpu{Count: pointer.Int64Ptr(vcpus)}, Memory: pointer.Int64Ptr(memory)}
+	}
+	floatingIP := func(zone string) vpcv1.FloatingIP {
+		return vpcv1.FloatingIP{Zone: &vpcv1.ZoneReference{Name: pointer.StringPtr(zone)}}
+	}
+
+	cases := []struct {
+		name        string
+		instanceErr error
+		expected    []quota.Quota
+		expectedErr string
+	}{
+		{
+			name: "usages",
+			expected: []quota.Quota{
+				{Service: "vpc", Name: "vpc/vcpu", Region: "us-south", InUse: 12, Limit: 200, Advisory: true},
+				{Service: "vpc", Name: "vpc/memory", Region: "us-south", InUse: 48, Limit: 5600, Advisory: true},
+				{Service: "vpc", Name: "vpc/vpcs", Region: "us-south", InUse: 1, Limit: 10, Advisory: true},
+				{Service: "vpc", Name: "vpc/load-balancers", Region: "us-south", InUse: 2, Limit: 50, Advisory: true},
+				{Service: "vpc", Name: "vpc/floating-ips", Region: "us-south-1", InUse: 2, Limit: 20, Advisory: true},
+				{Service: "vpc", Name: "vpc/floating-ips", Region: "us-south-2", InUse: 0, Limit: 20, Advisory: true},
+			},
+		},
+		{
+			name:        "failed to list instances",
+			instanceErr: errors.New("forbidden"),
+			expectedErr: "failed to load instances: forbidden",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			client := mock.NewMockAPI(mockCtrl)
+			client.EXPECT().GetInstances(gomock.Any(), "us-south").Return([]vpcv1.Instance{instance(4, 16), instance(8, 32)}, tc.instanceErr)
+			client.EXPECT().GetVPCs(gomock.Any(), "us-south").Return([]vpcv1.VPC{{}}, nil).AnyTimes()
+			client.EXPECT().GetLoadBalancers(gomock.Any(), "us-south").Return([]vpcv1.LoadBalancer{{}, {}}, nil).AnyTimes()
+			client.EXPECT().GetVPCZonesForRegion(gomock.Any(), "us-south").Return([]string{"us-south-1", "us-south-2"}, nil).AnyTimes()
+			client.EXPECT().GetFloatingIPs(gomock.Any(), "us-south").Return([]vpcv1.FloatingIP{floatingIP("us-south-1"), floatingIP("us-south-1")}, nil).AnyTimes()
+
+			quotas, err := Load(context.TODO(), client, "us-south")
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, quotas)
+		})
+	}
+}
@@ -17,6 +17,12 @@ type Quota struct {
 	Limit int64
 
 	Unlimited bool
+
+	// Advisory is true when the limit is not enforced by the platform, for
+	// example a default quota that may have been raised, or a capacity that
+	// may be overcommitted. Constraints exceeding an advisory limit are
+	// reported as AvailableButLow rather than NotAvailable.
+	Advisory bool
 }
 
 // Constraint defines a check against availablity
@@ -88,9 +94,17 @@ func Check(quotas []Quota, checks []Constraint) ([]ConstraintReport, error) {
 			continue
 		}
 
+		unavailable := NotAvailable
+		if matched.Advisory {
+			unavailable = AvailableButLow
+		}
+
 		if check.Count > matched.Limit {
-			report.Result = NotAvailable
+			report.Result = unavailable
 			report.Message = fmt.Sprintf("the required number of resources (%d) is more than the limit of %d", check.Count, matched.Limit)
+			if matched.Advisory {
+				report.Message += ", which is not enforced"
+			}
 			reports = append(reports, report)
 			continue
 		}
@@ -99,8 +113,11 @@ func Check(quotas []Quota, checks []Constraint) ([]ConstraintReport, error) {
 		availAfterUse := avail - check.Count
 		headroom := int64(math.Ceil(0.2 * float64(matched.Limit)))
 		if check.Count > avail {
-			report.Result = NotAvailable
+			report.Result = unavailable
 			report.Message = fmt.Sprintf("the required number of resources (%d) is more than remaining quota of %d", check.Count, avail)
+			if matched.Advisory {
+				report.Message += ", whose limit is not enforced"
+			}
 			reports = append(reports, report)
 			continue
 		}
@@ -1,6 +1,10 @@
 package quota
 
-import "testing"
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
 
 func TestCheck(t *testing.T) {
 	cases := []struct {
@@ -26,3 +30,23 @@ func TestCheck(t *testing.T) {
 		})
 	}
 }
+
+func TestCheckAdvisory(t *testing.T) {
+	quotas := []Quota{
+		{Name: "cluster/cpu", Region: "cluster1", InUse: 24, Limit: 32, Advisory: true},
+		{Name: "cluster/memory", Region: "cluster1", InUse: 24, Limit: 32},
+	}
+	reports, err := Check(quotas, []Constraint{
+		{Name: "cluster/cpu", Region: "cluster1", Count: 16},
+		{Name: "cluster/cpu", Region: "cluster1", Count: 64},
+		{Name: "cluster/memory", Region: "cluster1", Count: 16},
+	})
+	assert.Error(t, err)
+	if assert.Len(t, reports, 3) {
+		assert.Equal(t, AvailableButLow, reports[0].Result)
+		assert.Equal(t, "the required number of resources (16) is more than remaining quota of 8, whose limit is not enforced", reports[0].Message)
+		assert.Equal(t, AvailableButLow, reports[1].Result)
+		assert.Equal(t, "the required number of resources (64) is more than the limit of 32, which is not enforced", reports[1].Message)
+		assert.Equal(t, NotAvailable, reports[2].Result)
+	}
+}
@@ -0,0 +1,77 @@
+package quota
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"text/tabwriter"
+)
+
+// ReportResult returns whether the check of the report passed, warned or
+// failed. Only NotAvailable fails, so that constraints exceeding an advisory
+// limit, which are AvailableButLow, only warn.
+func ReportResult(report ConstraintReport) string {
+	switch report.Result {
+	case Available:
+		return "pass"
+	case NotAvailable:
+		return "fail"
+	default:
+		return "warn"
+	}
+}
+
+// ReportsError returns an error when any of the checks of the reports failed.
+func ReportsError(reports []ConstraintReport) error {
+	failed := 0
+	for _, report := range reports {
+		if ReportResult(report) == "fail" {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d checks failed", failed, len(reports))
+	}
+	return nil
+}
+
+// printedReport is the printed form of a ConstraintReport.
+type printedReport struct {
+	Name    string `json:"name"`
+	Region  string `json:"region,omitempty"`
+	Count   int64  `json:"count,omitempty"`
+	Result  string `json:"result"`
+	Message string `json:"message"`
+}
+
+// WriteReports writes the reports to out as a pass/warn/fail table, or as
+// JSON when output is "json".
+func WriteReports(out io.Writer, reports []ConstraintReport, output string) error {
+	printed := make([]printedReport, 0, len(reports))
+	for _, report := range reports {
+		printed = append(printed, printedReport{
+			Name:    report.For.Name,
+			Region:  report.For.Region,
+			Count:   report.For.Count,
+			Result:  ReportResult(report),
+			Message: report.Message,
+		})
+	}
+
+	if output == "json" {
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(printed)
+	}
+
+	w := tabwriter.NewWriter(out, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "CHECK\tREGION\tRESULT\tMESSAGE")
+	for _, report := range printed {
+		region := report.Region
+		if region == "" {
+			region = "-"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", report.Name, region, report.Result, report.Message)
+	}
+	return w.Flush()
+}
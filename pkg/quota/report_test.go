@@ -0,0 +1,136 @@
+package quota
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteReports(t *testing.T) {
+	reports := []ConstraintReport{
+		{For: &Constraint{Name: "Install Config"}, Result: Available, Message: "passed"},
+		{For: &Constraint{Name: "compute/cores", Region: "eastus", Count: 24}, Result: AvailableButLow, Message: "the required number of resources is available but only 2 will be leftover"},
+		{For: &Constraint{Name: "Platform Permissions Check"}, Result: NotAvailable, Message: "current credentials insufficient"},
+	}
+
+	cases := []struct {
+		name     string
+		output   string
+		expected string
+	}{
+		{
+			name:   "text",
+			output: "text",
+			expected: `CHECK                       REGION  RESULT  MESSAGE
+Install Config              -       pass    passed
+compute/cores               eastus  warn    the required number of resources is available but only 2 will be leftover
+Platform Permissions Check  -       fail    current credentials insufficient
+`,
+		},
+		{
+			name:   "json",
+			output: "json",
+			expected: `[
+  {
+    "name": "Install Config",
+    "result": "pass",
+    "message": "passed"
+  },
+  {
+    "name": "compute/cores",
+    "region": "eastus",
+    "count": 24,
+    "result": "warn",
+    "message": "the required number of resources is available but only 2 will be leftover"
+  },
+  {
+    "name": "Platform Permissions Check",
+    "result": "fail",
+    "message": "current credentials insufficient"
+  }
+]
+`,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			require.NoError(t, WriteReports(buf, reports, tc.output))
+			assert.Equal(t, tc.expected, buf.String())
+		})
+	}
+}
+
+func TestReportsError(t *testing.T) {
+	advisory, _ := Check(
+		[]Quota{{Name: "cluster/cpu", Region: "cluster1", InUse: 24, Limit: 32, Advisory: true}},
+		[]Constraint{{Name: "cluster/cpu", Region: "cluster1", Count: 64}},
+	)
+	enforced, _ := Check(
+		[]Quota{{Name: "cluster/cpu", Region: "cluster1", InUse: 24, Limit: 32}},
+		[]Constraint{{Name: "cluster/cpu", Region: "cluster1", Count: 64}},
+	)
+
+	cases := []struct {
+		name            string
+		reports         []ConstraintReport
+		expectedResults []string
+		expectedError   string
+	}{
+		{
+			name: "pass",
+			reports: []ConstraintReport{
+				{For: &Constraint{Name: "Install Config"}, Result: Available},
+			},
+			expectedResults: []string{"pass"},
+		},
+		{
+			name: "warn",
+			reports: []ConstraintReport{
+				{For: &Constraint{Name: "Install Config"}, Result: Available},
+				{For: &Constraint{Name: "quota"}, Result: Unknown},
+				{For: &Constraint{Name: "compute/cores"}, Result: AvailableButLow},
+			},
+			expectedResults: []string{"pass", "warn", "warn"},
+		},
+		{
+			name:            "advisory quota exceeded",
+			reports:         advisory,
+			expectedResults: []string{"warn"},
+		},
+		{
+			name:            "enforced quota exceeded",
+			reports:         enforced,
+			expectedResults: []string{"fail"},
+			expectedError:   "1 of 1 checks failed",
+		},
+		{
+			name: "fail",
+			reports: []ConstraintReport{
+				{For: &Constraint{Name: "Install Config"}, Result: Available},
+				{For: &Constraint{Name: "compute/cores"}, Result: AvailableButLow},
+				{For: &Constraint{Name: "Platform Permissions Check"}, Result: NotAvailable},
+			},
+			expectedResults: []string{"pass", "warn", "fail"},
+			expectedError:   "1 of 3 checks failed",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			results := make([]string, 0, len(tc.reports))
+			for _, report := range tc.reports {
+				results = append(results, ReportResult(report))
+			}
+			assert.Equal(t, tc.expectedResults, results)
+
+			err := ReportsError(tc.reports)
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
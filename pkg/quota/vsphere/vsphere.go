@@ -0,0 +1,80 @@
+package vsphere
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/vmware/govmomi/vim25/types"
+
+	"github.com/openshift/installer/pkg/quota"
+)
+
+const gib = 1024 * 1024 * 1024
+
+// CapacityGetter returns the capacity and usage of the datastores and
+// clusters in the vCenter.
+type CapacityGetter interface {
+	DatastoreSummary(ctx context.Context, path string) (*types.DatastoreSummary, error)
+	ClusterSummary(ctx context.Context, path string) (*types.ClusterComputeResourceSummary, error)
+}
+
+// Load loads the capacity of the datastore and of the cluster of a
+// datacenter. vSphere has no quotas, so the capacity and current demand are
+// used as the limit and usage of:
+// - "datastore/space", the space of the datastore in GiB,
+// - "cluster/cpu", the logical processors of the cluster, and
+// - "cluster/memory", the memory of the cluster in MiB.
+// The region of the quotas is the name of the datastore or of the cluster.
+// The datastore space and the cluster CPUs are advisory, since datastores
+// may be thin provisioned and CPUs overcommitted.
+func Load(ctx context.Context, client CapacityGetter, datacenter, cluster, datastore string) ([]quota.Quota, error) {
+	datastoreSummary, err := client.DatastoreSummary(ctx, fmt.Sprintf("/%s/datastore/%s", datacenter, datastore))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to load the capacity of datastore %s", datastore)
+	}
+	clusterSummary, err := client.ClusterSummary(ctx, fmt.Sprintf("/%s/host/%s", datacenter, cluster))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to load the capacity of cluster %s", cluster)
+	}
+
+	var cpuInUse, memoryInUse int64
+	if usage := clusterSummary.UsageSummary; usage != nil {
+		// the demand is in MHz, the logical processors in use are the
+		// demand over the frequency of a logical processor
+		if clusterSummary.TotalCpu > 0 {
+			cpuInUse = ceilDiv(int64(usage.CpuDemandMhz)*int64(clusterSummary.NumCpuThreads), int64(clusterSummary.TotalCpu))
+		}
+		memoryInUse = int64(usage.MemDemandMB)
+	}
+
+	return []quota.Quota{
+		{
+			Service:  "datastore",
+			Name:     "datastore/space",
+			Region:   datastore,
+			InUse:    ceilDiv(datastoreSummary.Capacity-datastoreSummary.FreeSpace, gib),
+			Limit:    datastoreSummary.Capacity / gib,
+			Advisory: true,
+		},
+		{
+			Service:  "cluster",
+			Name:     "cluster/cpu",
+			Region:   cluster,
+			InUse:    cpuInUse,
+			Limit:    int64(clusterSummary.NumCpuThreads),
+			Advisory: true,
+		},
+		{
+			Service: "cluster",
+			Name:    "cluster/memory",
+			Region:  cluster,
+			InUse:   memoryInUse,
+			Limit:   clusterSummary.EffectiveMemory,
+		},
+	}, nil
+}
+
+func ceilDiv(a, b int64) int64 {
+	return (a + b - 1) / b
+}
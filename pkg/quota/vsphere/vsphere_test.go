@@ -0,0 +1,82 @@
+package vsphere
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/vmware/govmomi/vim25/types"
+
+	"github.com/openshift/installer/pkg/asset/installconfig/vsphere/mock"
+	"github.com/openshift/installer/pkg/quota"
+)
+
+func TestLoad(t *testing.T) {
+	cases := []struct {
+		name        string
+		cluster     *types.ClusterComputeResourceSummary
+		clusterErr  error
+		expected    []quota.Quota
+		expectedErr string
+	}{
+		{
+			name: "capacity and demand",
+			cluster: &types.ClusterComputeResourceSummary{
+				ComputeResourceSummary: types.ComputeResourceSummary{
+					TotalCpu:        64000,
+					NumCpuThreads:   32,
+					EffectiveMemory: 262144,
+				},
+				UsageSummary: &types.ClusterUsageSummary{CpuDemandMhz: 10100, MemDemandMB: 65536},
+			},
+			expected: []quota.Quota{
+				{Service: "datastore", Name: "datastore/space", Region: "datastore1", InUse: 301, Limit: 1024, Advisory: true},
+				{Service: "cluster", Name: "cluster/cpu", Region: "cluster1", InUse: 6, Limit: 32, Advisory: true},
+				{Service: "cluster", Name: "cluster/memory", Region: "cluster1", InUse: 65536, Limit: 262144},
+			},
+		},
+		{
+			name: "no usage summary",
+			cluster: &types.ClusterComputeResourceSummary{
+				ComputeResourceSummary: types.ComputeResourceSummary{
+					TotalCpu:        64000,
+					NumCpuThreads:   32,
+					EffectiveMemory: 262144,
+				},
+			},
+			expected: []quota.Quota{
+				{Service: "datastore", Name: "datastore/space", Region: "datastore1", InUse: 301, Limit: 1024, Advisory: true},
+				{Service: "cluster", Name: "cluster/cpu", Region: "cluster1", InUse: 0, Limit: 32, Advisory: true},
+				{Service: "cluster", Name: "cluster/memory", Region: "cluster1", InUse: 0, Limit: 262144},
+			},
+		},
+		{
+			name:        "missing cluster",
+			clusterErr:  errors.New("cluster '/dc1/host/cluster1' not found"),
+			expectedErr: "failed to load the capacity of cluster cluster1: cluster '/dc1/host/cluster1' not found",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			client := mock.NewMockCapacityGetter(mockCtrl)
+			client.EXPECT().DatastoreSummary(gomock.Any(), "/dc1/datastore/datastore1").Return(&types.DatastoreSummary{
+				Capacity:  1024 * gib,
+				FreeSpace: 723*gib + 1,
+			}, nil)
+			client.EXPECT().ClusterSummary(gomock.Any(), "/dc1/host/cluster1").Return(tc.cluster, tc.clusterErr)
+
+			quotas, err := Load(context.TODO(), client, "dc1", "cluster1", "datastore1")
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, quotas)
+		})
+	}
+}
@@ -0,0 +1,229 @@
+package terraform
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+
+	"github.com/hashicorp/terraform/configs"
+	"github.com/pkg/errors"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// UnknownOutputPlaceholder is the value substituted for string stage outputs
+// that are not known until the stage has been applied.
+const UnknownOutputPlaceholder = "(known after apply)"
+
+// PlanAction is the kind of change that terraform plans for a resource.
+type PlanAction string
+
+const (
+	// PlanActionCreate is a resource that will be created.
+	PlanActionCreate PlanAction = "create"
+	// PlanActionReplace is a resource that will be destroyed and re-created.
+	PlanActionReplace PlanAction = "replace"
+	// PlanActionUpdate is a resource that will be updated in place.
+	PlanActionUpdate PlanAction = "update"
+	// PlanActionDelete is a resource that will be destroyed.
+	PlanActionDelete PlanAction = "delete"
+)
+
+// ResourcePlan is the number of planned changes for a single resource type.
+type ResourcePlan struct {
+	Type    string             `json:"type"`
+	Changes map[PlanAction]int `json:"changes"`
+}
+
+// StagePlan is the summary of the terraform plan for a single stage.
+type StagePlan struct {
+	// Stage is the name of the stage.
+	Stage string `json:"stage"`
+	// Resources are the planned changes grouped by resource type, sorted by type.
+	Resources []ResourcePlan `json:"resources"`
+	// Totals are the planned changes across all resource types.
+	Totals map[PlanAction]int `json:"totals"`
+	// Outputs are the outputs of the stage which are known before apply.
+	Outputs map[string]interface{} `json:"outputs"`
+	// UnknownOutputs are the names of the outputs of the stage which are not
+	// known until apply, sorted by name.
+	UnknownOutputs []string `json:"unknownOutputs"`
+}
+
+// jsonPlan is the subset of the `terraform show -json` plan representation
+// that is needed to summarize a plan.
+type jsonPlan struct {
+	ResourceChanges []struct {
+		Type   string `json:"type"`
+		Mode   string `json:"mode"`
+		Change struct {
+			Actions []string `json:"actions"`
+		} `json:"change"`
+	} `json:"resource_changes"`
+	OutputChanges map[string]struct {
+		After        json.RawMessage `json:"after"`
+		AfterUnknown json.RawMessage `json:"after_unknown"`
+	} `json:"output_changes"`
+}
+
+// ParsePlan summarizes the JSON representation of a terraform plan, as
+// returned by Plan, for the given stage.
+func ParsePlan(stage string, data []byte) (*StagePlan, error) {
+	// Terraform debug logging may share the output with the plan, so skip
+	// ahead to the JSON document.
+	if i := bytes.IndexByte(data, '{'); i > 0 {
+		data = data[i:]
+	}
+
+	var plan jsonPlan
+	if err := json.Unmarshal(data, &plan); err != nil {
+		return nil, errors.Wrap(err, "failed to parse terraform plan")
+	}
+
+	byType := map[string]map[PlanAction]int{}
+	totals := map[PlanAction]int{}
+	for _, rc := range plan.ResourceChanges {
+		if rc.Mode == "data" {
+			continue
+		}
+		action, ok := planAction(rc.Change.Actions)
+		if !ok {
+			continue
+		}
+		if byType[rc.Type] == nil {
+			byType[rc.Type] = map[PlanAction]int{}
+		}
+		byType[rc.Type][action]++
+		totals[action]++
+	}
+
+	resources := make([]ResourcePlan, 0, len(byType))
+	for t, changes := range byType {
+		resources = append(resources, ResourcePlan{Type: t, Changes: changes})
+	}
+	sort.Slice(resources, func(i, j int) bool {
+		return resources[i].Type < resources[j].Type
+	})
+
+	outputs := make(map[string]interface{}, len(plan.OutputChanges))
+	unknownOutputs := []string{}
+	for name, oc := range plan.OutputChanges {
+		var unknown bool
+		if len(oc.AfterUnknown) > 0 {
+			// after_unknown is either a bool or, for partially-known
+			// values, a structure mirroring the value. Any structure means
+			// that the value cannot be fed into the next stage as-is.
+			if err := json.Unmarshal(oc.AfterUnknown, &unknown); err != nil {
+				unknown = true
+			}
+		}
+		if unknown || len(oc.After) == 0 {
+			unknownOutputs = append(unknownOutputs, name)
+			continue
+		}
+		var value interface{}
+		if err := json.Unmarshal(oc.After, &value); err != nil {
+			return nil, errors.Wrapf(err, "failed to parse output %q", name)
+		}
+		outputs[name] = value
+	}
+
+	sort.Strings(unknownOutputs)
+
+	return &StagePlan{
+		Stage:          stage,
+		Resources:      resources,
+		Totals:         totals,
+		Outputs:        outputs,
+		UnknownOutputs: unknownOutputs,
+	}, nil
+}
+
+// OutputsFile returns the planned outputs of the stage in the same format as
+// the outputs file written after applying the stage, so that it can be used
+// as a var file for a later stage. The outputs which are not known until
+// apply are replaced with placeholders of the type of the variable that the
+// later stage declares for them, as returned by VariableTypes.
+func (p *StagePlan) OutputsFile(variableTypes map[string]cty.Type) ([]byte, error) {
+	outputs := make(map[string]interface{}, len(p.Outputs)+len(p.UnknownOutputs))
+	for name, value := range p.Outputs {
+		outputs[name] = value
+	}
+	for _, name := range p.UnknownOutputs {
+		ty, ok := variableTypes[name]
+		if !ok {
+			ty = cty.String
+		}
+		outputs[name] = placeholderValue(ty)
+	}
+	data, err := json.Marshal(outputs)
+	return data, errors.Wrap(err, "could not marshal outputs")
+}
+
+// VariableTypes unpacks the platform-specific Terraform modules for the stage
+// into the given directory and returns the types of the variables declared by
+// the stage.
+func VariableTypes(dir string, platform string, stage Stage) (map[string]cty.Type, error) {
+	if err := unpack(dir, platform, stage.Name()); err != nil {
+		return nil, errors.Wrap(err, "failed to unpack Terraform modules")
+	}
+	return variableTypes(dir)
+}
+
+func variableTypes(dir string) (map[string]cty.Type, error) {
+	module, diags := configs.NewParser(nil).LoadConfigDir(dir)
+	if diags.HasErrors() {
+		return nil, errors.Wrap(diags, "failed to load Terraform modules")
+	}
+	types := make(map[string]cty.Type, len(module.Variables))
+	for name, variable := range module.Variables {
+		types[name] = variable.Type
+	}
+	return types, nil
+}
+
+// placeholderValue returns a value of the given type which stands in for a
+// value that is not known until apply. Collections hold a single placeholder
+// element so that indexing into them still plans.
+func placeholderValue(ty cty.Type) interface{} {
+	switch {
+	case ty == cty.Number:
+		return 0
+	case ty == cty.Bool:
+		return false
+	case ty.IsListType(), ty.IsSetType():
+		return []interface{}{placeholderValue(ty.ElementType())}
+	case ty.IsMapType():
+		return map[string]interface{}{UnknownOutputPlaceholder: placeholderValue(ty.ElementType())}
+	case ty.IsObjectType():
+		value := map[string]interface{}{}
+		for name, attributeType := range ty.AttributeTypes() {
+			value[name] = placeholderValue(attributeType)
+		}
+		return value
+	case ty.IsTupleType():
+		value := make([]interface{}, 0, len(ty.TupleElementTypes()))
+		for _, elementType := range ty.TupleElementTypes() {
+			value = append(value, placeholderValue(elementType))
+		}
+		return value
+	default:
+		return UnknownOutputPlaceholder
+	}
+}
+
+func planAction(actions []string) (PlanAction, bool) {
+	switch len(actions) {
+	case 1:
+		switch actions[0] {
+		case "create":
+			return PlanActionCreate, true
+		case "update":
+			return PlanActionUpdate, true
+		case "delete":
+			return PlanActionDelete, true
+		}
+	case 2:
+		return PlanActionReplace, true
+	}
+	return "", false
+}
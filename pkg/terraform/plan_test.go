@@ -0,0 +1,134 @@
+package terraform
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func TestParsePlan(t *testing.T) {
+	cases := []struct {
+		name      string
+		input     string
+		resources []ResourcePlan
+		totals    map[PlanAction]int
+		outputs   map[string]interface{}
+		unknown   []string
+		err       string
+	}{{
+		name: "creates and replaces",
+		input: `{
+  "resource_changes": [
+    {"type": "aws_instance", "mode": "managed", "change": {"actions": ["create"]}},
+    {"type": "aws_instance", "mode": "managed", "change": {"actions": ["create"]}},
+    {"type": "aws_lb", "mode": "managed", "change": {"actions": ["delete", "create"]}},
+    {"type": "aws_ami", "mode": "data", "change": {"actions": ["read"]}},
+    {"type": "aws_s3_bucket", "mode": "managed", "change": {"actions": ["no-op"]}}
+  ],
+  "output_changes": {
+    "vpc_id": {"actions": ["create"], "after_unknown": true},
+    "region": {"actions": ["create"], "after": "us-east-1", "after_unknown": false},
+    "subnet_ids": {"actions": ["create"], "after": ["a", null], "after_unknown": [false, true]}
+  }
+}`,
+		resources: []ResourcePlan{
+			{Type: "aws_instance", Changes: map[PlanAction]int{PlanActionCreate: 2}},
+			{Type: "aws_lb", Changes: map[PlanAction]int{PlanActionReplace: 1}},
+		},
+		totals: map[PlanAction]int{PlanActionCreate: 2, PlanActionReplace: 1},
+		outputs: map[string]interface{}{
+			"region": "us-east-1",
+		},
+		unknown: []string{"subnet_ids", "vpc_id"},
+	}, {
+		name:      "leading log output",
+		input:     "2021/01/01 [DEBUG] something\n{}",
+		resources: []ResourcePlan{},
+		totals:    map[PlanAction]int{},
+		outputs:   map[string]interface{}{},
+		unknown:   []string{},
+	}, {
+		name:  "invalid",
+		input: `{"resource_changes": {}}`,
+		err:   `^failed to parse terraform plan: .*$`,
+	}}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			plan, err := ParsePlan("test", []byte(tc.input))
+			if tc.err != "" {
+				assert.Regexp(t, tc.err, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, "test", plan.Stage)
+			assert.Equal(t, tc.resources, plan.Resources)
+			assert.Equal(t, tc.totals, plan.Totals)
+			assert.Equal(t, tc.outputs, plan.Outputs)
+			assert.Equal(t, tc.unknown, plan.UnknownOutputs)
+		})
+	}
+}
+
+func TestOutputsFile(t *testing.T) {
+	plan := &StagePlan{
+		Outputs:        map[string]interface{}{"region": "us-east-1"},
+		UnknownOutputs: []string{"vpc_id", "subnet_ids", "subnet_count", "private", "tags", "endpoint", "pair", "undeclared"},
+	}
+	data, err := plan.OutputsFile(map[string]cty.Type{
+		"vpc_id":       cty.String,
+		"subnet_ids":   cty.List(cty.String),
+		"subnet_count": cty.Number,
+		"private":      cty.Bool,
+		"tags":         cty.Map(cty.String),
+		"endpoint":     cty.Object(map[string]cty.Type{"host": cty.String, "port": cty.Number}),
+		"pair":         cty.Tuple([]cty.Type{cty.String, cty.Bool}),
+	})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+  "region": "us-east-1",
+  "vpc_id": "(known after apply)",
+  "subnet_ids": ["(known after apply)"],
+  "subnet_count": 0,
+  "private": false,
+  "tags": {"(known after apply)": "(known after apply)"},
+  "endpoint": {"host": "(known after apply)", "port": 0},
+  "pair": ["(known after apply)", false],
+  "undeclared": "(known after apply)"
+}`, string(data))
+}
+
+func TestVariableTypes(t *testing.T) {
+	dir, err := ioutil.TempDir("", "openshift-install-")
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer os.RemoveAll(dir)
+
+	err = ioutil.WriteFile(filepath.Join(dir, "variables.tf"), []byte(`
+variable "vpc_id" {
+  type = string
+}
+
+variable "subnet_ids" {
+  type = list(string)
+}
+
+variable "untyped" {
+}
+`), 0600)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	types, err := variableTypes(dir)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]cty.Type{
+		"vpc_id":     cty.String,
+		"subnet_ids": cty.List(cty.String),
+		"untyped":    cty.DynamicPseudoType,
+	}, types)
+}
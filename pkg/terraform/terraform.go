@@ -50,6 +50,43 @@ func Apply(dir string, platform string, stage Stage, extraArgs ...string) (path
 	return sf, nil
 }
 
+// Plan unpacks the platform-specific Terraform modules into the
+// given directory and then runs 'terraform init', 'terraform plan'
+// and 'terraform show -json' on the resulting plan. It returns the
+// JSON representation of the plan without changing any
+// infrastructure.
+func Plan(dir string, platform string, stage Stage, extraArgs ...string) ([]byte, error) {
+	err := unpackAndInit(dir, platform, stage.Name())
+	if err != nil {
+		return nil, err
+	}
+
+	planFile := filepath.Join(dir, fmt.Sprintf("%s.tfplan", stage.Name()))
+	defaultArgs := []string{
+		"-input=false",
+		fmt.Sprintf("-state=%s", filepath.Join(dir, stage.StateFilename())),
+		fmt.Sprintf("-out=%s", planFile),
+	}
+	args := append(defaultArgs, extraArgs...)
+	args = append(args, dir)
+
+	lpDebug := &lineprinter.LinePrinter{Print: (&lineprinter.Trimmer{WrappedPrint: logrus.Debug}).Print}
+	lpError := &lineprinter.LinePrinter{Print: (&lineprinter.Trimmer{WrappedPrint: logrus.Error}).Print}
+	defer lpDebug.Close()
+	defer lpError.Close()
+
+	errBuf := &bytes.Buffer{}
+	if exitCode := texec.Plan(dir, args, lpDebug, io.MultiWriter(errBuf, lpError)); exitCode != 0 {
+		return nil, errors.Wrap(Diagnose(errBuf.String()), "failed to plan Terraform")
+	}
+
+	outBuf := &bytes.Buffer{}
+	if exitCode := texec.Show(dir, []string{"-json", planFile}, outBuf, lpError); exitCode != 0 {
+		return nil, errors.New("failed to show Terraform plan")
+	}
+	return outBuf.Bytes(), nil
+}
+
 // Destroy unpacks the platform-specific Terraform modules into the
 // given directory and then runs 'terraform init' and 'terraform
 // destroy'.
@@ -7,5 +7,42 @@ import (
 // ClusterQuota contains the size, in cloud quota, of
 // the cluster that was created by installer.
 type ClusterQuota struct {
-	GCP *gcp.Quota `json:"gcp,omitempty"`
+	GCP          *gcp.Quota     `json:"gcp,omitempty"`
+	AWS          *PlatformQuota `json:"aws,omitempty"`
+	Azure        *PlatformQuota `json:"azure,omitempty"`
+	IBMCloud     *PlatformQuota `json:"ibmcloud,omitempty"`
+	OpenStack    *PlatformQuota `json:"openstack,omitempty"`
+	AlibabaCloud *PlatformQuota `json:"alibabacloud,omitempty"`
+}
+
+// PlatformQuota is a record of the resources, in the units that cloud quotas
+// are usually expressed in, consumed by a cluster on a platform.
+type PlatformQuota struct {
+	// Instances is the number of instances by instance type.
+	Instances map[string]int64 `json:"instances,omitempty"`
+	// VCPUs is the number of vCPUs of the instances.
+	VCPUs int64 `json:"vCPUs,omitempty"`
+	// Volumes is the number of block storage volumes.
+	Volumes int64 `json:"volumes,omitempty"`
+	// VolumeGB is the total size of the block storage volumes in GB.
+	VolumeGB int64 `json:"volumeGB,omitempty"`
+	// LoadBalancers is the number of load balancers.
+	LoadBalancers int64 `json:"loadBalancers,omitempty"`
+	// PublicIPs is the number of elastic, floating or public IP addresses.
+	PublicIPs int64 `json:"publicIPs,omitempty"`
+}
+
+// Add adds the resources in other to the quota.
+func (q *PlatformQuota) Add(other PlatformQuota) {
+	for instanceType, count := range other.Instances {
+		if q.Instances == nil {
+			q.Instances = map[string]int64{}
+		}
+		q.Instances[instanceType] += count
+	}
+	q.VCPUs += other.VCPUs
+	q.Volumes += other.Volumes
+	q.VolumeGB += other.VolumeGB
+	q.LoadBalancers += other.LoadBalancers
+	q.PublicIPs += other.PublicIPs
 }
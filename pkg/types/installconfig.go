@@ -64,6 +64,23 @@ const (
 	InternalPublishingStrategy PublishingStrategy = "Internal"
 )
 
+// KeyAlgorithm is the algorithm, and its size, of the private keys generated by the installer.
+// +kubebuilder:validation:Enum="";RSA2048;RSA3072;RSA4096;ECDSAP256;ECDSAP384
+type KeyAlgorithm string
+
+const (
+	// KeyAlgorithmRSA2048 generates RSA keys of 2048 bits.
+	KeyAlgorithmRSA2048 KeyAlgorithm = "RSA2048"
+	// KeyAlgorithmRSA3072 generates RSA keys of 3072 bits.
+	KeyAlgorithmRSA3072 KeyAlgorithm = "RSA3072"
+	// KeyAlgorithmRSA4096 generates RSA keys of 4096 bits.
+	KeyAlgorithmRSA4096 KeyAlgorithm = "RSA4096"
+	// KeyAlgorithmECDSAP256 generates ECDSA keys on the NIST P-256 curve.
+	KeyAlgorithmECDSAP256 KeyAlgorithm = "ECDSAP256"
+	// KeyAlgorithmECDSAP384 generates ECDSA keys on the NIST P-384 curve.
+	KeyAlgorithmECDSAP384 KeyAlgorithm = "ECDSAP384"
+)
+
 //go:generate go run ../../vendor/sigs.k8s.io/controller-tools/cmd/controller-gen crd:crdVersions=v1 paths=. output:dir=../../data/data/
 
 // InstallConfig is the configuration for an OpenShift install.
@@ -129,6 +146,13 @@ type InstallConfig struct {
 	// +optional
 	FIPS bool `json:"fips,omitempty"`
 
+	// KeyAlgorithm is the algorithm of the private keys generated by the installer for the certificate
+	// authorities, the service account signer and the bootstrap SSH key. The keys of the certificates
+	// use the algorithm of the certificate authority that signs them. When empty, RSA2048 is used.
+	//
+	// +optional
+	KeyAlgorithm KeyAlgorithm `json:"keyAlgorithm,omitempty"`
+
 	// CredentialsMode is used to explicitly set the mode with which CredentialRequests are satisfied.
 	//
 	// If this field is set, then the installer will not attempt to query the cloud permissions before attempting
@@ -1,6 +1,7 @@
 package validation
 
 import (
+	"crypto/rsa"
 	"fmt"
 	"net"
 	"net/url"
@@ -125,6 +126,9 @@ func ValidateInstallConfig(c *types.InstallConfig) field.ErrorList {
 		allErrs = append(allErrs, field.NotSupported(field.NewPath("publish"), c.Publish, validPublishingStrategyValues))
 	}
 	allErrs = append(allErrs, validateCloudCredentialsMode(c.CredentialsMode, field.NewPath("credentialsMode"), c.Platform)...)
+	if _, ok := validKeyAlgorithms[c.KeyAlgorithm]; !ok && c.KeyAlgorithm != "" {
+		allErrs = append(allErrs, field.NotSupported(field.NewPath("keyAlgorithm"), c.KeyAlgorithm, validKeyAlgorithmValues))
+	}
 
 	if c.Publish == types.InternalPublishingStrategy {
 		switch platformName := c.Platform.Name(); platformName {
@@ -599,6 +603,23 @@ var (
 		sort.Strings(v)
 		return v
 	}()
+
+	validKeyAlgorithms = map[types.KeyAlgorithm]struct{}{
+		types.KeyAlgorithmRSA2048:   {},
+		types.KeyAlgorithmRSA3072:   {},
+		types.KeyAlgorithmRSA4096:   {},
+		types.KeyAlgorithmECDSAP256: {},
+		types.KeyAlgorithmECDSAP384: {},
+	}
+
+	validKeyAlgorithmValues = func() []string {
+		v := make([]string, 0, len(validKeyAlgorithms))
+		for m := range validKeyAlgorithms {
+			v = append(v, string(m))
+		}
+		sort.Strings(v)
+		return v
+	}()
 )
 
 func validateCloudCredentialsMode(mode types.CredentialsMode, fldPath *field.Path, platform types.Platform) field.ErrorList {
@@ -684,7 +705,8 @@ func validateIPProxy(proxy string, n *types.Networking, fldPath *field.Path) fie
 
 // validateFIPSconfig checks if the current install-config is compatible with FIPS standards
 // and returns an error if it's not the case. As of this writing, only rsa or ecdsa algorithms are supported
-// for ssh keys on FIPS.
+// for ssh keys on FIPS, and rsa keys must be at least 2048 bits. All the key algorithms of the keys
+// generated by the installer are approved.
 func validateFIPSconfig(c *types.InstallConfig) field.ErrorList {
 	allErrs := field.ErrorList{}
 	sshParsedKey, _, _, _, err := ssh.ParseAuthorizedKey([]byte(c.SSHKey))
@@ -695,7 +717,14 @@ func validateFIPSconfig(c *types.InstallConfig) field.ErrorList {
 		re := regexp.MustCompile(`^ecdsa-sha2-nistp\d{3}$|^ssh-rsa$`)
 		if !re.MatchString(sshKeyType) {
 			allErrs = append(allErrs, field.Invalid(field.NewPath("sshKey"), c.SSHKey, fmt.Sprintf("SSH key type %s unavailable when FIPS is enabled. Please use rsa or ecdsa.", sshKeyType)))
+		} else if cryptoKey, ok := sshParsedKey.(ssh.CryptoPublicKey); ok {
+			if rsaKey, ok := cryptoKey.CryptoPublicKey().(*rsa.PublicKey); ok && rsaKey.N.BitLen() < fipsMinRSAKeySize {
+				allErrs = append(allErrs, field.Invalid(field.NewPath("sshKey"), c.SSHKey, fmt.Sprintf("SSH key of %d bits unavailable when FIPS is enabled. Please use a key of at least %d bits.", rsaKey.N.BitLen(), fipsMinRSAKeySize)))
+			}
 		}
 	}
 	return allErrs
 }
+
+// fipsMinRSAKeySize is the minimum size of the rsa keys approved by FIPS.
+const fipsMinRSAKeySize = 2048
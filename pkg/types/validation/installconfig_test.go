@@ -1361,6 +1361,61 @@ func TestValidateInstallConfig(t *testing.T) {
 			}(),
 			expectedError: `^credentialsMode: Unsupported value: "bad-mode": supported values: "Manual", "Mint", "Passthrough"$`,
 		},
+		{
+			name: "valid key algorithm",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.KeyAlgorithm = types.KeyAlgorithmECDSAP384
+				return c
+			}(),
+		},
+		{
+			name: "invalid key algorithm",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.KeyAlgorithm = "DSA"
+				return c
+			}(),
+			expectedError: `^keyAlgorithm: Unsupported value: "DSA": supported values: "ECDSAP256", "ECDSAP384", "RSA2048", "RSA3072", "RSA4096"$`,
+		},
+		{
+			name: "FIPS with a rsa ssh key",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.FIPS = true
+				c.SSHKey = "ssh-rsa AAAAB3NzaC1yc2EAAAADAQABAAABAQDMgIBAJJKLpWDDdaYQuOhCx4e6uLGo0qDuXYggLTO2knRLmA4xikpnMGio00c/4KyWE/Lxl2bvwPa9Mct5HjL2ryi6Bx2ADhpGjU6cgEzX8bEpZyz3WX2roVhBSWh8W/+k9A1mdBI+pWEm1v4B/ky5756yr0c/z7f15lJknXU9xYMQZ6GxccWW10NwPsLnqAIWhtmIBuGD/yAm20sOh1JzdTnQduRN9/Ka/kE8QzrQzP1OyRqrwiCw4cnTPkr+wiOLrqBbQ+3a9QnboRQ4tMx+pKqiIvDKmi2FP3OoKkEW2EA6iTT3HSFixgDHvNWiNMW7czAOhGP8eNyVm7FTaad5"
+				return c
+			}(),
+		},
+		{
+			name: "FIPS with an ecdsa key algorithm",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.FIPS = true
+				c.KeyAlgorithm = types.KeyAlgorithmECDSAP384
+				return c
+			}(),
+		},
+		{
+			name: "FIPS with a short rsa ssh key",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.FIPS = true
+				c.SSHKey = "ssh-rsa AAAAB3NzaC1yc2EAAAADAQABAAAAgQDqL142iBGpSWDC5JkxHwHvmfC1B5mIVld9SbBGgymZucaXmRyzwVzOFVcYKIISq1dTXNE+jrrZuCbL6Sl6f2Mk3GbSe96b+SRi7lFSbpDL0xmjIHr24WXi1CXQ9sSPUS+SGhBrzdF24lbmmStA1xhDzt81l3rkE/tDcm4oceOTiw=="
+				return c
+			}(),
+			expectedError: `^sshKey: Invalid value: ".*": SSH key of 1024 bits unavailable when FIPS is enabled\. Please use a key of at least 2048 bits\.$`,
+		},
+		{
+			name: "FIPS with an ed25519 ssh key",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.FIPS = true
+				c.SSHKey = "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIE9HH0+PntT3rT57K94uruolRgQbZ1p0U4wNNfADhHEf"
+				return c
+			}(),
+			expectedError: `^sshKey: Invalid value: ".*": SSH key type ssh-ed25519 unavailable when FIPS is enabled\. Please use rsa or ecdsa\.$`,
+		},
 		{
 			name: "allowed docker bridge with non-libvirt",
 			installConfig: func() *types.InstallConfig {